@@ -97,6 +97,8 @@ type TestConfig struct {
 			NetworkPool     string `json:"networkPool"`
 			StorageProfile  string `json:"storageProfile"`
 			StorageProfile2 string `json:"storageProfile2"`
+			VmGroupId       string `json:"vmGroupId,omitempty"`
+			VgpuProfileId   string `json:"vgpuProfileId,omitempty"`
 		} `json:"providerVdc"`
 		NsxtProviderVdc struct {
 			Name           string `json:"name"`
@@ -139,6 +141,7 @@ type TestConfig struct {
 		VdcGroup            string `json:"vdcGroup"`
 		VdcGroupEdgeGateway string `json:"vdcGroupEdgeGateway"`
 		NsxtImportSegment   string `json:"nsxtImportSegment"`
+		GatewayQosProfile   string `json:"gatewayQosProfile"`
 
 		NsxtAlbControllerUrl      string `json:"nsxtAlbControllerUrl"`
 		NsxtAlbControllerUser     string `json:"nsxtAlbControllerUser"`
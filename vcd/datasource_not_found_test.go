@@ -170,6 +170,19 @@ func addMandatoryParams(dataSourceName string, mandatoryFields []string, t *test
 		case "catalog":
 			testParamsNotEmpty(t, StringMap{"VCD.Catalog.Name": testConfig.VCD.Catalog.Name})
 			templateFields = templateFields + `catalog = "` + testConfig.VCD.Catalog.Name + `"` + "\n"
+		case "catalog_id":
+			testParamsNotEmpty(t, StringMap{"VCD.Org": testConfig.VCD.Org, "VCD.Catalog.Name": testConfig.VCD.Catalog.Name})
+			org, err := vcdClient.GetOrgByName(testConfig.VCD.Org)
+			if err != nil {
+				t.Skipf("Unable to lookup Org '%s': %s", testConfig.VCD.Org, err)
+				return ""
+			}
+			catalog, err := org.GetCatalogByName(testConfig.VCD.Catalog.Name, false)
+			if err != nil {
+				t.Skipf("Unable to lookup catalog '%s': %s", testConfig.VCD.Catalog.Name, err)
+				return ""
+			}
+			templateFields = templateFields + `catalog_id = "` + catalog.Catalog.ID + `"` + "\n"
 		case "vapp_name":
 			testParamsNotEmpty(t, StringMap{"VCD.Org": testConfig.VCD.Org, "testConfig.Nsxt.Vdc": testConfig.Nsxt.Vdc})
 			vapp, err := getAvailableVapp()
@@ -71,7 +71,8 @@ func datasourceNsxtTier0RouterRead(d *schema.ResourceData, meta interface{}) err
 			for _, v := range extNetwork.ExternalNetwork.NetworkBackings.Values {
 				// Very odd but when VRF Tier-0 router is used - BackingType can be UNKNOWN
 				if v.Name == tier0RouterName &&
-					(v.BackingType == types.ExternalNetworkBackingTypeNsxtTier0Router || v.BackingType == "UNKNOWN") {
+					(v.BackingType == types.ExternalNetworkBackingTypeNsxtTier0Router ||
+						v.BackingType == types.ExternalNetworkBackingTypeNsxtVrfTier0Router || v.BackingType == "UNKNOWN") {
 					dSet(d, "is_assigned", true)
 					d.SetId(v.BackingID)
 					return nil
@@ -0,0 +1,78 @@
+package vcd
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceVcdCatalogVappTemplate() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdCatalogVappTemplateRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"catalog_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the catalog containing the vApp template. It can be a catalog shared from another Organization",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the vApp template",
+			},
+			"catalog_item_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the catalog item that holds the vApp template",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the vApp template",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time stamp of when the vApp template was created",
+			},
+			"vm_names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of VM names inside the vApp template",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"vm_template_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Map of VM names to the IDs of the VMs inside the vApp template, usable in 'vm_template_id' of vcd_vapp_vm and vcd_vm",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"metadata": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Key and value pairs from the metadata of the vApp template",
+			},
+			"vm": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "VMs inside the vApp template, with their disks and network interfaces",
+				Elem:        vappTemplateVmComputed,
+			},
+		},
+	}
+}
+
+func datasourceVcdCatalogVappTemplateRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericVcdCatalogVappTemplateRead(d, meta, "datasource")
+}
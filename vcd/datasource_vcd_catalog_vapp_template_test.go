@@ -0,0 +1,102 @@
+//go:build catalog || vm || ALL || functional
+// +build catalog vm ALL functional
+
+package vcd
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccVcdCatalogVappTemplateDS checks that vcd_catalog_vapp_template data source retrieves the IDs of the
+// vApp template and its VMs, and that both IDs can be used to create VMs without catalog and template names
+func TestAccVcdCatalogVappTemplateDS(t *testing.T) {
+	preTestChecks(t)
+
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"Vdc":         testConfig.VCD.Vdc,
+		"Catalog":     testSuiteCatalogName,
+		"CatalogItem": testSuiteCatalogOVAItem,
+		"VappName":    t.Name() + "-vapp",
+		"VappVmName":  t.Name() + "-vapp-vm",
+		"VmName":      t.Name() + "-vm",
+		"FuncName":    t.Name(),
+		"Tags":        "catalog vm",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText := templateFill(testAccVcdCatalogVappTemplateDS, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckVcdVAppDestroy,
+			testAccCheckVcdStandaloneVmDestroy(params["VmName"].(string), params["Org"].(string), params["Vdc"].(string)),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.vcd_catalog_vapp_template.template", "id", regexp.MustCompile(`^urn:vcloud:vapptemplate:`)),
+					resource.TestMatchResourceAttr("data.vcd_catalog_vapp_template.template", "catalog_item_id", regexp.MustCompile(`^urn:vcloud:catalogitem:`)),
+					resource.TestCheckResourceAttrSet("data.vcd_catalog_vapp_template.template", "created"),
+					resource.TestCheckResourceAttr("data.vcd_catalog_vapp_template.template", "vm_names.#", "1"),
+					resource.TestCheckResourceAttr("data.vcd_catalog_vapp_template.template", "vm_template_ids.%", "1"),
+					resource.TestCheckResourceAttrPair("data.vcd_catalog_vapp_template.template", "id", "vcd_vm.by-vapp-template-id", "vapp_template_id"),
+					resource.TestCheckResourceAttrSet("vcd_vm.by-vapp-template-id", "id"),
+					resource.TestMatchResourceAttr("vcd_vapp_vm.by-vm-template-id", "vm_template_id", regexp.MustCompile(`^urn:vcloud:vm:`)),
+					resource.TestCheckResourceAttrSet("vcd_vapp_vm.by-vm-template-id", "id"),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdCatalogVappTemplateDS = `
+data "vcd_catalog" "catalog" {
+  org  = "{{.Org}}"
+  name = "{{.Catalog}}"
+}
+
+data "vcd_catalog_vapp_template" "template" {
+  org        = "{{.Org}}"
+  catalog_id = data.vcd_catalog.catalog.id
+  name       = "{{.CatalogItem}}"
+}
+
+resource "vcd_vm" "by-vapp-template-id" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  name             = "{{.VmName}}"
+  vapp_template_id = data.vcd_catalog_vapp_template.template.id
+  power_on         = false
+}
+
+resource "vcd_vapp" "template" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  name = "{{.VappName}}"
+}
+
+resource "vcd_vapp_vm" "by-vm-template-id" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name      = vcd_vapp.template.name
+  name           = "{{.VappVmName}}"
+  vm_template_id = data.vcd_catalog_vapp_template.template.vm_template_ids[data.vcd_catalog_vapp_template.template.vm_names[0]]
+  power_on       = false
+}
+`
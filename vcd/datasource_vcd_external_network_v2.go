@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/vmware/go-vcloud-director/v2/govcd"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -70,6 +68,31 @@ func datasourceVcdExternalNetworkV2() *schema.Resource {
 					},
 				},
 			},
+			"use_ip_spaces": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Defines if IP Spaces are used instead of 'ip_scope' for IP management",
+			},
+			"dedicated_org_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of Org to which this Provider Gateway is dedicated",
+			},
+			"route_advertisement_intention": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Route advertisement intention of Provider Gateway",
+			},
+			"used_ip_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of used IP addresses in all IP scopes",
+			},
+			"total_ip_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of IP addresses defined by static IP pools in all IP scopes",
+			},
 		},
 	}
 }
@@ -80,12 +103,18 @@ func datasourceVcdExternalNetworkV2Read(d *schema.ResourceData, meta interface{}
 
 	name := d.Get("name").(string)
 
-	extNet, err := govcd.GetExternalNetworkV2ByName(vcdClient.VCDClient, name)
+	extNet, err := getExternalNetworkV2ByName(&vcdClient.Client, name)
 	if err != nil {
 		return fmt.Errorf("could not find external network V2 by name '%s': %s", name, err)
 	}
 
-	d.SetId(extNet.ExternalNetwork.ID)
+	d.SetId(extNet.ID)
+
+	err = setExternalNetworkV2Data(d, &extNet.ExternalNetworkV2, vcdClient)
+	if err != nil {
+		return err
+	}
+	setExternalNetworkV2ExtendedData(d, extNet)
 
-	return setExternalNetworkV2Data(d, extNet.ExternalNetwork, vcdClient)
+	return nil
 }
@@ -218,6 +218,16 @@ func datasourceVcdAlbPool() *schema.Resource {
 				Computed:    true,
 				Description: "Health message",
 			},
+			"member_group_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of Firewall Group (Security Group or IP Set) used as pool members (VCD 10.4.0+)",
+			},
+			"ssl_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Defines if SSL is enabled for traffic sent to pool members (VCD 10.4.0+)",
+			},
 		},
 	}
 }
@@ -235,16 +245,17 @@ func datasourceVcdAlbPoolRead(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("could not retrieve NSX-T nsxtEdge gateway with ID '%s': %s", d.Id(), err)
 	}
 
-	albPool, err := vcdClient.GetAlbPoolByName(nsxtEdge.EdgeGateway.ID, d.Get("name").(string))
+	albPool, err := getNsxtAlbPoolByName(vcdClient, nsxtEdge.EdgeGateway.ID, d.Get("name").(string))
 	if err != nil {
 		return diag.Errorf("could not retrieve NSX-T ALB Pool '%s': %s", d.Get("name").(string), err)
 	}
 
-	err = setNsxtAlbPoolData(d, albPool.NsxtAlbPool)
+	err = setNsxtAlbPoolData(d, &albPool.NsxtAlbPool)
 	if err != nil {
 		return diag.Errorf("error setting NSX-T ALB Pool data: %s", err)
 	}
-	d.SetId(albPool.NsxtAlbPool.ID)
+	setNsxtAlbPoolExtendedData(d, albPool)
+	d.SetId(albPool.ID)
 
 	return nil
 }
@@ -42,6 +42,26 @@ func datasourceVcdAlbSettings() *schema.Resource {
 				Computed:    true,
 				Description: "Optional custom network CIDR definition for ALB Service Engine placement (VCD default is 192.168.255.1/25)",
 			},
+			"ipv6_service_network_specification": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Custom IPv6 network definition for ALB Service Engine placement (VCD 10.4.0+)",
+			},
+			"supported_feature_set": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Feature set of this Edge Gateway if ALB is enabled. One of 'STANDARD', 'PREMIUM' (VCD 10.4.0+)",
+			},
+			"is_transparent_mode_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Defines if transparent mode (preserving client IP) is enabled (VCD 10.4.1+)",
+			},
+			"license_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "License type of the NSX-T ALB Controller backing this Edge Gateway. One of 'BASIC', 'ENTERPRISE'. Only read by System Administrators",
+			},
 		},
 	}
 }
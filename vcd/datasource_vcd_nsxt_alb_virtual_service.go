@@ -67,6 +67,16 @@ func datasourceVcdAlbVirtualService() *schema.Resource {
 				Computed:    true,
 				Description: "Virtual IP address (VIP) for Virtual Service",
 			},
+			"ipv6_virtual_ip_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "IPv6 Virtual IP address (VIP) for Virtual Service (VCD 10.4.0+)",
+			},
+			"is_transparent_mode_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Preserves client IP on a Virtual Service (VCD 10.4.1+)",
+			},
 			"application_profile_type": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -90,7 +100,7 @@ func datasourceVcdAlbVirtualService() *schema.Resource {
 						"ssl_enabled": {
 							Type:        schema.TypeBool,
 							Computed:    true,
-							Description: "Starting port in the range",
+							Description: "Defines if certificate is used for this port range",
 						},
 						"type": {
 							Type:        schema.TypeString,
@@ -100,6 +110,21 @@ func datasourceVcdAlbVirtualService() *schema.Resource {
 					},
 				},
 			},
+			"health_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Health status of Virtual Service",
+			},
+			"health_message": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Health message of Virtual Service",
+			},
+			"detailed_health_message": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Detailed health message of Virtual Service",
+			},
 		},
 	}
 }
@@ -117,16 +142,17 @@ func datasourceVcdAlbVirtualServiceRead(ctx context.Context, d *schema.ResourceD
 		return diag.Errorf("could not retrieve NSX-T Edge Gateway with ID '%s': %s", d.Id(), err)
 	}
 
-	albVirtualService, err := vcdClient.GetAlbVirtualServiceByName(nsxtEdge.EdgeGateway.ID, d.Get("name").(string))
+	albVirtualService, err := getNsxtAlbVirtualServiceByName(vcdClient, nsxtEdge.EdgeGateway.ID, d.Get("name").(string))
 	if err != nil {
 		return diag.Errorf("could not retrieve NSX-T ALB Virtual Service '%s': %s", d.Get("name").(string), err)
 	}
 
-	err = setNsxtAlbVirtualServiceData(d, albVirtualService.NsxtAlbVirtualService)
+	err = setNsxtAlbVirtualServiceData(d, &albVirtualService.NsxtAlbVirtualService)
 	if err != nil {
 		return diag.Errorf("error setting NSX-T ALB Virtual Service data: %s", err)
 	}
-	d.SetId(albVirtualService.NsxtAlbVirtualService.ID)
+	setNsxtAlbVirtualServiceExtendedData(d, albVirtualService)
+	d.SetId(albVirtualService.ID)
 
 	return nil
 }
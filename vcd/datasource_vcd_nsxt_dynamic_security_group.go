@@ -0,0 +1,90 @@
+package vcd
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceVcdDynamicSecurityGroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdDynamicSecurityGroupRead,
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc_group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of VDC Group in which Dynamic Security Group is located",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Dynamic Security Group name",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Dynamic Security Group description",
+			},
+			"criteria": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Criteria for matching VMs",
+				Elem:        nsxtDynamicSecurityGroupCriteria,
+			},
+			"member_vms": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Set of VM IDs",
+				Elem:        nsxtFirewallGroupMemberVms,
+			},
+		},
+	}
+}
+
+func datasourceVcdDynamicSecurityGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	org, err := vcdClient.GetOrgFromResource(d)
+	if err != nil {
+		return diag.Errorf("[nsxt dynamic security group read] error retrieving Org: %s", err)
+	}
+
+	vdcGroup, err := org.GetVdcGroupById(d.Get("vdc_group_id").(string))
+	if err != nil {
+		return diag.Errorf("[nsxt dynamic security group read] error retrieving VDC Group: %s", err)
+	}
+
+	securityGroupName := d.Get("name").(string)
+	dynamicSecurityGroup, err := getNsxtDynamicSecurityGroupByName(&vcdClient.Client, vdcGroup.VdcGroup.Id, securityGroupName)
+	if err != nil {
+		return diag.Errorf("[nsxt dynamic security group read] error getting NSX-T Dynamic Security Group with Name '%s': %s", securityGroupName, err)
+	}
+
+	err = setNsxtDynamicSecurityGroupData(d, dynamicSecurityGroup)
+	if err != nil {
+		return diag.Errorf("[nsxt dynamic security group read] error setting NSX-T Dynamic Security Group: %s", err)
+	}
+
+	// A separate GET call is required to get all associated VMs
+	associatedVms, err := getNsxtDynamicSecurityGroupAssociatedVms(&vcdClient.Client, dynamicSecurityGroup.ID)
+	if err != nil {
+		return diag.Errorf("[nsxt dynamic security group read] error getting associated VMs for Dynamic Security Group '%s': %s", dynamicSecurityGroup.Name, err)
+	}
+
+	err = setNsxtSecurityGroupAssociatedVmsData(d, associatedVms)
+	if err != nil {
+		return diag.Errorf("[nsxt dynamic security group read] error setting associated VMs for Dynamic Security Group '%s': %s", dynamicSecurityGroup.Name, err)
+	}
+
+	d.SetId(dynamicSecurityGroup.ID)
+
+	return nil
+}
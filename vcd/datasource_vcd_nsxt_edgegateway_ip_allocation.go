@@ -0,0 +1,126 @@
+package vcd
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func datasourceVcdNsxtEdgegatewayIpAllocation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdNsxtEdgegatewayIpAllocationRead,
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"edge_gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "NSX-T Edge Gateway ID for which IP usage should be listed",
+			},
+			"unused_ip_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				Description:  "Maximum number of unused IP addresses to list. '0' lists all unused IP addresses (IPv4 only)",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"used_ip": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "IP addresses used by NAT rules, Load Balancer Virtual Services, IPSec VPN tunnels and other services",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Used IP address",
+						},
+						"category": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Category of IP address usage (e.g. 'SNAT', 'DNAT', 'LOAD_BALANCER', 'IPSEC')",
+						},
+						"entity_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of entity using the IP address",
+						},
+						"entity_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of entity using the IP address",
+						},
+					},
+				},
+			},
+			"unused_ip_addresses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IP addresses allocated to Edge Gateway which are not used (up to 'unused_ip_limit')",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func datasourceVcdNsxtEdgegatewayIpAllocationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	orgName, err := vcdClient.GetOrgNameFromResource(d)
+	if err != nil {
+		return diag.Errorf("error when getting Org name: %s", err)
+	}
+
+	nsxtEdgeGateway, err := vcdClient.GetNsxtEdgeGatewayById(orgName, d.Get("edge_gateway_id").(string))
+	if err != nil {
+		return diag.Errorf("error retrieving NSX-T Edge Gateway: %s", err)
+	}
+
+	usedIpAddresses, err := getNsxtEdgeGatewayUsedIpAddresses(&vcdClient.Client, nsxtEdgeGateway.EdgeGateway.ID)
+	if err != nil {
+		return diag.Errorf("error retrieving used IP addresses of NSX-T Edge Gateway '%s': %s",
+			nsxtEdgeGateway.EdgeGateway.Name, err)
+	}
+
+	usedIpSlice := make([]interface{}, len(usedIpAddresses))
+	for index, usedIpAddress := range usedIpAddresses {
+		usedIp := map[string]interface{}{
+			"ip_address": usedIpAddress.IpAddress,
+			"category":   usedIpAddress.Category,
+		}
+		if usedIpAddress.EntityRef != nil {
+			usedIp["entity_id"] = usedIpAddress.EntityRef.ID
+			usedIp["entity_name"] = usedIpAddress.EntityRef.Name
+		}
+		usedIpSlice[index] = usedIp
+	}
+
+	err = d.Set("used_ip", usedIpSlice)
+	if err != nil {
+		return diag.Errorf("error storing 'used_ip': %s", err)
+	}
+
+	unusedIpAddresses, err := getNsxtEdgeGatewayUnusedIpAddresses(nsxtEdgeGateway.EdgeGateway, usedIpAddresses, nil, d.Get("unused_ip_limit").(int))
+	if err != nil {
+		return diag.Errorf("error finding unused IP addresses of NSX-T Edge Gateway '%s': %s",
+			nsxtEdgeGateway.EdgeGateway.Name, err)
+	}
+
+	err = d.Set("unused_ip_addresses", unusedIpAddresses)
+	if err != nil {
+		return diag.Errorf("error storing 'unused_ip_addresses': %s", err)
+	}
+
+	d.SetId(nsxtEdgeGateway.EdgeGateway.ID)
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package vcd
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceVcdNsxtEdgegatewayL2VpnTunnel() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdNsxtEdgegatewayL2VpnTunnelRead,
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"edge_gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Edge Gateway ID in which L2 VPN Tunnel is located",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of L2 VPN Tunnel",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of L2 VPN Tunnel",
+			},
+			"session_mode": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Mode of the Edge Gateway in L2 VPN session ('SERVER' or 'CLIENT')",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Status of L2 VPN Tunnel",
+			},
+			"connector_initiation_mode": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Connector initiation mode of the session for SERVER mode",
+			},
+			"local_endpoint_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Local endpoint IP of the tunnel",
+			},
+			"remote_endpoint_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Remote endpoint IP of the tunnel",
+			},
+			"tunnel_interface": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Network CIDR block over which the session interfaces for SERVER mode",
+			},
+			"pre_shared_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Pre-shared key used for authentication",
+			},
+			"peer_code": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Base64 encoded peer code",
+			},
+			"stretched_network": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Org VDC networks which are stretched over the tunnel",
+				Elem:        nsxtL2VpnStretchedNetwork,
+				Set:         resourceVcdNsxtL2VpnStretchedNetworkHash,
+			},
+			"logging": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Logging status of the tunnel",
+			},
+		},
+	}
+}
+
+func datasourceVcdNsxtEdgegatewayL2VpnTunnelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	tunnelName := d.Get("name").(string)
+	l2VpnTunnel, err := getNsxtL2VpnTunnelByName(&vcdClient.Client, d.Get("edge_gateway_id").(string), tunnelName)
+	if err != nil {
+		return diag.Errorf("[l2 vpn tunnel data source read] error retrieving NSX-T L2 VPN Tunnel '%s': %s", tunnelName, err)
+	}
+
+	err = setNsxtL2VpnTunnelData(d, l2VpnTunnel)
+	if err != nil {
+		return diag.Errorf("[l2 vpn tunnel data source read] error storing NSX-T L2 VPN Tunnel to schema: %s", err)
+	}
+
+	d.SetId(l2VpnTunnel.ID)
+
+	return nil
+}
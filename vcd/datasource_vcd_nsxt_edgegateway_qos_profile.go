@@ -0,0 +1,114 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func datasourceVcdNsxtEdgegatewayQosProfile() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdNsxtEdgegatewayQosProfileRead,
+		Schema: map[string]*schema.Schema{
+			"nsxt_manager_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of NSX-T Manager",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of NSX-T Gateway QoS Profile",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of NSX-T Gateway QoS Profile",
+			},
+			"committed_bandwidth": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Committed bandwidth in Mb/s",
+			},
+			"burst_size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Burst size in bytes",
+			},
+			"excess_action": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Excess action defines action on traffic exceeding bandwidth",
+			},
+		},
+	}
+}
+
+// nsxtEdgeGatewayQosProfile is a Gateway QoS Profile defined in NSX-T Manager
+type nsxtEdgeGatewayQosProfile struct {
+	ID                 string `json:"id"`
+	DisplayName        string `json:"displayName"`
+	Description        string `json:"description"`
+	CommittedBandwidth int    `json:"committedBandwidth"`
+	BurstSize          int    `json:"burstSize"`
+	ExcessAction       string `json:"excessAction"`
+}
+
+func datasourceVcdNsxtEdgegatewayQosProfileRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	nsxtManagerId := d.Get("nsxt_manager_id").(string)
+	qosProfileName := d.Get("name").(string)
+
+	qosProfile, err := getNsxtEdgeGatewayQosProfileByName(&vcdClient.Client, nsxtManagerId, qosProfileName)
+	if err != nil {
+		return diag.Errorf("could not find NSX-T Gateway QoS Profile by name '%s' in NSX-T Manager %s: %s",
+			qosProfileName, nsxtManagerId, err)
+	}
+
+	dSet(d, "description", qosProfile.Description)
+	dSet(d, "committed_bandwidth", qosProfile.CommittedBandwidth)
+	dSet(d, "burst_size", qosProfile.BurstSize)
+	dSet(d, "excess_action", qosProfile.ExcessAction)
+	d.SetId(qosProfile.ID)
+
+	return nil
+}
+
+// getNsxtEdgeGatewayQosProfileByName retrieves all Gateway QoS Profiles in NSX-T Manager and
+// looks for an exact name match, as the endpoint does not support filtering by name
+func getNsxtEdgeGatewayQosProfileByName(client *govcd.Client, nsxtManagerId, name string) (*nsxtEdgeGatewayQosProfile, error) {
+	urlRef, err := openApiBuildEndpointWithVersion(client, nsxtEdgeGatewayQosMinApiVersion,
+		types.OpenApiPathVersion1_0_0, "nsxTResources/gatewayQoSProfiles")
+	if err != nil {
+		return nil, err
+	}
+
+	queryParameters := openApiFilterAnd("nsxTManagerRef.id==" + nsxtManagerId)
+	allQosProfiles := []*nsxtEdgeGatewayQosProfile{{}}
+	err = client.OpenApiGetAllItems(nsxtEdgeGatewayQosMinApiVersion, urlRef, queryParameters, &allQosProfiles, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var foundProfiles []*nsxtEdgeGatewayQosProfile
+	for _, qosProfile := range allQosProfiles {
+		if qosProfile.DisplayName == name {
+			foundProfiles = append(foundProfiles, qosProfile)
+		}
+	}
+
+	if len(foundProfiles) == 0 {
+		return nil, fmt.Errorf("%s: expected exactly one NSX-T Gateway QoS Profile with name '%s'. Got %d",
+			govcd.ErrorEntityNotFound, name, len(foundProfiles))
+	}
+
+	if len(foundProfiles) > 1 {
+		return nil, fmt.Errorf("expected exactly one NSX-T Gateway QoS Profile with name '%s'. Got %d", name, len(foundProfiles))
+	}
+
+	return foundProfiles[0], nil
+}
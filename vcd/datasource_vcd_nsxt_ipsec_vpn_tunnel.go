@@ -46,11 +46,36 @@ func datasourceVcdNsxtIpSecVpnTunnel() *schema.Resource {
 				Computed:    true,
 				Description: "Description of NAT rule",
 			},
+			"authentication_mode": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Authentication mode - 'PSK' or 'CERTIFICATE'",
+			},
 			"pre_shared_key": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "Pre-Shared Key (PSK)",
 			},
+			"certificate_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Library certificate ID used to authenticate local endpoint",
+			},
+			"ca_certificate_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Library CA certificate ID used to verify remote endpoint",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of IPsec VPN Tunnel - 'POLICY_BASED' or 'ROUTE_BASED'",
+			},
+			"vti_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Virtual Tunnel Interface (VTI) address for 'ROUTE_BASED' tunnels",
+			},
 			"local_ip_address": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -217,36 +242,12 @@ func datasourceVcdNsxtIpSecVpnTunnelRead(ctx context.Context, d *schema.Resource
 	}
 
 	ipSecVpnTunnelName := d.Get("name").(string)
-	ipSecVpnTunnel, err := nsxtEdge.GetIpSecVpnTunnelByName(ipSecVpnTunnelName)
+	ipSecVpnTunnel, err := getNsxtIpSecVpnTunnelByName(&vcdClient.Client, nsxtEdge.EdgeGateway.ID, ipSecVpnTunnelName)
 	if err != nil {
 		return diag.Errorf("error retrieving NSX-T IPsec VPN Tunnel configuration with name '%s': %s", ipSecVpnTunnelName, err)
 	}
 
-	// Set general schema for configuration
-	err = setNsxtIpSecVpnTunnelData(d, ipSecVpnTunnel.NsxtIpSecVpn)
-	if err != nil {
-		return diag.Errorf("error storing NSX-T IPsec VPN Tunnel configuration to schema: %s", err)
-	}
-
-	d.SetId(ipSecVpnTunnel.NsxtIpSecVpn.ID)
-
-	// Tunnel Security Properties
-	tunnelConnectionProperties, err := ipSecVpnTunnel.GetTunnelConnectionProperties()
-	if err != nil {
-		return diag.Errorf("error reading NSX-T IPsec VPN Tunnel Security Customization: %s", err)
-	}
-
-	err = setNsxtIpSecVpnProfileTunnelConfigurationData(d, tunnelConnectionProperties)
-	if err != nil {
-		return diag.Errorf("error storing NSX-T IPsec VPN Tunnel Security Customization to schema: %s", err)
-	}
-
-	// Read tunnel status data from separate endpoint
-	tunnelStatus, err := ipSecVpnTunnel.GetStatus()
-	if err != nil {
-		return diag.Errorf("error reading NSX-T IPsec VPN Tunnel status: %s", err)
-	}
-	setNsxtIpSecVpnTunnelStatusData(d, tunnelStatus)
+	d.SetId(ipSecVpnTunnel.ID)
 
-	return nil
+	return readNsxtIpSecVpnTunnel(&vcdClient.Client, d, nsxtEdge.EdgeGateway.ID, ipSecVpnTunnel)
 }
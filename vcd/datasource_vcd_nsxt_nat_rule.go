@@ -39,7 +39,7 @@ func datasourceVcdNsxtNatRule() *schema.Resource {
 			"rule_type": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "Rule type - one of 'DNAT', 'NO_DNAT', 'SNAT', 'NO_SNAT'",
+				Description: "Rule type - one of 'DNAT', 'NO_DNAT', 'SNAT', 'NO_SNAT', 'REFLEXIVE'",
 			},
 			"description": {
 				Type:        schema.TypeString,
@@ -91,6 +91,11 @@ func datasourceVcdNsxtNatRule() *schema.Resource {
 				Computed:    true,
 				Description: "VCD 10.2.2+ If an address has multiple NAT rules, the rule with the highest priority is applied. A lower value means a higher precedence for this rule.",
 			},
+			"applied_to": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "VCD 10.3+ Org VDC network ID to which the rule applies",
+			},
 		},
 	}
 }
@@ -108,16 +113,17 @@ func datasourceVcdNsxtNatRuleRead(ctx context.Context, d *schema.ResourceData, m
 
 	natRuleName := d.Get("name").(string)
 
-	existingRule, err := nsxtEdge.GetNatRuleByName(natRuleName)
+	existingRule, err := getNsxtNatRuleByName(&vcdClient.Client, nsxtEdge.EdgeGateway.ID, natRuleName)
 	if err != nil {
 		return diag.Errorf("unable to find NSX-T NAT rule with Name '%s': %s", natRuleName, err)
 	}
 
-	err = setNsxtNatRuleData(existingRule.NsxtNatRule, d, vcdClient)
+	err = setNsxtNatRuleData(&existingRule.NsxtNatRule, d, vcdClient)
 	if err != nil {
 		return diag.Errorf("error storing NSX-T NAT rule in statefile: %s", err)
 	}
-	d.SetId(existingRule.NsxtNatRule.ID)
+	setNsxtNatRuleAppliedTo(d, existingRule)
+	d.SetId(existingRule.ID)
 
 	return nil
 }
@@ -201,6 +201,22 @@ func datasourceVcdOrgVdc() *schema.Resource {
 				Computed:    true,
 				Description: "ID of default VM sizing policy ID",
 			},
+			"vm_placement_policy_ids": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Set of VM placement policy IDs",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"vm_vgpu_policy_ids": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Set of vGPU policy IDs",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
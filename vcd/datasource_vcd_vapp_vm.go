@@ -131,6 +131,11 @@ func vcdVmDS(vmType typeOfVm) map[string]*schema.Schema {
 			Computed:    true,
 			Description: "Virtual Hardware Version.",
 		},
+		"customization_status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Guest customization status (e.g. 'GC_PENDING', 'GC_COMPLETE', 'GC_FAILED')",
+		},
 		"network_dhcp_wait_seconds": {
 			Optional:     true,
 			Type:         schema.TypeInt,
@@ -374,6 +379,12 @@ func vcdVmDS(vmType typeOfVm) map[string]*schema.Schema {
 			Computed:    true,
 			Description: "VM sizing policy ID.",
 		},
+		"placement_policy_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "VM placement policy ID.",
+		},
 	}
 }
 
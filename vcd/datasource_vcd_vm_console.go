@@ -0,0 +1,165 @@
+package vcd
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// vmMksTicket is the answer of the VM action 'screen/action/acquireMksTicket', used by WebMKS consoles
+type vmMksTicket struct {
+	XMLName xml.Name `xml:"MksTicket"`
+	Host    string   `xml:"Host"`
+	Vmx     string   `xml:"Vmx"`
+	Ticket  string   `xml:"Ticket"`
+	Port    int      `xml:"Port"`
+}
+
+// vmScreenTicket is the answer of the VM action 'screen/action/acquireTicket', used by VMRC.
+// Its value has the format 'mks://host/vmx/ticket'
+type vmScreenTicket struct {
+	XMLName xml.Name `xml:"ScreenTicket"`
+	Value   string   `xml:",chardata"`
+}
+
+func datasourceVcdVmConsole() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdVmConsoleRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of VDC to use, optional if defined at provider level",
+			},
+			"vapp_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The vApp containing the VM. For standalone VMs, it is the 'vapp_name' computed by vcd_vm",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name or ID of the VM",
+			},
+			"console_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "webmks",
+				ValidateFunc: validation.StringInSlice([]string{"webmks", "vmrc"}, false),
+				Description:  "Type of console ticket to acquire. One of 'webmks' (default) or 'vmrc'",
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Host serving the VM console",
+			},
+			"port": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Port of the VM console. Only returned for 'webmks' consoles",
+			},
+			"vmx": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "vSphere reference of the VM",
+			},
+			"ticket": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "One-time ticket to open the VM console",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "URL to open the VM console, containing the ticket",
+			},
+		},
+	}
+}
+
+func datasourceVcdVmConsoleRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient, _, _, _, identifier, vm, err := getVmFromResource(d, meta, vappVmType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	consoleType := d.Get("console_type").(string)
+	rel := types.RelScreenAcquireMksTicket
+	if consoleType == "vmrc" {
+		rel = types.RelScreenAcquireTicket
+	}
+	ticketHref := ""
+	for _, link := range vm.VM.Link {
+		if link.Rel == rel {
+			ticketHref = link.HREF
+			break
+		}
+	}
+	// The link is only offered for VMs that are powered on
+	if ticketHref == "" {
+		return diag.Errorf("VM %s doesn't allow acquiring a %s console ticket. The VM must be powered on", identifier, consoleType)
+	}
+
+	var host, vmx, ticket, consoleUrl string
+	var port int
+	switch consoleType {
+	case "webmks":
+		mksTicket := &vmMksTicket{}
+		_, err = vcdClient.Client.ExecuteRequest(ticketHref, http.MethodPost, "", "error acquiring WebMKS ticket: %s", nil, mksTicket)
+		if err != nil {
+			return diag.Errorf("error acquiring console ticket for VM %s: %s", identifier, err)
+		}
+		host, port, vmx, ticket = mksTicket.Host, mksTicket.Port, mksTicket.Vmx, mksTicket.Ticket
+		consoleUrl = fmt.Sprintf("wss://%s/%d;%s", host, port, ticket)
+	case "vmrc":
+		screenTicket := &vmScreenTicket{}
+		_, err = vcdClient.Client.ExecuteRequest(ticketHref, http.MethodPost, "", "error acquiring VMRC ticket: %s", nil, screenTicket)
+		if err != nil {
+			return diag.Errorf("error acquiring console ticket for VM %s: %s", identifier, err)
+		}
+		host, vmx, ticket, err = parseVmScreenTicket(screenTicket.Value)
+		if err != nil {
+			return diag.Errorf("error acquiring console ticket for VM %s: %s", identifier, err)
+		}
+		consoleUrl = fmt.Sprintf("vmrc://clone:%s@%s/?moid=%s", url.QueryEscape(ticket), host, vmx)
+	}
+
+	dSet(d, "host", host)
+	dSet(d, "port", port)
+	dSet(d, "vmx", vmx)
+	dSet(d, "ticket", ticket)
+	dSet(d, "url", consoleUrl)
+	d.SetId(vm.VM.ID)
+
+	return nil
+}
+
+// parseVmScreenTicket splits a screen ticket with format 'mks://host/vmx/ticket' into its components.
+// The ticket part is URL encoded
+func parseVmScreenTicket(screenTicket string) (string, string, string, error) {
+	elements := strings.SplitN(strings.TrimPrefix(strings.TrimSpace(screenTicket), "mks://"), "/", 3)
+	if len(elements) != 3 || elements[0] == "" || elements[2] == "" {
+		return "", "", "", fmt.Errorf("unexpected screen ticket format")
+	}
+	ticket, err := url.QueryUnescape(elements[2])
+	if err != nil {
+		return "", "", "", fmt.Errorf("error decoding screen ticket: %s", err)
+	}
+	return elements[0], elements[1], ticket, nil
+}
@@ -0,0 +1,86 @@
+//go:build vm || ALL || functional
+// +build vm ALL functional
+
+package vcd
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccVcdVmConsoleDS checks that WebMKS and VMRC console tickets can be acquired for a powered on VM
+func TestAccVcdVmConsoleDS(t *testing.T) {
+	preTestChecks(t)
+
+	var params = StringMap{
+		"Org":      testConfig.VCD.Org,
+		"Vdc":      testConfig.VCD.Vdc,
+		"VmName":   t.Name() + "-vm",
+		"FuncName": t.Name(),
+		"Tags":     "vm",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText := templateFill(testAccVcdVmConsoleDS, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdStandaloneVmDestroy(params["VmName"].(string), params["Org"].(string), params["Vdc"].(string)),
+		Steps: []resource.TestStep{
+			{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.vcd_vm_console.webmks", "id", "vcd_vm.console", "id"),
+					resource.TestCheckResourceAttrSet("data.vcd_vm_console.webmks", "host"),
+					resource.TestCheckResourceAttrSet("data.vcd_vm_console.webmks", "port"),
+					resource.TestCheckResourceAttrSet("data.vcd_vm_console.webmks", "ticket"),
+					resource.TestMatchResourceAttr("data.vcd_vm_console.webmks", "url", regexp.MustCompile(`^wss://`)),
+					resource.TestCheckResourceAttrSet("data.vcd_vm_console.vmrc", "ticket"),
+					resource.TestMatchResourceAttr("data.vcd_vm_console.vmrc", "url", regexp.MustCompile(`^vmrc://`)),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdVmConsoleDS = `
+resource "vcd_vm" "console" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  name             = "{{.VmName}}"
+  power_on         = true
+  memory           = 512
+  cpus             = 1
+  cpu_cores        = 1
+  os_type          = "sles11_64Guest"
+  hardware_version = "vmx-14"
+  computer_name    = "vm-console"
+}
+
+data "vcd_vm_console" "webmks" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name = vcd_vm.console.vapp_name
+  name      = vcd_vm.console.name
+}
+
+data "vcd_vm_console" "vmrc" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name    = vcd_vm.console.vapp_name
+  name         = vcd_vm.console.id
+  console_type = "vmrc"
+}
+`
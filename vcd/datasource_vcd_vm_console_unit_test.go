@@ -0,0 +1,37 @@
+//go:build unit || ALL
+// +build unit ALL
+
+package vcd
+
+import (
+	"testing"
+)
+
+func TestParseVmScreenTicket(t *testing.T) {
+	tests := []struct {
+		name         string
+		screenTicket string
+		host         string
+		vmx          string
+		ticket       string
+		wantErr      bool
+	}{
+		{name: "valid", screenTicket: "mks://10.0.0.1/vm-42/cst-abc%3D%3D", host: "10.0.0.1", vmx: "vm-42", ticket: "cst-abc=="},
+		{name: "surrounding spaces", screenTicket: " mks://host.example.com/vm-1/t1 \n", host: "host.example.com", vmx: "vm-1", ticket: "t1"},
+		{name: "ticket with slash", screenTicket: "mks://h/vm-1/a/b", host: "h", vmx: "vm-1", ticket: "a/b"},
+		{name: "missing ticket", screenTicket: "mks://h/vm-1", wantErr: true},
+		{name: "empty", screenTicket: "", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			host, vmx, ticket, err := parseVmScreenTicket(test.screenTicket)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error: %t, got: %v", test.wantErr, err)
+			}
+			if host != test.host || vmx != test.vmx || ticket != test.ticket {
+				t.Errorf("expected (%s, %s, %s), got (%s, %s, %s)", test.host, test.vmx, test.ticket, host, vmx, ticket)
+			}
+		})
+	}
+}
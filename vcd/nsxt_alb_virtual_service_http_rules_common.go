@@ -0,0 +1,824 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// nsxtAlbVirtualServiceHttpRulesMinApiVersion is the first API version which supports HTTP
+// request, response and security rules for ALB Virtual Services (VCD 10.5.0)
+const nsxtAlbVirtualServiceHttpRulesMinApiVersion = "38.0"
+
+// Endpoint suffixes for HTTP policies of a particular ALB Virtual Service
+const (
+	nsxtAlbVirtualServiceHttpRequestRulesPath  = "/httpRequestRules"
+	nsxtAlbVirtualServiceHttpResponseRulesPath = "/httpResponseRules"
+	nsxtAlbVirtualServiceHttpSecurityRulesPath = "/httpSecurityRules"
+)
+
+var albVsHttpInCriteria = []string{"IS_IN", "IS_NOT_IN"}
+
+var albVsHttpStringCriteria = []string{"BEGINS_WITH", "DOES_NOT_BEGIN_WITH", "CONTAINS", "DOES_NOT_CONTAIN",
+	"ENDS_WITH", "DOES_NOT_END_WITH", "EQUALS", "DOES_NOT_EQUAL", "REGEX_MATCH", "REGEX_DOES_NOT_MATCH"}
+
+var albVsHttpHeaderCriteria = append([]string{"EXISTS", "DOES_NOT_EXIST"}, albVsHttpStringCriteria...)
+
+// albVsHttpRuleMatchCriteria defines which traffic an HTTP request, response or security rule
+// matches. Fields are shared between all rule types, except the ones marked as response only.
+type albVsHttpRuleMatchCriteria struct {
+	ClientIpMatch    *albVsHttpIpMatch      `json:"clientIpMatch,omitempty"`
+	ServicePortMatch *albVsHttpPortMatch    `json:"servicePortMatch,omitempty"`
+	MethodMatch      *albVsHttpMethodMatch  `json:"methodMatch,omitempty"`
+	Protocol         string                 `json:"protocol,omitempty"`
+	PathMatch        *albVsHttpPathMatch    `json:"pathMatch,omitempty"`
+	QueryMatch       []string               `json:"queryMatch,omitempty"`
+	HeaderMatch      []albVsHttpHeaderMatch `json:"headerMatch,omitempty"`
+	CookieMatch      *albVsHttpCookieMatch  `json:"cookieMatch,omitempty"`
+
+	// Response rules only
+	LocationHeaderMatch *albVsHttpLocationHeaderMatch `json:"locationHeaderMatch,omitempty"`
+	RequestHeaderMatch  []albVsHttpHeaderMatch        `json:"requestHeaderMatch,omitempty"`
+	ResponseHeaderMatch []albVsHttpHeaderMatch        `json:"responseHeaderMatch,omitempty"`
+	StatusCodeMatch     *albVsHttpStatusCodeMatch     `json:"statusCodeMatch,omitempty"`
+}
+
+type albVsHttpIpMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	Addresses     []string `json:"addresses"`
+}
+
+type albVsHttpPortMatch struct {
+	MatchCriteria string `json:"matchCriteria"`
+	Ports         []int  `json:"ports"`
+}
+
+type albVsHttpMethodMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	Methods       []string `json:"methods"`
+}
+
+type albVsHttpPathMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	MatchStrings  []string `json:"matchStrings"`
+}
+
+type albVsHttpHeaderMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	Key           string   `json:"key"`
+	Value         []string `json:"value,omitempty"`
+}
+
+type albVsHttpCookieMatch struct {
+	MatchCriteria string `json:"matchCriteria"`
+	Key           string `json:"key"`
+	Value         string `json:"value,omitempty"`
+}
+
+type albVsHttpLocationHeaderMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	Value         []string `json:"value,omitempty"`
+}
+
+type albVsHttpStatusCodeMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	StatusCodes   []string `json:"statusCodes"`
+}
+
+// albVsHttpHeaderAction adds, removes or replaces an HTTP header
+type albVsHttpHeaderAction struct {
+	Action string `json:"action"`
+	Name   string `json:"name"`
+	Value  string `json:"value,omitempty"`
+}
+
+// albVsHttpRedirectAction redirects a request to a different location
+type albVsHttpRedirectAction struct {
+	Protocol   string `json:"protocol"`
+	Port       *int   `json:"port,omitempty"`
+	StatusCode int    `json:"statusCode"`
+	Host       string `json:"host,omitempty"`
+	Path       string `json:"path,omitempty"`
+	KeepQuery  bool   `json:"keepQuery"`
+}
+
+// albVsHttpLocalResponseAction responds to a request directly from the Virtual Service
+type albVsHttpLocalResponseAction struct {
+	StatusCode  int    `json:"statusCode"`
+	Content     string `json:"content,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+var albVsHttpIpMatchSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"criteria": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Criteria to use for IP address matching - 'IS_IN' or 'IS_NOT_IN'",
+			ValidateFunc: validation.StringInSlice(albVsHttpInCriteria, false),
+		},
+		"ip_addresses": {
+			Type:        schema.TypeSet,
+			Required:    true,
+			Description: "A set of IP addresses, CIDRs or ranges",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	},
+}
+
+var albVsHttpPortMatchSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"criteria": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Criteria to use for port matching - 'IS_IN' or 'IS_NOT_IN'",
+			ValidateFunc: validation.StringInSlice(albVsHttpInCriteria, false),
+		},
+		"ports": {
+			Type:        schema.TypeSet,
+			Required:    true,
+			Description: "A set of Virtual Service ports",
+			Elem: &schema.Schema{
+				Type: schema.TypeInt,
+			},
+		},
+	},
+}
+
+var albVsHttpMethodMatchSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"criteria": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Criteria to use for HTTP method matching - 'IS_IN' or 'IS_NOT_IN'",
+			ValidateFunc: validation.StringInSlice(albVsHttpInCriteria, false),
+		},
+		"methods": {
+			Type:        schema.TypeSet,
+			Required:    true,
+			Description: "A set of HTTP methods (e.g. 'GET', 'POST', 'PUT')",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	},
+}
+
+var albVsHttpPathMatchSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"criteria": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Criteria to use for path matching (e.g. 'BEGINS_WITH', 'EQUALS', 'REGEX_MATCH')",
+			ValidateFunc: validation.StringInSlice(albVsHttpStringCriteria, false),
+		},
+		"paths": {
+			Type:        schema.TypeSet,
+			Required:    true,
+			Description: "A set of paths to match",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	},
+}
+
+var albVsHttpHeaderMatchSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"criteria": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Criteria to use for header matching (e.g. 'EXISTS', 'CONTAINS', 'EQUALS')",
+			ValidateFunc: validation.StringInSlice(albVsHttpHeaderCriteria, false),
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the HTTP header",
+		},
+		"values": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "A set of header values. Not used with 'EXISTS' and 'DOES_NOT_EXIST' criteria",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	},
+}
+
+var albVsHttpCookieMatchSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"criteria": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Criteria to use for cookie matching (e.g. 'EXISTS', 'CONTAINS', 'EQUALS')",
+			ValidateFunc: validation.StringInSlice(albVsHttpHeaderCriteria, false),
+		},
+		"key": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the cookie",
+		},
+		"value": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Value of the cookie. Not used with 'EXISTS' and 'DOES_NOT_EXIST' criteria",
+		},
+	},
+}
+
+var albVsHttpLocationHeaderMatchSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"criteria": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Criteria to use for location header matching (e.g. 'EXISTS', 'CONTAINS', 'EQUALS')",
+			ValidateFunc: validation.StringInSlice(albVsHttpHeaderCriteria, false),
+		},
+		"values": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "A set of location header values",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	},
+}
+
+var albVsHttpStatusCodeMatchSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"criteria": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Criteria to use for status code matching - 'IS_IN' or 'IS_NOT_IN'",
+			ValidateFunc: validation.StringInSlice(albVsHttpInCriteria, false),
+		},
+		"http_status_codes": {
+			Type:        schema.TypeSet,
+			Required:    true,
+			Description: "A set of HTTP status codes or ranges (e.g. '200', '500-599')",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	},
+}
+
+// albVsHttpMatchCriteriaSchema returns 'match_criteria' block definition. Response rules can
+// additionally match response headers, location header and status codes.
+func albVsHttpMatchCriteriaSchema(isResponse bool) *schema.Schema {
+	criteriaSchema := map[string]*schema.Schema{
+		"client_ip_address": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Client IP address criteria",
+			Elem:        albVsHttpIpMatchSchema,
+		},
+		"service_ports": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Virtual Service port criteria",
+			Elem:        albVsHttpPortMatchSchema,
+		},
+		"protocol_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Protocol to match - 'HTTP' or 'HTTPS'",
+			ValidateFunc: validation.StringInSlice([]string{"HTTP", "HTTPS"}, false),
+		},
+		"http_methods": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "HTTP method criteria",
+			Elem:        albVsHttpMethodMatchSchema,
+		},
+		"path": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Request path criteria",
+			Elem:        albVsHttpPathMatchSchema,
+		},
+		"query": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "A set of HTTP request query strings to match",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"request_headers": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "HTTP request header criteria",
+			Elem:        albVsHttpHeaderMatchSchema,
+		},
+		"cookie": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Cookie criteria",
+			Elem:        albVsHttpCookieMatchSchema,
+		},
+	}
+
+	if isResponse {
+		criteriaSchema["location_header"] = &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Location header criteria",
+			Elem:        albVsHttpLocationHeaderMatchSchema,
+		}
+		criteriaSchema["response_headers"] = &schema.Schema{
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "HTTP response header criteria",
+			Elem:        albVsHttpHeaderMatchSchema,
+		}
+		criteriaSchema["status_code"] = &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "HTTP status code criteria",
+			Elem:        albVsHttpStatusCodeMatchSchema,
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Rule will be applied only to traffic matching all criteria. Empty block matches all traffic",
+		Elem:        &schema.Resource{Schema: criteriaSchema},
+	}
+}
+
+var albVsHttpHeaderActionSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"action": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "One of 'ADD', 'REMOVE', 'REPLACE'",
+			ValidateFunc: validation.StringInSlice([]string{"ADD", "REMOVE", "REPLACE"}, false),
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the HTTP header",
+		},
+		"value": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Value of the HTTP header. Not used with 'REMOVE' action",
+		},
+	},
+}
+
+var albVsHttpRedirectActionSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"protocol": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Protocol to redirect to - 'HTTP' or 'HTTPS'",
+			ValidateFunc: validation.StringInSlice([]string{"HTTP", "HTTPS"}, false),
+		},
+		"port": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Port to redirect to",
+		},
+		"status_code": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			Description:  "HTTP status code to use for redirect - one of 301, 302, 307",
+			ValidateFunc: validation.IntInSlice([]int{301, 302, 307}),
+		},
+		"host": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Host to redirect to",
+		},
+		"path": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Path to redirect to",
+		},
+		"keep_query": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Preserve query string of the original request (default 'true')",
+		},
+	},
+}
+
+var albVsHttpLocalResponseActionSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"status_code": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			Description:  "HTTP status code to respond with - one of 200, 204, 403, 404, 429, 501",
+			ValidateFunc: validation.IntInSlice([]int{200, 204, 403, 404, 429, 501}),
+		},
+		"content": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Base64 encoded content of the response",
+		},
+		"content_type": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "MIME type of the content (e.g. 'text/plain')",
+		},
+	},
+}
+
+func getAlbVsHttpMatchCriteriaType(matchCriteria []interface{}, isResponse bool) albVsHttpRuleMatchCriteria {
+	criteria := albVsHttpRuleMatchCriteria{}
+	if len(matchCriteria) == 0 || matchCriteria[0] == nil {
+		return criteria
+	}
+	criteriaMap := matchCriteria[0].(map[string]interface{})
+
+	if ipMatch := criteriaMap["client_ip_address"].([]interface{}); len(ipMatch) > 0 {
+		ipMatchMap := ipMatch[0].(map[string]interface{})
+		criteria.ClientIpMatch = &albVsHttpIpMatch{
+			MatchCriteria: ipMatchMap["criteria"].(string),
+			Addresses:     convertSchemaSetToSliceOfStrings(ipMatchMap["ip_addresses"].(*schema.Set)),
+		}
+	}
+
+	if portMatch := criteriaMap["service_ports"].([]interface{}); len(portMatch) > 0 {
+		portMatchMap := portMatch[0].(map[string]interface{})
+		ports := portMatchMap["ports"].(*schema.Set).List()
+		criteria.ServicePortMatch = &albVsHttpPortMatch{
+			MatchCriteria: portMatchMap["criteria"].(string),
+			Ports:         make([]int, len(ports)),
+		}
+		for index, port := range ports {
+			criteria.ServicePortMatch.Ports[index] = port.(int)
+		}
+	}
+
+	criteria.Protocol = criteriaMap["protocol_type"].(string)
+
+	if methodMatch := criteriaMap["http_methods"].([]interface{}); len(methodMatch) > 0 {
+		methodMatchMap := methodMatch[0].(map[string]interface{})
+		criteria.MethodMatch = &albVsHttpMethodMatch{
+			MatchCriteria: methodMatchMap["criteria"].(string),
+			Methods:       convertSchemaSetToSliceOfStrings(methodMatchMap["methods"].(*schema.Set)),
+		}
+	}
+
+	if pathMatch := criteriaMap["path"].([]interface{}); len(pathMatch) > 0 {
+		pathMatchMap := pathMatch[0].(map[string]interface{})
+		criteria.PathMatch = &albVsHttpPathMatch{
+			MatchCriteria: pathMatchMap["criteria"].(string),
+			MatchStrings:  convertSchemaSetToSliceOfStrings(pathMatchMap["paths"].(*schema.Set)),
+		}
+	}
+
+	criteria.QueryMatch = convertSchemaSetToSliceOfStrings(criteriaMap["query"].(*schema.Set))
+
+	// Response rules distinguish request and response headers
+	requestHeaders := getAlbVsHttpHeaderMatchType(criteriaMap["request_headers"].(*schema.Set))
+	if isResponse {
+		criteria.RequestHeaderMatch = requestHeaders
+	} else {
+		criteria.HeaderMatch = requestHeaders
+	}
+
+	if cookieMatch := criteriaMap["cookie"].([]interface{}); len(cookieMatch) > 0 {
+		cookieMatchMap := cookieMatch[0].(map[string]interface{})
+		criteria.CookieMatch = &albVsHttpCookieMatch{
+			MatchCriteria: cookieMatchMap["criteria"].(string),
+			Key:           cookieMatchMap["key"].(string),
+			Value:         cookieMatchMap["value"].(string),
+		}
+	}
+
+	if !isResponse {
+		return criteria
+	}
+
+	if locationHeaderMatch := criteriaMap["location_header"].([]interface{}); len(locationHeaderMatch) > 0 {
+		locationHeaderMatchMap := locationHeaderMatch[0].(map[string]interface{})
+		criteria.LocationHeaderMatch = &albVsHttpLocationHeaderMatch{
+			MatchCriteria: locationHeaderMatchMap["criteria"].(string),
+			Value:         convertSchemaSetToSliceOfStrings(locationHeaderMatchMap["values"].(*schema.Set)),
+		}
+	}
+
+	criteria.ResponseHeaderMatch = getAlbVsHttpHeaderMatchType(criteriaMap["response_headers"].(*schema.Set))
+
+	if statusCodeMatch := criteriaMap["status_code"].([]interface{}); len(statusCodeMatch) > 0 {
+		statusCodeMatchMap := statusCodeMatch[0].(map[string]interface{})
+		criteria.StatusCodeMatch = &albVsHttpStatusCodeMatch{
+			MatchCriteria: statusCodeMatchMap["criteria"].(string),
+			StatusCodes:   convertSchemaSetToSliceOfStrings(statusCodeMatchMap["http_status_codes"].(*schema.Set)),
+		}
+	}
+
+	return criteria
+}
+
+func getAlbVsHttpHeaderMatchType(headerSet *schema.Set) []albVsHttpHeaderMatch {
+	headers := make([]albVsHttpHeaderMatch, len(headerSet.List()))
+	for index, header := range headerSet.List() {
+		headerMap := header.(map[string]interface{})
+		headers[index] = albVsHttpHeaderMatch{
+			MatchCriteria: headerMap["criteria"].(string),
+			Key:           headerMap["name"].(string),
+			Value:         convertSchemaSetToSliceOfStrings(headerMap["values"].(*schema.Set)),
+		}
+	}
+	return headers
+}
+
+func getAlbVsHttpMatchCriteriaData(criteria albVsHttpRuleMatchCriteria, isResponse bool) []interface{} {
+	criteriaMap := make(map[string]interface{})
+	isEmpty := true
+
+	if criteria.ClientIpMatch != nil {
+		criteriaMap["client_ip_address"] = []interface{}{map[string]interface{}{
+			"criteria":     criteria.ClientIpMatch.MatchCriteria,
+			"ip_addresses": convertStringsToTypeSet(criteria.ClientIpMatch.Addresses),
+		}}
+		isEmpty = false
+	}
+
+	if criteria.ServicePortMatch != nil {
+		ports := make([]interface{}, len(criteria.ServicePortMatch.Ports))
+		for index, port := range criteria.ServicePortMatch.Ports {
+			ports[index] = port
+		}
+		criteriaMap["service_ports"] = []interface{}{map[string]interface{}{
+			"criteria": criteria.ServicePortMatch.MatchCriteria,
+			"ports":    schema.NewSet(schema.HashInt, ports),
+		}}
+		isEmpty = false
+	}
+
+	if criteria.Protocol != "" {
+		criteriaMap["protocol_type"] = criteria.Protocol
+		isEmpty = false
+	}
+
+	if criteria.MethodMatch != nil {
+		criteriaMap["http_methods"] = []interface{}{map[string]interface{}{
+			"criteria": criteria.MethodMatch.MatchCriteria,
+			"methods":  convertStringsToTypeSet(criteria.MethodMatch.Methods),
+		}}
+		isEmpty = false
+	}
+
+	if criteria.PathMatch != nil {
+		criteriaMap["path"] = []interface{}{map[string]interface{}{
+			"criteria": criteria.PathMatch.MatchCriteria,
+			"paths":    convertStringsToTypeSet(criteria.PathMatch.MatchStrings),
+		}}
+		isEmpty = false
+	}
+
+	if len(criteria.QueryMatch) > 0 {
+		criteriaMap["query"] = convertStringsToTypeSet(criteria.QueryMatch)
+		isEmpty = false
+	}
+
+	requestHeaders := criteria.HeaderMatch
+	if isResponse {
+		requestHeaders = criteria.RequestHeaderMatch
+	}
+	if len(requestHeaders) > 0 {
+		criteriaMap["request_headers"] = getAlbVsHttpHeaderMatchData(requestHeaders)
+		isEmpty = false
+	}
+
+	if criteria.CookieMatch != nil {
+		criteriaMap["cookie"] = []interface{}{map[string]interface{}{
+			"criteria": criteria.CookieMatch.MatchCriteria,
+			"key":      criteria.CookieMatch.Key,
+			"value":    criteria.CookieMatch.Value,
+		}}
+		isEmpty = false
+	}
+
+	if isResponse {
+		if criteria.LocationHeaderMatch != nil {
+			criteriaMap["location_header"] = []interface{}{map[string]interface{}{
+				"criteria": criteria.LocationHeaderMatch.MatchCriteria,
+				"values":   convertStringsToTypeSet(criteria.LocationHeaderMatch.Value),
+			}}
+			isEmpty = false
+		}
+
+		if len(criteria.ResponseHeaderMatch) > 0 {
+			criteriaMap["response_headers"] = getAlbVsHttpHeaderMatchData(criteria.ResponseHeaderMatch)
+			isEmpty = false
+		}
+
+		if criteria.StatusCodeMatch != nil {
+			criteriaMap["status_code"] = []interface{}{map[string]interface{}{
+				"criteria":          criteria.StatusCodeMatch.MatchCriteria,
+				"http_status_codes": convertStringsToTypeSet(criteria.StatusCodeMatch.StatusCodes),
+			}}
+			isEmpty = false
+		}
+	}
+
+	if isEmpty {
+		return nil
+	}
+
+	return []interface{}{criteriaMap}
+}
+
+func getAlbVsHttpHeaderMatchData(headers []albVsHttpHeaderMatch) *schema.Set {
+	headerSlice := make([]interface{}, len(headers))
+	for index, header := range headers {
+		headerSlice[index] = map[string]interface{}{
+			"criteria": header.MatchCriteria,
+			"name":     header.Key,
+			"values":   convertStringsToTypeSet(header.Value),
+		}
+	}
+	return schema.NewSet(schema.HashResource(albVsHttpHeaderMatchSchema), headerSlice)
+}
+
+func getAlbVsHttpHeaderActionsType(headerActions []interface{}) []albVsHttpHeaderAction {
+	actions := make([]albVsHttpHeaderAction, len(headerActions))
+	for index, headerAction := range headerActions {
+		headerActionMap := headerAction.(map[string]interface{})
+		actions[index] = albVsHttpHeaderAction{
+			Action: headerActionMap["action"].(string),
+			Name:   headerActionMap["name"].(string),
+			Value:  headerActionMap["value"].(string),
+		}
+	}
+	return actions
+}
+
+func getAlbVsHttpHeaderActionsData(headerActions []albVsHttpHeaderAction) []interface{} {
+	actions := make([]interface{}, len(headerActions))
+	for index, headerAction := range headerActions {
+		actions[index] = map[string]interface{}{
+			"action": headerAction.Action,
+			"name":   headerAction.Name,
+			"value":  headerAction.Value,
+		}
+	}
+	return actions
+}
+
+func getAlbVsHttpRedirectActionType(redirectAction []interface{}) *albVsHttpRedirectAction {
+	if len(redirectAction) == 0 || redirectAction[0] == nil {
+		return nil
+	}
+	redirectMap := redirectAction[0].(map[string]interface{})
+
+	action := &albVsHttpRedirectAction{
+		Protocol:   redirectMap["protocol"].(string),
+		StatusCode: redirectMap["status_code"].(int),
+		Host:       redirectMap["host"].(string),
+		Path:       redirectMap["path"].(string),
+		KeepQuery:  redirectMap["keep_query"].(bool),
+	}
+	if port := redirectMap["port"].(int); port != 0 {
+		action.Port = takeIntPointer(port)
+	}
+	return action
+}
+
+func getAlbVsHttpRedirectActionData(redirectAction *albVsHttpRedirectAction) []interface{} {
+	if redirectAction == nil {
+		return nil
+	}
+
+	redirectMap := map[string]interface{}{
+		"protocol":    redirectAction.Protocol,
+		"status_code": redirectAction.StatusCode,
+		"host":        redirectAction.Host,
+		"path":        redirectAction.Path,
+		"keep_query":  redirectAction.KeepQuery,
+	}
+	if redirectAction.Port != nil {
+		redirectMap["port"] = *redirectAction.Port
+	}
+	return []interface{}{redirectMap}
+}
+
+func getAlbVsHttpLocalResponseActionType(localResponseAction []interface{}) *albVsHttpLocalResponseAction {
+	if len(localResponseAction) == 0 || localResponseAction[0] == nil {
+		return nil
+	}
+	localResponseMap := localResponseAction[0].(map[string]interface{})
+
+	return &albVsHttpLocalResponseAction{
+		StatusCode:  localResponseMap["status_code"].(int),
+		Content:     localResponseMap["content"].(string),
+		ContentType: localResponseMap["content_type"].(string),
+	}
+}
+
+func getAlbVsHttpLocalResponseActionData(localResponseAction *albVsHttpLocalResponseAction) []interface{} {
+	if localResponseAction == nil {
+		return nil
+	}
+
+	return []interface{}{map[string]interface{}{
+		"status_code":  localResponseAction.StatusCode,
+		"content":      localResponseAction.Content,
+		"content_type": localResponseAction.ContentType,
+	}}
+}
+
+// lockAlbVsHttpRulesParent locks the parent VDC Group or Edge Gateway of ALB Virtual Service defined
+// in 'virtual_service_id', just like the ALB Virtual Service resource does, so that HTTP rules are
+// not modified concurrently with other ALB entities of the same parent. The returned function
+// releases the acquired lock.
+func lockAlbVsHttpRulesParent(vcdClient *VCDClient, d *schema.ResourceData, actionMessage string) (func(), error) {
+	virtualServiceId := d.Get("virtual_service_id").(string)
+	albVirtualService, err := vcdClient.GetAlbVirtualServiceById(virtualServiceId)
+	if err != nil {
+		return nil, fmt.Errorf("[%s] error retrieving NSX-T ALB Virtual Service '%s': %s", actionMessage, virtualServiceId, err)
+	}
+
+	unlock, _, err := lockParentVdcGroupOrEdgeGatewayById(vcdClient, d,
+		albVirtualService.NsxtAlbVirtualService.GatewayRef.ID, actionMessage)
+	return unlock, err
+}
+
+// getAlbVsHttpRules retrieves HTTP rules of type defined by rulesPath into rules
+func getAlbVsHttpRules(client *govcd.Client, virtualServiceId, rulesPath string, rules interface{}) error {
+	urlRef, err := openApiBuildEndpointWithVersion(client, nsxtAlbVirtualServiceHttpRulesMinApiVersion,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointAlbVirtualServices, virtualServiceId, rulesPath)
+	if err != nil {
+		return err
+	}
+
+	return client.OpenApiGetItem(nsxtAlbVirtualServiceHttpRulesMinApiVersion, urlRef, nil, rules, nil)
+}
+
+// updateAlbVsHttpRules replaces all HTTP rules of type defined by rulesPath. updatedRules must be
+// a pointer to the same type as rules and will contain rules returned by the API.
+func updateAlbVsHttpRules(client *govcd.Client, virtualServiceId, rulesPath string, rules, updatedRules interface{}) error {
+	urlRef, err := openApiBuildEndpointWithVersion(client, nsxtAlbVirtualServiceHttpRulesMinApiVersion,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointAlbVirtualServices, virtualServiceId, rulesPath)
+	if err != nil {
+		return err
+	}
+
+	return client.OpenApiPutItem(nsxtAlbVirtualServiceHttpRulesMinApiVersion, urlRef, nil, rules, updatedRules, nil)
+}
+
+// resourceVcdAlbVirtualServiceHttpRulesImport is shared by all HTTP rule resources, because they
+// are identified by their parent ALB Virtual Service
+func resourceVcdAlbVirtualServiceHttpRulesImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Printf("[TRACE] NSX-T ALB Virtual Service HTTP rules import initiated")
+
+	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	if len(resourceURI) != 4 {
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name.virtual_service_name")
+	}
+	orgName, vdcOrVdcGroupName, edgeName, virtualServiceName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
+
+	vcdClient := meta.(*VCDClient)
+	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(vcdClient, orgName, vdcOrVdcGroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !vdcOrVdcGroup.IsNsxt() {
+		return nil, fmt.Errorf("ALB Virtual Services are only supported on NSX-T")
+	}
+
+	edge, err := vdcOrVdcGroup.GetNsxtEdgeGatewayByName(edgeName)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve NSX-T Edge Gateway '%s': %s", edgeName, err)
+	}
+
+	albVirtualService, err := vcdClient.GetAlbVirtualServiceByName(edge.EdgeGateway.ID, virtualServiceName)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve NSX-T ALB Virtual Service '%s': %s", virtualServiceName, err)
+	}
+
+	dSet(d, "org", orgName)
+	dSet(d, "virtual_service_id", albVirtualService.NsxtAlbVirtualService.ID)
+	d.SetId(albVirtualService.NsxtAlbVirtualService.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -8,13 +8,19 @@ import (
 
 // getParentEdgeGatewayOwnerIdAndNsxtEdgeGateway returns VDC or VDC group ID and NSX-T Edge Gateway type
 func getParentEdgeGatewayOwnerIdAndNsxtEdgeGateway(vcdClient *VCDClient, d *schema.ResourceData, actionMessage string) (string, *govcd.NsxtEdgeGateway, error) {
+	return getParentEdgeGatewayOwnerIdAndNsxtEdgeGatewayById(vcdClient, d, d.Get("edge_gateway_id").(string), actionMessage)
+}
+
+// getParentEdgeGatewayOwnerIdAndNsxtEdgeGatewayById is like getParentEdgeGatewayOwnerIdAndNsxtEdgeGateway,
+// but for resources which do not have 'edge_gateway_id' field
+func getParentEdgeGatewayOwnerIdAndNsxtEdgeGatewayById(vcdClient *VCDClient, d *schema.ResourceData, edgeGatewayId, actionMessage string) (string, *govcd.NsxtEdgeGateway, error) {
 	org, err := vcdClient.GetOrgFromResource(d)
 	if err != nil {
 		return "", nil, fmt.Errorf("[%s] error retrieving Org: %s", actionMessage, err)
 	}
 
 	// Lookup Edge Gateway to know parent VDC or VDC Group
-	anyEdgeGateway, err := org.GetAnyTypeEdgeGatewayById(d.Get("edge_gateway_id").(string))
+	anyEdgeGateway, err := org.GetAnyTypeEdgeGatewayById(edgeGatewayId)
 	if err != nil {
 		return "", nil, fmt.Errorf("[%s] error retrieving Edge Gateway structure: %s", actionMessage, err)
 	}
@@ -24,8 +30,46 @@ func getParentEdgeGatewayOwnerIdAndNsxtEdgeGateway(vcdClient *VCDClient, d *sche
 
 	nsxtEdgeGateway, err := anyEdgeGateway.GetNsxtEdgeGateway()
 	if err != nil {
-		return "", nil, fmt.Errorf("[%s] could not retrieve NSX-T Edge Gateway with ID '%s': %s", actionMessage, d.Id(), err)
+		return "", nil, fmt.Errorf("[%s] could not retrieve NSX-T Edge Gateway with ID '%s': %s", actionMessage, edgeGatewayId, err)
 	}
 
 	return anyEdgeGateway.EdgeGateway.OwnerRef.ID, nsxtEdgeGateway, nil
 }
+
+// lockParentVdcGroupOrEdgeGateway acquires a lock on the parent VDC Group when NSX-T Edge Gateway
+// defined in 'edge_gateway_id' belongs to a VDC Group and on the Edge Gateway itself otherwise.
+// It is required for child entities of Edge Gateway that share their parent with VDC Group
+// entities (e.g. IP Sets). The returned function releases the acquired lock.
+// Note. It is not safe to do multiple locks in the same resource as it can result in a deadlock
+func lockParentVdcGroupOrEdgeGateway(vcdClient *VCDClient, d *schema.ResourceData, actionMessage string) (func(), *govcd.NsxtEdgeGateway, error) {
+	parentEdgeGatewayOwnerId, nsxtEdgeGateway, err := getParentEdgeGatewayOwnerIdAndNsxtEdgeGateway(vcdClient, d, actionMessage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if govcd.OwnerIsVdcGroup(parentEdgeGatewayOwnerId) {
+		vcdClient.lockById(parentEdgeGatewayOwnerId)
+		return func() { vcdClient.unlockById(parentEdgeGatewayOwnerId) }, nsxtEdgeGateway, nil
+	}
+
+	vcdClient.lockParentEdgeGtw(d)
+	return func() { vcdClient.unLockParentEdgeGtw(d) }, nsxtEdgeGateway, nil
+}
+
+// lockParentVdcGroupOrEdgeGatewayById is like lockParentVdcGroupOrEdgeGateway, but for resources
+// which do not have 'edge_gateway_id' field and find their Edge Gateway through another parent
+// (e.g. ALB Virtual Service). Edge Gateway ID is used as a lock key, just like in lockParentEdgeGtw.
+func lockParentVdcGroupOrEdgeGatewayById(vcdClient *VCDClient, d *schema.ResourceData, edgeGatewayId, actionMessage string) (func(), *govcd.NsxtEdgeGateway, error) {
+	parentEdgeGatewayOwnerId, nsxtEdgeGateway, err := getParentEdgeGatewayOwnerIdAndNsxtEdgeGatewayById(vcdClient, d, edgeGatewayId, actionMessage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lockId := nsxtEdgeGateway.EdgeGateway.ID
+	if govcd.OwnerIsVdcGroup(parentEdgeGatewayOwnerId) {
+		lockId = parentEdgeGatewayOwnerId
+	}
+
+	vcdClient.lockById(lockId)
+	return func() { vcdClient.unlockById(lockId) }, nsxtEdgeGateway, nil
+}
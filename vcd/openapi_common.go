@@ -0,0 +1,135 @@
+package vcd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+// This file contains helpers for OpenAPI endpoints which are not yet wrapped by go-vcloud-director.
+// They rely on the generic OpenAPI methods of govcd.Client and must be replaced by the SDK
+// equivalents once those become available.
+
+// openApiPathVersion2_0_0 is the OpenAPI path for endpoints of version 2.0.0, which is not defined in
+// go-vcloud-director types
+const openApiPathVersion2_0_0 = "2.0.0/"
+
+// openApiBuildEndpointWithVersion checks that VCD supports at least minimumApiVersion and returns a
+// complete URL for the given endpoint parts
+func openApiBuildEndpointWithVersion(client *govcd.Client, minimumApiVersion string, endpoint ...string) (*url.URL, error) {
+	if client.APIVCDMaxVersionIs("< " + minimumApiVersion) {
+		return nil, fmt.Errorf("endpoint '%s' requires VCD with API version %s or newer",
+			strings.Join(endpoint, ""), minimumApiVersion)
+	}
+
+	return client.OpenApiBuildEndpoint(endpoint...)
+}
+
+// openApiFilterAnd joins multiple FIQL filter expressions with AND operator (';') and returns
+// url.Values which can be used as query parameters
+func openApiFilterAnd(filters ...string) url.Values {
+	queryParameters := url.Values{}
+	nonEmptyFilters := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		if filter != "" {
+			nonEmptyFilters = append(nonEmptyFilters, filter)
+		}
+	}
+
+	if len(nonEmptyFilters) > 0 {
+		queryParameters.Set("filter", strings.Join(nonEmptyFilters, ";"))
+	}
+
+	return queryParameters
+}
+
+// The helpers below handle types which extend SDK types with fields that go-vcloud-director does
+// not know yet. Such types embed the SDK type, so that existing code for the SDK type can be reused,
+// and are sent and retrieved directly because SDK methods would drop the unknown fields.
+//
+// 'apiVersions' lists API versions of the endpoint in ascending order. The first one is the minimum
+// version which supports the endpoint, the following ones are versions which introduced new fields.
+// The highest version supported by VCD is used for requests so that all known fields are returned.
+// Extended types can therefore be used for all VCD versions and fields which are not supported are
+// simply left empty.
+
+// openApiExtendedTypeEndpoint returns the API version to use for an extended type and a complete URL
+// for the given endpoint parts
+func openApiExtendedTypeEndpoint(client *govcd.Client, apiVersions []string, endpoint ...string) (string, *url.URL, error) {
+	if len(apiVersions) == 0 {
+		return "", nil, fmt.Errorf("no API versions specified for endpoint '%s'", strings.Join(endpoint, ""))
+	}
+
+	urlRef, err := openApiBuildEndpointWithVersion(client, apiVersions[0], endpoint...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	apiVersion := apiVersions[0]
+	for _, version := range apiVersions[1:] {
+		if client.APIVCDMaxVersionIs(">= " + version) {
+			apiVersion = version
+		}
+	}
+
+	return apiVersion, urlRef, nil
+}
+
+// openApiGetExtendedItem retrieves a single item of an extended type into outType
+func openApiGetExtendedItem(client *govcd.Client, apiVersions []string, outType interface{}, endpoint ...string) error {
+	apiVersion, urlRef, err := openApiExtendedTypeEndpoint(client, apiVersions, endpoint...)
+	if err != nil {
+		return err
+	}
+
+	return client.OpenApiGetItem(apiVersion, urlRef, nil, outType, nil)
+}
+
+// openApiGetAllExtendedItems retrieves all items of an extended type into outType, which must be a
+// pointer to a slice
+func openApiGetAllExtendedItems(client *govcd.Client, apiVersions []string, queryParameters url.Values, outType interface{}, endpoint ...string) error {
+	apiVersion, urlRef, err := openApiExtendedTypeEndpoint(client, apiVersions, endpoint...)
+	if err != nil {
+		return err
+	}
+
+	return client.OpenApiGetAllItems(apiVersion, urlRef, queryParameters, outType, nil)
+}
+
+// openApiPostExtendedItem creates an item of an extended type and stores the created item in outType
+func openApiPostExtendedItem(client *govcd.Client, apiVersions []string, payload, outType interface{}, endpoint ...string) error {
+	apiVersion, urlRef, err := openApiExtendedTypeEndpoint(client, apiVersions, endpoint...)
+	if err != nil {
+		return err
+	}
+
+	return client.OpenApiPostItem(apiVersion, urlRef, nil, payload, outType, nil)
+}
+
+// openApiPostExtendedItemAsync creates an item of an extended type on endpoints which only return a
+// task and waits for the task to finish
+func openApiPostExtendedItemAsync(client *govcd.Client, apiVersions []string, payload interface{}, endpoint ...string) error {
+	apiVersion, urlRef, err := openApiExtendedTypeEndpoint(client, apiVersions, endpoint...)
+	if err != nil {
+		return err
+	}
+
+	task, err := client.OpenApiPostItemAsync(apiVersion, urlRef, nil, payload)
+	if err != nil {
+		return err
+	}
+
+	return task.WaitTaskCompletion()
+}
+
+// openApiPutExtendedItem updates an item of an extended type and stores the updated item in outType
+func openApiPutExtendedItem(client *govcd.Client, apiVersions []string, payload, outType interface{}, endpoint ...string) error {
+	apiVersion, urlRef, err := openApiExtendedTypeEndpoint(client, apiVersions, endpoint...)
+	if err != nil {
+		return err
+	}
+
+	return client.OpenApiPutItem(apiVersion, urlRef, nil, payload, outType, nil)
+}
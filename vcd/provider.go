@@ -96,6 +96,12 @@ var globalDataSourceMap = map[string]*schema.Resource{
 	"vcd_nsxt_distributed_firewall":                 datasourceVcdNsxtDistributedFirewall(),          // 3.6
 	"vcd_nsxt_network_context_profile":              datasourceVcdNsxtNetworkContextProfile(),        // 3.6
 	"vcd_nsxt_route_advertisement":                  datasourceVcdNsxtRouteAdvertisement(),           // 3.7
+	"vcd_nsxt_dynamic_security_group":               datasourceVcdDynamicSecurityGroup(),             // 3.7
+	"vcd_nsxt_edgegateway_qos_profile":              datasourceVcdNsxtEdgegatewayQosProfile(),       // 3.7
+	"vcd_nsxt_edgegateway_l2_vpn_tunnel":            datasourceVcdNsxtEdgegatewayL2VpnTunnel(),       // 3.7
+	"vcd_nsxt_edgegateway_ip_allocation":            datasourceVcdNsxtEdgegatewayIpAllocation(),      // 3.7
+	"vcd_catalog_vapp_template":                     datasourceVcdCatalogVappTemplate(),              // 3.7
+	"vcd_vm_console":                                datasourceVcdVmConsole(),                        // 3.7
 
 }
 
@@ -166,6 +172,19 @@ var globalResourceMap = map[string]*schema.Resource{
 	"vcd_security_tag":                              resourceVcdSecurityTag(),                      // 3.7
 	"vcd_nsxt_route_advertisement":                  resourceVcdNsxtRouteAdvertisement(),           // 3.7
 	"vcd_org_vdc_access_control":                    resourceVcdOrgVdcAccessControl(),              // 3.7
+	"vcd_nsxt_dynamic_security_group":               resourceVcdDynamicSecurityGroup(),             // 3.7
+	"vcd_nsxt_edgegateway_rate_limiting":            resourceVcdNsxtEdgegatewayRateLimiting(),      // 3.7
+	"vcd_nsxt_firewall_rule":                        resourceVcdNsxtFirewallRule(),                 // 3.7
+	"vcd_nsxt_distributed_firewall_rule":            resourceVcdNsxtDistributedFirewallRule(),      // 3.7
+	"vcd_nsxt_edgegateway_l2_vpn_tunnel":            resourceVcdNsxtEdgegatewayL2VpnTunnel(),       // 3.7
+	"vcd_nsxt_alb_virtual_service_http_req_rules":   resourceVcdAlbVirtualServiceHttpReqRules(),    // 3.7
+	"vcd_nsxt_alb_virtual_service_http_resp_rules":  resourceVcdAlbVirtualServiceHttpRespRules(),   // 3.7
+	"vcd_nsxt_alb_virtual_service_http_sec_rules":   resourceVcdAlbVirtualServiceHttpSecRules(),    // 3.7
+	"vcd_nsxt_edgegateway_unused_ip_lookup":         resourceVcdNsxtEdgegatewayUnusedIpLookup(),    // 3.7
+	"vcd_vm_snapshot":                               resourceVcdVmSnapshot(),                       // 3.7
+	"vcd_catalog_vapp_template":                     resourceVcdCatalogVappTemplate(),              // 3.7
+	"vcd_vm_placement_policy":                       resourceVcdVmPlacementPolicy(),                // 3.7
+	"vcd_vm_vgpu_policy":                            resourceVcdVmVgpuPolicy(),                     // 3.7
 }
 
 // Provider returns a terraform.ResourceProvider.
@@ -0,0 +1,676 @@
+package vcd
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// captureVAppParamsMime is the content type of captureVApp catalog action payload
+const captureVAppParamsMime = "application/vnd.vmware.vcloud.captureVAppParams+xml"
+
+// captureVAppParams is the payload of captureVApp catalog action, which creates a vApp template
+// from an existing vApp
+type captureVAppParams struct {
+	XMLName              xml.Name                        `xml:"CaptureVAppParams"`
+	Xmlns                string                          `xml:"xmlns,attr"`
+	XmlnsOvf             string                          `xml:"xmlns:ovf,attr"`
+	Name                 string                          `xml:"name,attr"`
+	Description          string                          `xml:"Description,omitempty"`
+	Source               *types.Reference                `xml:"Source"`
+	CustomizationSection captureVAppCustomizationSection `xml:"CustomizationSection"`
+	TargetCatalogItem    *types.Reference                `xml:"TargetCatalogItem,omitempty"`
+}
+
+// captureVAppCustomizationSection defines whether VMs instantiated from the captured vApp template
+// get customized
+type captureVAppCustomizationSection struct {
+	Info                   string `xml:"ovf:Info"`
+	CustomizeOnInstantiate bool   `xml:"CustomizeOnInstantiate"`
+}
+
+// vappTemplateVmSpec is used to retrieve the hardware specification of a VM inside a vApp template,
+// as it is not part of types.VAppTemplate
+type vappTemplateVmSpec struct {
+	VmSpecSection *types.VmSpecSection `xml:"VmSpecSection,omitempty"`
+}
+
+// catalogItemForUpdate is the payload used to rename a catalog item, as the SDK doesn't provide an
+// update method for it
+type catalogItemForUpdate struct {
+	XMLName     xml.Name      `xml:"CatalogItem"`
+	Xmlns       string        `xml:"xmlns,attr"`
+	HREF        string        `xml:"href,attr,omitempty"`
+	ID          string        `xml:"id,attr,omitempty"`
+	Name        string        `xml:"name,attr"`
+	Description string        `xml:"Description,omitempty"`
+	Entity      *types.Entity `xml:"Entity"`
+}
+
+// vappTemplateVmComputed describes the VMs contained in a vApp template
+var vappTemplateVmComputed = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Name of the VM",
+		},
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the VM inside the vApp template",
+		},
+		"disk": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Internal disks of the VM",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"disk_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Disk ID",
+					},
+					"bus_type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The type of disk controller. Possible values: ide, parallel(LSI Logic Parallel SCSI), sas(LSI Logic SAS (SCSI)), paravirtual(Paravirtual (SCSI)), sata, nvme",
+					},
+					"bus_number": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The number of the SCSI or IDE controller itself",
+					},
+					"unit_number": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The device number on the SCSI or IDE controller of the disk",
+					},
+					"size_in_mb": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The size of the disk in MB",
+					},
+					"storage_profile": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Storage profile of the disk",
+					},
+				},
+			},
+		},
+		"network_interface": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Network interfaces of the VM",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"network_name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Name of the network the NIC is connected to",
+					},
+					"adapter_type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Network card adapter type",
+					},
+					"ip_allocation_mode": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "IP address allocation mode",
+					},
+					"ip": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "IP address of the NIC",
+					},
+					"mac": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "MAC address of the NIC",
+					},
+					"is_primary": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "True if this NIC is the primary one of the VM",
+					},
+					"connected": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "True if the NIC is connected",
+					},
+				},
+			},
+		},
+	},
+}
+
+func resourceVcdCatalogVappTemplate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdCatalogVappTemplateCreate,
+		ReadContext:   resourceVcdCatalogVappTemplateRead,
+		UpdateContext: resourceVcdCatalogVappTemplateUpdate,
+		DeleteContext: resourceVcdCatalogVappTemplateDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdCatalogVappTemplateImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"catalog_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the catalog where the vApp template is created",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the vApp template",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the vApp template",
+			},
+			"ova_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"ova_path", "ovf_url", "capture_vapp"},
+				Description:  "Absolute or relative path to OVA",
+			},
+			"ovf_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"ova_path", "ovf_url", "capture_vapp"},
+				Description:  "URL of OVF file",
+			},
+			"capture_vapp": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"ova_path", "ovf_url", "capture_vapp"},
+				Description:  "Captures the vApp template from an existing vApp",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"vapp_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "ID of the vApp to capture. It can be either powered on or powered off",
+						},
+						"overwrite": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							ForceNew:    true,
+							Default:     false,
+							Description: "Overwrite the catalog item with the same name, if it exists, instead of failing",
+						},
+						"customize_on_instantiate": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							ForceNew:    true,
+							Default:     false,
+							Description: "Customize the VMs of the vApp template when they are instantiated",
+						},
+					},
+				},
+			},
+			"upload_piece_size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1,
+				Description: "Size of upload file piece size in megabytes",
+			},
+			"show_upload_progress": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Shows upload progress in stdout",
+			},
+			"metadata": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Key and value pairs for the metadata of the vApp template",
+			},
+			"catalog_item_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the catalog item that holds the vApp template",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time stamp of when the vApp template was created",
+			},
+			"vm_names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of VM names inside the vApp template",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"vm_template_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Map of VM names to the IDs of the VMs inside the vApp template, usable in 'vm_template_id' of vcd_vapp_vm and vcd_vm",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"vm": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "VMs inside the vApp template, with their disks and network interfaces",
+				Elem:        vappTemplateVmComputed,
+			},
+		},
+	}
+}
+
+func resourceVcdCatalogVappTemplateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	org, err := vcdClient.GetOrgFromResource(d)
+	if err != nil {
+		return diag.Errorf(errorRetrievingOrg, err)
+	}
+
+	catalogId := d.Get("catalog_id").(string)
+	catalog, err := org.GetCatalogById(catalogId, false)
+	if err != nil {
+		return diag.Errorf("error retrieving catalog %s: %s", catalogId, err)
+	}
+
+	name := d.Get("name").(string)
+	var diagError diag.Diagnostics
+	switch {
+	case d.Get("ova_path").(string) != "":
+		diagError = uploadFile(d, catalog, name)
+	case d.Get("ovf_url").(string) != "":
+		diagError = uploadFromUrl(d, catalog, name)
+	default:
+		err = captureVappTemplate(d, &vcdClient.Client, catalog)
+		if err != nil {
+			diagError = diag.FromErr(err)
+		}
+	}
+	if diagError != nil {
+		return diagError
+	}
+
+	catalogItem, err := catalog.GetCatalogItemByName(name, true)
+	if err != nil {
+		return diag.Errorf("error retrieving catalog item %s: %s", name, err)
+	}
+	vAppTemplate, err := catalogItem.GetVAppTemplate()
+	if err != nil {
+		return diag.Errorf("error retrieving vApp template %s: %s", name, err)
+	}
+	dSet(d, "catalog_item_id", catalogItem.CatalogItem.ID)
+	d.SetId(vAppTemplate.VAppTemplate.ID)
+	log.Printf("[TRACE] vApp template %s created with ID %s", name, d.Id())
+
+	err = createOrUpdateMetadata(d, &vAppTemplate, "metadata")
+	if err != nil {
+		return diag.Errorf("error adding metadata to vApp template %s: %s", name, err)
+	}
+
+	return resourceVcdCatalogVappTemplateRead(ctx, d, meta)
+}
+
+// captureVappTemplate creates a vApp template in the given catalog from the vApp defined in
+// 'capture_vapp' block
+func captureVappTemplate(d *schema.ResourceData, client *govcd.Client, catalog *govcd.Catalog) error {
+	captureBlock := d.Get("capture_vapp").([]interface{})[0].(map[string]interface{})
+	vappId := captureBlock["vapp_id"].(string)
+	vappUuid := extractUuid(vappId)
+	if vappUuid == "" {
+		return fmt.Errorf("'%s' is not a valid vApp ID", vappId)
+	}
+
+	params := captureVAppParams{
+		Xmlns:       types.XMLNamespaceVCloud,
+		XmlnsOvf:    types.XMLNamespaceOVF,
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Source: &types.Reference{
+			HREF: fmt.Sprintf("%s/vApp/vapp-%s", client.VCDHREF.String(), vappUuid),
+		},
+		CustomizationSection: captureVAppCustomizationSection{
+			Info:                   "VApp template customization section",
+			CustomizeOnInstantiate: captureBlock["customize_on_instantiate"].(bool),
+		},
+	}
+
+	if captureBlock["overwrite"].(bool) {
+		existingItem, err := catalog.GetCatalogItemByName(params.Name, true)
+		if err != nil && !govcd.ContainsNotFound(err) {
+			return fmt.Errorf("error checking for existing catalog item %s: %s", params.Name, err)
+		}
+		if existingItem != nil {
+			log.Printf("[DEBUG] capture of vApp %s overwrites catalog item %s", vappId, existingItem.CatalogItem.ID)
+			params.TargetCatalogItem = &types.Reference{HREF: existingItem.CatalogItem.HREF}
+		}
+	}
+
+	captured := &types.VAppTemplate{}
+	_, err := client.ExecuteRequest(catalog.Catalog.HREF+"/action/captureVApp", http.MethodPost,
+		captureVAppParamsMime, "error capturing vApp: %s", params, captured)
+	if err != nil {
+		return err
+	}
+
+	if captured.Tasks != nil {
+		for _, taskInProgress := range captured.Tasks.Task {
+			task := govcd.NewTask(client)
+			task.Task = taskInProgress
+			err = task.WaitTaskCompletion()
+			if err != nil {
+				return fmt.Errorf("error capturing vApp %s: %s", vappId, err)
+			}
+		}
+	}
+	return nil
+}
+
+func resourceVcdCatalogVappTemplateRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericVcdCatalogVappTemplateRead(d, meta, "resource")
+}
+
+// genericVcdCatalogVappTemplateRead reads a vApp template for both resource and data source. The resource
+// looks up the vApp template through its catalog item ID, while the data source uses the name
+func genericVcdCatalogVappTemplateRead(d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	org, err := vcdClient.GetOrgFromResource(d)
+	if err != nil {
+		return diag.Errorf(errorRetrievingOrg, err)
+	}
+
+	catalogId := d.Get("catalog_id").(string)
+	catalog, err := org.GetCatalogById(catalogId, false)
+	if err != nil {
+		return diag.Errorf("error retrieving catalog %s: %s", catalogId, err)
+	}
+
+	var catalogItem *govcd.CatalogItem
+	if origin == "resource" {
+		catalogItem, err = catalog.GetCatalogItemById(d.Get("catalog_item_id").(string), false)
+		if govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] vApp template %s not found. Removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+	} else {
+		catalogItem, err = catalog.GetCatalogItemByName(d.Get("name").(string), false)
+	}
+	if err != nil {
+		return diag.Errorf("error retrieving catalog item of vApp template %s: %s", d.Get("name").(string), err)
+	}
+
+	vAppTemplate, err := catalogItem.GetVAppTemplate()
+	if err != nil {
+		return diag.Errorf("error retrieving vApp template %s: %s", catalogItem.CatalogItem.Name, err)
+	}
+
+	metadata, err := vAppTemplate.GetMetadata()
+	if err != nil {
+		return diag.Errorf("error retrieving metadata of vApp template %s: %s", vAppTemplate.VAppTemplate.Name, err)
+	}
+
+	var vmNames []string
+	vmTemplateIds := make(map[string]interface{})
+	var vms []map[string]interface{}
+	if vAppTemplate.VAppTemplate.Children != nil {
+		for _, vm := range vAppTemplate.VAppTemplate.Children.VM {
+			vmNames = append(vmNames, vm.Name)
+			vmTemplateIds[vm.Name] = vm.ID
+
+			disks, err := getVappTemplateVmDisks(&vcdClient.Client, vm)
+			if err != nil {
+				return diag.Errorf("error retrieving disks of VM %s in vApp template %s: %s", vm.Name, vAppTemplate.VAppTemplate.Name, err)
+			}
+			vms = append(vms, map[string]interface{}{
+				"name":              vm.Name,
+				"id":                vm.ID,
+				"disk":              disks,
+				"network_interface": getVappTemplateVmNics(vm),
+			})
+		}
+	}
+
+	dSet(d, "name", vAppTemplate.VAppTemplate.Name)
+	dSet(d, "description", vAppTemplate.VAppTemplate.Description)
+	dSet(d, "created", vAppTemplate.VAppTemplate.DateCreated)
+	dSet(d, "catalog_item_id", catalogItem.CatalogItem.ID)
+	err = d.Set("metadata", getMetadataStruct(metadata.MetadataEntry))
+	if err != nil {
+		return diag.Errorf("error setting metadata: %s", err)
+	}
+	err = d.Set("vm_names", vmNames)
+	if err != nil {
+		return diag.Errorf("error setting vm_names: %s", err)
+	}
+	err = d.Set("vm_template_ids", vmTemplateIds)
+	if err != nil {
+		return diag.Errorf("error setting vm_template_ids: %s", err)
+	}
+	err = d.Set("vm", vms)
+	if err != nil {
+		return diag.Errorf("error setting vm: %s", err)
+	}
+
+	d.SetId(vAppTemplate.VAppTemplate.ID)
+	return nil
+}
+
+// getVappTemplateVmDisks retrieves the internal disks of a VM inside a vApp template
+func getVappTemplateVmDisks(client *govcd.Client, vm *types.VAppTemplate) ([]map[string]interface{}, error) {
+	vmSpec := &vappTemplateVmSpec{}
+	_, err := client.ExecuteRequest(vm.HREF, http.MethodGet, "", "error retrieving VM template: %s", nil, vmSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	var disks []map[string]interface{}
+	if vmSpec.VmSpecSection == nil || vmSpec.VmSpecSection.DiskSection == nil {
+		return disks, nil
+	}
+	for _, disk := range vmSpec.VmSpecSection.DiskSection.DiskSettings {
+		// Independent disks are not part of the template
+		if disk.Disk != nil {
+			continue
+		}
+		storageProfile := ""
+		if disk.StorageProfile != nil {
+			storageProfile = disk.StorageProfile.Name
+		}
+		disks = append(disks, map[string]interface{}{
+			"disk_id":         disk.DiskId,
+			"bus_type":        internalDiskBusTypesFromValues[strings.ToLower(disk.AdapterType)],
+			"bus_number":      disk.BusNumber,
+			"unit_number":     disk.UnitNumber,
+			"size_in_mb":      int(disk.SizeMb),
+			"storage_profile": storageProfile,
+		})
+	}
+	return disks, nil
+}
+
+// getVappTemplateVmNics converts the network connections of a VM inside a vApp template
+func getVappTemplateVmNics(vm *types.VAppTemplate) []map[string]interface{} {
+	var nics []map[string]interface{}
+	if vm.NetworkConnectionSection == nil {
+		return nics
+	}
+	for _, nic := range vm.NetworkConnectionSection.NetworkConnection {
+		nics = append(nics, map[string]interface{}{
+			"network_name":       nic.Network,
+			"adapter_type":       nic.NetworkAdapterType,
+			"ip_allocation_mode": nic.IPAddressAllocationMode,
+			"ip":                 nic.IPAddress,
+			"mac":                nic.MACAddress,
+			"is_primary":         nic.NetworkConnectionIndex == vm.NetworkConnectionSection.PrimaryNetworkConnectionIndex,
+			"connected":          nic.IsConnected,
+		})
+	}
+	return nics
+}
+
+func resourceVcdCatalogVappTemplateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	vAppTemplate, err := getVappTemplateById(&vcdClient.Client, d.Id())
+	if err != nil {
+		return diag.Errorf("error retrieving vApp template %s: %s", d.Id(), err)
+	}
+
+	if d.HasChanges("name", "description") {
+		vAppTemplate.VAppTemplate.Name = d.Get("name").(string)
+		vAppTemplate.VAppTemplate.Description = d.Get("description").(string)
+		_, err = vAppTemplate.Update()
+		if err != nil {
+			return diag.Errorf("error updating vApp template %s: %s", d.Id(), err)
+		}
+
+		// The catalog item is not renamed together with the vApp template
+		err = updateVappTemplateCatalogItem(vcdClient, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	err = createOrUpdateMetadata(d, vAppTemplate, "metadata")
+	if err != nil {
+		return diag.Errorf("error updating metadata of vApp template %s: %s", d.Id(), err)
+	}
+
+	return resourceVcdCatalogVappTemplateRead(ctx, d, meta)
+}
+
+// updateVappTemplateCatalogItem sets the name and description of the catalog item that contains the
+// vApp template to the ones in configuration
+func updateVappTemplateCatalogItem(vcdClient *VCDClient, d *schema.ResourceData) error {
+	org, err := vcdClient.GetOrgFromResource(d)
+	if err != nil {
+		return fmt.Errorf(errorRetrievingOrg, err)
+	}
+
+	catalogId := d.Get("catalog_id").(string)
+	catalog, err := org.GetCatalogById(catalogId, false)
+	if err != nil {
+		return fmt.Errorf("error retrieving catalog %s: %s", catalogId, err)
+	}
+
+	catalogItemId := d.Get("catalog_item_id").(string)
+	catalogItem, err := catalog.GetCatalogItemById(catalogItemId, false)
+	if err != nil {
+		return fmt.Errorf("error retrieving catalog item %s: %s", catalogItemId, err)
+	}
+
+	payload := catalogItemForUpdate{
+		Xmlns:       types.XMLNamespaceVCloud,
+		HREF:        catalogItem.CatalogItem.HREF,
+		ID:          catalogItem.CatalogItem.ID,
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Entity:      catalogItem.CatalogItem.Entity,
+	}
+	_, err = vcdClient.Client.ExecuteRequest(catalogItem.CatalogItem.HREF, http.MethodPut, types.MimeCatalogItem,
+		"error updating catalog item: %s", payload, &types.CatalogItem{})
+	if err != nil {
+		return fmt.Errorf("error updating catalog item %s: %s", catalogItemId, err)
+	}
+	return nil
+}
+
+func resourceVcdCatalogVappTemplateDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	org, err := vcdClient.GetOrgFromResource(d)
+	if err != nil {
+		return diag.Errorf(errorRetrievingOrg, err)
+	}
+
+	catalogId := d.Get("catalog_id").(string)
+	catalog, err := org.GetCatalogById(catalogId, false)
+	if err != nil {
+		return diag.Errorf("error retrieving catalog %s: %s", catalogId, err)
+	}
+
+	catalogItemId := d.Get("catalog_item_id").(string)
+	catalogItem, err := catalog.GetCatalogItemById(catalogItemId, false)
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			// The catalog item is already gone
+			return nil
+		}
+		return diag.Errorf("error retrieving catalog item %s: %s", catalogItemId, err)
+	}
+
+	err = catalogItem.Delete()
+	if err != nil {
+		return diag.Errorf("error deleting vApp template %s: %s", d.Get("name").(string), err)
+	}
+	return nil
+}
+
+// resourceVcdCatalogVappTemplateImport imports a vApp template into Terraform state
+//
+// Example import path (id): org_name.catalog_name.vapp_template_name
+// Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
+func resourceVcdCatalogVappTemplateImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	if len(resourceURI) != 3 {
+		return nil, fmt.Errorf("resource name must be specified as org.catalog.vapp_template")
+	}
+	orgName, catalogName, vAppTemplateName := resourceURI[0], resourceURI[1], resourceURI[2]
+
+	vcdClient := meta.(*VCDClient)
+	org, err := vcdClient.GetOrgByName(orgName)
+	if err != nil {
+		return nil, fmt.Errorf(errorRetrievingOrg, orgName)
+	}
+
+	catalog, err := org.GetCatalogByName(catalogName, false)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving catalog %s: %s", catalogName, err)
+	}
+
+	catalogItem, err := catalog.GetCatalogItemByName(vAppTemplateName, false)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving vApp template %s: %s", vAppTemplateName, err)
+	}
+
+	vAppTemplate, err := catalogItem.GetVAppTemplate()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving vApp template %s: %s", vAppTemplateName, err)
+	}
+
+	dSet(d, "org", orgName)
+	dSet(d, "catalog_id", catalog.Catalog.ID)
+	dSet(d, "name", vAppTemplateName)
+	dSet(d, "catalog_item_id", catalogItem.CatalogItem.ID)
+	dSet(d, "upload_piece_size", 1)
+	d.SetId(vAppTemplate.VAppTemplate.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,312 @@
+//go:build catalog || ALL || functional
+// +build catalog ALL functional
+
+package vcd
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccVcdCatalogVappTemplateResource(t *testing.T) {
+	preTestChecks(t)
+
+	templateName := t.Name()
+	renamedTemplateName := t.Name() + "-renamed"
+	var params = StringMap{
+		"Org":             testConfig.VCD.Org,
+		"Catalog":         testSuiteCatalogName,
+		"Name":            templateName,
+		"Description":     t.Name() + "-description",
+		"OvaPath":         testConfig.Ova.OvaPath,
+		"UploadPieceSize": testConfig.Ova.UploadPieceSize,
+		"MetadataValue":   "value1",
+		"FuncName":        t.Name(),
+		"Tags":            "catalog",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText1 := templateFill(testAccVcdCatalogVappTemplateResource, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "-update"
+	params["Name"] = renamedTemplateName
+	params["Description"] = t.Name() + "-description-updated"
+	params["MetadataValue"] = "value2"
+	configText2 := templateFill(testAccVcdCatalogVappTemplateResource, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_catalog_vapp_template.template"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			catalogItemDestroyed(testSuiteCatalogName, templateName),
+			catalogItemDestroyed(testSuiteCatalogName, renamedTemplateName),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile(`^urn:vcloud:vapptemplate:`)),
+					resource.TestMatchResourceAttr(resourceName, "catalog_item_id", regexp.MustCompile(`^urn:vcloud:catalogitem:`)),
+					resource.TestCheckResourceAttr(resourceName, "name", templateName),
+					resource.TestCheckResourceAttr(resourceName, "description", t.Name()+"-description"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.key1", "value1"),
+					resource.TestCheckResourceAttrSet(resourceName, "created"),
+					resource.TestCheckResourceAttr(resourceName, "vm.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "vm.0.name", resourceName, "vm_names.0"),
+					resource.TestMatchResourceAttr(resourceName, "vm.0.id", regexp.MustCompile(`^urn:vcloud:vm:`)),
+					resource.TestCheckResourceAttrPair(resourceName, "id", "data.vcd_catalog_vapp_template.template", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "vm.#", "data.vcd_catalog_vapp_template.template", "vm.#"),
+				),
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", renamedTemplateName),
+					resource.TestCheckResourceAttr(resourceName, "description", t.Name()+"-description-updated"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.key1", "value2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Import looks up the catalog item by name, therefore it also checks that it was renamed
+				ImportStateIdFunc: importStateIdOrgCatalogObject(testConfig, renamedTemplateName),
+				// These fields can't be retrieved from the vApp template
+				ImportStateVerifyIgnore: []string{"ova_path", "upload_piece_size", "show_upload_progress"},
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdCatalogVappTemplateResource = `
+data "vcd_catalog" "catalog" {
+  org  = "{{.Org}}"
+  name = "{{.Catalog}}"
+}
+
+resource "vcd_catalog_vapp_template" "template" {
+  org        = "{{.Org}}"
+  catalog_id = data.vcd_catalog.catalog.id
+
+  name              = "{{.Name}}"
+  description       = "{{.Description}}"
+  ova_path          = "{{.OvaPath}}"
+  upload_piece_size = {{.UploadPieceSize}}
+
+  metadata = {
+    key1 = "{{.MetadataValue}}"
+  }
+}
+
+data "vcd_catalog_vapp_template" "template" {
+  org        = "{{.Org}}"
+  catalog_id = data.vcd_catalog.catalog.id
+  name       = vcd_catalog_vapp_template.template.name
+}
+`
+
+// TestAccVcdCatalogVappTemplateCapture checks that a vApp template can be captured from an existing vApp
+func TestAccVcdCatalogVappTemplateCapture(t *testing.T) {
+	preTestChecks(t)
+
+	templateName := t.Name()
+	var params = StringMap{
+		"Org":      testConfig.VCD.Org,
+		"Vdc":      testConfig.VCD.Vdc,
+		"Catalog":  testSuiteCatalogName,
+		"Name":     templateName,
+		"VappName": t.Name() + "-vapp",
+		"VmName":   t.Name() + "-vm",
+		"OvaPath":  testConfig.Ova.OvaPath,
+		"FuncName": t.Name(),
+		"Tags":     "catalog",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText := templateFill(testAccVcdCatalogVappTemplateCapture, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText)
+
+	existingItemName := templateName + "-existing"
+	params["ExistingName"] = existingItemName
+	params["FuncName"] = t.Name() + "-step2"
+	configText2 := templateFill(testAccVcdCatalogVappTemplateCapture+testAccVcdCatalogVappTemplateCaptureOverwrite, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText2)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_catalog_vapp_template.captured"
+	overwritingResourceName := "vcd_catalog_vapp_template.overwriting"
+	// existingItemId is the ID of a catalog item which is uploaded outside of Terraform in step 2
+	existingItemId := ""
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			catalogItemDestroyed(testSuiteCatalogName, templateName),
+			catalogItemDestroyed(testSuiteCatalogName, existingItemName),
+			testAccCheckVcdVAppDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile(`^urn:vcloud:vapptemplate:`)),
+					resource.TestCheckResourceAttr(resourceName, "capture_vapp.0.customize_on_instantiate", "true"),
+					resource.TestCheckResourceAttr(resourceName, "capture_vapp.0.overwrite", "true"),
+					resource.TestCheckResourceAttr(resourceName, "vm_names.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "vm_names.0", params["VmName"].(string)),
+					resource.TestCheckResourceAttr(resourceName, "vm.#", "1"),
+				),
+			},
+			// Captures the vApp over a catalog item which already exists. The catalog item must be
+			// kept and its vApp template replaced by the captured one
+			{
+				PreConfig: func() {
+					existingItemId = testUploadCatalogItem(t, testSuiteCatalogName, existingItemName)
+				},
+				Config: configText2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(overwritingResourceName, "catalog_item_id", &existingItemId),
+					resource.TestCheckResourceAttr(overwritingResourceName, "vm_names.#", "1"),
+					resource.TestCheckResourceAttr(overwritingResourceName, "vm_names.0", params["VmName"].(string)),
+					testAccCheckCatalogItemCount(testSuiteCatalogName, existingItemName, 1),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+// testUploadCatalogItem uploads the test OVA to the given catalog outside of Terraform and returns
+// the ID of the created catalog item
+func testUploadCatalogItem(t *testing.T, catalogName, itemName string) string {
+	conn := testAccProvider.Meta().(*VCDClient)
+	org, err := conn.GetOrgByName(testConfig.VCD.Org)
+	if err != nil {
+		t.Fatalf("error retrieving Org '%s': %s", testConfig.VCD.Org, err)
+	}
+	catalog, err := org.GetCatalogByName(catalogName, false)
+	if err != nil {
+		t.Fatalf("error retrieving catalog '%s': %s", catalogName, err)
+	}
+
+	uploadTask, err := catalog.UploadOvf(testConfig.Ova.OvaPath, itemName, "upload from test", 1024)
+	if err != nil {
+		t.Fatalf("error uploading catalog item '%s': %s", itemName, err)
+	}
+	err = uploadTask.WaitTaskCompletion()
+	if err != nil {
+		t.Fatalf("error uploading catalog item '%s': %s", itemName, err)
+	}
+
+	catalogItem, err := catalog.GetCatalogItemByName(itemName, true)
+	if err != nil {
+		t.Fatalf("error retrieving uploaded catalog item '%s': %s", itemName, err)
+	}
+	return catalogItem.CatalogItem.ID
+}
+
+// testAccCheckCatalogItemCount checks how many catalog items with the given name exist in the catalog
+func testAccCheckCatalogItemCount(catalogName, itemName string, expectedCount int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*VCDClient)
+		org, err := conn.GetOrgByName(testConfig.VCD.Org)
+		if err != nil {
+			return err
+		}
+		catalog, err := org.GetCatalogByName(catalogName, true)
+		if err != nil {
+			return err
+		}
+
+		count := 0
+		for _, catalogItems := range catalog.Catalog.CatalogItems {
+			for _, catalogItem := range catalogItems.CatalogItem {
+				if catalogItem.Name == itemName {
+					count++
+				}
+			}
+		}
+		if count != expectedCount {
+			return fmt.Errorf("expected %d catalog items with name '%s' in catalog '%s', found %d",
+				expectedCount, itemName, catalogName, count)
+		}
+		return nil
+	}
+}
+
+const testAccVcdCatalogVappTemplateCapture = `
+data "vcd_catalog" "catalog" {
+  org  = "{{.Org}}"
+  name = "{{.Catalog}}"
+}
+
+resource "vcd_vapp" "source" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  name = "{{.VappName}}"
+}
+
+resource "vcd_vapp_vm" "source" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name        = vcd_vapp.source.name
+  name             = "{{.VmName}}"
+  power_on         = false
+  memory           = 512
+  cpus             = 1
+  cpu_cores        = 1
+  os_type          = "sles11_64Guest"
+  hardware_version = "vmx-14"
+  computer_name    = "capture-vm"
+}
+
+resource "vcd_catalog_vapp_template" "captured" {
+  org        = "{{.Org}}"
+  catalog_id = data.vcd_catalog.catalog.id
+
+  name = "{{.Name}}"
+
+  capture_vapp {
+    vapp_id                  = vcd_vapp.source.id
+    customize_on_instantiate = true
+    overwrite                = true
+  }
+
+  depends_on = [vcd_vapp_vm.source]
+}
+`
+
+const testAccVcdCatalogVappTemplateCaptureOverwrite = `
+resource "vcd_catalog_vapp_template" "overwriting" {
+  org        = "{{.Org}}"
+  catalog_id = data.vcd_catalog.catalog.id
+
+  name = "{{.ExistingName}}"
+
+  capture_vapp {
+    vapp_id                  = vcd_vapp.source.id
+    customize_on_instantiate = true
+    overwrite                = true
+  }
+
+  depends_on = [vcd_vapp_vm.source]
+}
+`
@@ -150,8 +150,8 @@ func resourceVcdExternalNetworkV2() *schema.Resource {
 			},
 			"ip_scope": {
 				Type:        schema.TypeSet,
-				Required:    true,
-				Description: "A set of IP scopes for the network",
+				Optional:    true,
+				Description: "A set of IP scopes for the network. Required unless 'use_ip_spaces' is set",
 				Elem:        networkV2IpScope,
 			},
 			"vsphere_network": {
@@ -172,10 +172,65 @@ func resourceVcdExternalNetworkV2() *schema.Resource {
 				Description:  "Reference to NSX-T Tier-0 router or segment and manager",
 				Elem:         networkV2NsxtNetwork,
 			},
+			// VCD only accepts 'dedicatedOrg' and 'routeAdvertisementIntention' for Provider Gateways
+			// using IP Spaces, which can only be chosen at creation time
+			"use_ip_spaces": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "Use IP Spaces instead of 'ip_scope' for IP management (NSX-T Tier-0 backed only, VCD 10.4.1+)",
+				ConflictsWith: []string{"ip_scope"},
+			},
+			"dedicated_org_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Dedicate this Provider Gateway to an Org (requires 'use_ip_spaces', VCD 10.4.1+)",
+				RequiredWith: []string{"use_ip_spaces"},
+			},
+			"route_advertisement_intention": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Route advertisement intention of Provider Gateway (requires 'use_ip_spaces', VCD 10.4.1+)",
+				RequiredWith: []string{"use_ip_spaces"},
+				ValidateFunc: validation.StringInSlice([]string{"IP_SPACE_UPLINKS_ADVERTISED_STRICT",
+					"IP_SPACE_UPLINKS_ADVERTISED_FLEXIBLE", "ALL_NETWORKS_ADVERTISED"}, false),
+			},
+			"used_ip_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of used IP addresses in all IP scopes",
+			},
+			"total_ip_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of IP addresses defined by static IP pools in all IP scopes",
+			},
 		},
 	}
 }
 
+// externalNetworkV2IpSpacesMinApiVersion is the first API version which supports IP Spaces, dedicated
+// Provider Gateways and route advertisement intention (VCD 10.4.1)
+const externalNetworkV2IpSpacesMinApiVersion = "37.1"
+
+// externalNetworkV2ApiVersions lists API versions used for externalNetworkV2 type. API 35.0
+// introduced 'backingTypeValue' field and API 36.0 added NSX-T Segment backing.
+var externalNetworkV2ApiVersions = []string{"33.0", "35.0", "36.0", externalNetworkV2IpSpacesMinApiVersion}
+
+// externalNetworkV2 extends types.ExternalNetworkV2 with IP Space, dedicated Org and route
+// advertisement fields
+type externalNetworkV2 struct {
+	types.ExternalNetworkV2
+	// UsingIpSpace defines if this external network uses IP Spaces instead of subnets
+	UsingIpSpace *bool `json:"usingIpSpace,omitempty"`
+	// DedicatedOrg dedicates Provider Gateway to a single Org
+	DedicatedOrg *types.OpenApiReference `json:"dedicatedOrg,omitempty"`
+	// NetworkRouteAdvertisementIntention is one of 'IP_SPACE_UPLINKS_ADVERTISED_STRICT',
+	// 'IP_SPACE_UPLINKS_ADVERTISED_FLEXIBLE', 'ALL_NETWORKS_ADVERTISED'
+	NetworkRouteAdvertisementIntention string `json:"networkRouteAdvertisementIntention,omitempty"`
+}
+
 func resourceVcdExternalNetworkV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	log.Printf("[TRACE] external network V2 creation initiated")
@@ -185,18 +240,18 @@ func resourceVcdExternalNetworkV2Create(ctx context.Context, d *schema.ResourceD
 		return diag.Errorf("%s", err)
 	}
 
-	netType, err := getExternalNetworkV2Type(vcdClient, d, "")
+	netType, err := getExternalNetworkV2ExtendedType(vcdClient, d, "", "")
 	if err != nil {
 		return diag.Errorf("could not get network data: %s", err)
 	}
 
-	extNet, err := govcd.CreateExternalNetworkV2(vcdClient.VCDClient, netType)
+	createdExtNet, err := createExternalNetworkV2(&vcdClient.Client, netType)
 	if err != nil {
 		return diag.Errorf("error applying data: %s", err)
 	}
 
 	// Only store ID and leave all the rest to "READ"
-	d.SetId(extNet.ExternalNetwork.ID)
+	d.SetId(createdExtNet.ID)
 
 	return resourceVcdExternalNetworkV2Read(ctx, d, meta)
 }
@@ -205,25 +260,25 @@ func resourceVcdExternalNetworkV2Update(ctx context.Context, d *schema.ResourceD
 	vcdClient := meta.(*VCDClient)
 	log.Printf("[TRACE] update network V2 creation initiated")
 
-	extNet, err := govcd.GetExternalNetworkV2ById(vcdClient.VCDClient, d.Id())
+	extNet, err := getExternalNetworkV2ById(&vcdClient.Client, d.Id())
 	if err != nil {
 		return diag.Errorf("could not find external network V2 by ID '%s': %s", d.Id(), err)
 	}
 
 	var knownNsxtSegmentId string
-	if extNet.ExternalNetwork.NetworkBackings.Values[0].BackingTypeValue == types.ExternalNetworkBackingTypeNsxtSegment {
-		knownNsxtSegmentId = extNet.ExternalNetwork.NetworkBackings.Values[0].BackingID
+	knownBackingType := extNet.NetworkBackings.Values[0].BackingTypeValue
+	if knownBackingType == types.ExternalNetworkBackingTypeNsxtSegment {
+		knownNsxtSegmentId = extNet.NetworkBackings.Values[0].BackingID
 	}
 
-	netType, err := getExternalNetworkV2Type(vcdClient, d, knownNsxtSegmentId)
+	netType, err := getExternalNetworkV2ExtendedType(vcdClient, d, knownNsxtSegmentId, knownBackingType)
 	if err != nil {
 		return diag.Errorf("could not get network data: %s", err)
 	}
 
-	netType.ID = extNet.ExternalNetwork.ID
-	extNet.ExternalNetwork = netType
+	netType.ID = extNet.ID
 
-	_, err = extNet.Update()
+	err = updateExternalNetworkV2(&vcdClient.Client, netType)
 	if err != nil {
 		return diag.Errorf("error updating external network V2: %s", err)
 	}
@@ -235,7 +290,7 @@ func resourceVcdExternalNetworkV2Read(ctx context.Context, d *schema.ResourceDat
 	vcdClient := meta.(*VCDClient)
 	log.Printf("[TRACE] external network V2 read initiated")
 
-	extNet, err := govcd.GetExternalNetworkV2ById(vcdClient.VCDClient, d.Id())
+	extNet, err := getExternalNetworkV2ById(&vcdClient.Client, d.Id())
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
 			d.SetId("")
@@ -244,10 +299,11 @@ func resourceVcdExternalNetworkV2Read(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("could not find external network V2 by ID '%s': %s", d.Id(), err)
 	}
 
-	err = setExternalNetworkV2Data(d, extNet.ExternalNetwork, vcdClient)
+	err = setExternalNetworkV2Data(d, &extNet.ExternalNetworkV2, vcdClient)
 	if err != nil {
 		return diag.Errorf("%s", err)
 	}
+	setExternalNetworkV2ExtendedData(d, extNet)
 
 	return nil
 }
@@ -279,17 +335,18 @@ func resourceVcdExternalNetworkV2Delete(ctx context.Context, d *schema.ResourceD
 func resourceVcdExternalNetworkV2Import(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	vcdClient := meta.(*VCDClient)
 
-	extNetRes, err := govcd.GetExternalNetworkV2ByName(vcdClient.VCDClient, d.Id())
+	extNetRes, err := getExternalNetworkV2ByName(&vcdClient.Client, d.Id())
 	if err != nil {
 		return nil, fmt.Errorf("error fetching external network V2 details %s", err)
 	}
 
-	d.SetId(extNetRes.ExternalNetwork.ID)
+	d.SetId(extNetRes.ID)
 
-	err = setExternalNetworkV2Data(d, extNetRes.ExternalNetwork, vcdClient)
+	err = setExternalNetworkV2Data(d, &extNetRes.ExternalNetworkV2, vcdClient)
 	if err != nil {
 		return nil, err
 	}
+	setExternalNetworkV2ExtendedData(d, extNetRes)
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -311,6 +368,51 @@ func getExternalNetworkV2Type(vcdClient *VCDClient, d *schema.ResourceData, know
 	return newExtNet, nil
 }
 
+// getExternalNetworkV2ExtendedType wraps getExternalNetworkV2Type and adds fields which are not yet
+// available in SDK types. It returns an error if they are used in unsupported VCD versions.
+//
+// knownBackingType must be set for update operations so that VRF Tier-0 router backing is preserved,
+// because the backing type cannot be derived from the configuration.
+func getExternalNetworkV2ExtendedType(vcdClient *VCDClient, d *schema.ResourceData, knownNsxtSegmentId, knownBackingType string) (*externalNetworkV2, error) {
+	netType, err := getExternalNetworkV2Type(vcdClient, d, knownNsxtSegmentId)
+	if err != nil {
+		return nil, err
+	}
+	extNet := &externalNetworkV2{ExternalNetworkV2: *netType}
+
+	if knownBackingType == types.ExternalNetworkBackingTypeNsxtVrfTier0Router &&
+		extNet.NetworkBackings.Values[0].BackingTypeValue == types.ExternalNetworkBackingTypeNsxtTier0Router {
+		extNet.NetworkBackings.Values[0].BackingTypeValue = types.ExternalNetworkBackingTypeNsxtVrfTier0Router
+	}
+
+	useIpSpaces := d.Get("use_ip_spaces").(bool)
+	dedicatedOrgId := d.Get("dedicated_org_id").(string)
+	routeAdvertisementIntention := d.Get("route_advertisement_intention").(string)
+
+	if !useIpSpaces && len(extNet.Subnets.Values) == 0 {
+		return nil, fmt.Errorf("at least one 'ip_scope' must be defined when 'use_ip_spaces' is not set")
+	}
+
+	if vcdClient.Client.APIVCDMaxVersionIs("< " + externalNetworkV2IpSpacesMinApiVersion) {
+		if useIpSpaces || dedicatedOrgId != "" || routeAdvertisementIntention != "" {
+			return nil, fmt.Errorf("'use_ip_spaces', 'dedicated_org_id' and 'route_advertisement_intention' require VCD 10.4.1+")
+		}
+		return extNet, nil
+	}
+
+	if useIpSpaces && len(d.Get("nsxt_network").([]interface{})) == 0 {
+		return nil, fmt.Errorf("'use_ip_spaces' is only supported for NSX-T Tier-0 router backed networks")
+	}
+
+	extNet.UsingIpSpace = &useIpSpaces
+	if dedicatedOrgId != "" {
+		extNet.DedicatedOrg = &types.OpenApiReference{ID: dedicatedOrgId}
+	}
+	extNet.NetworkRouteAdvertisementIntention = routeAdvertisementIntention
+
+	return extNet, nil
+}
+
 func getExternalNetworkV2BackingType(vcdClient *VCDClient, d *schema.ResourceData, knownNsxtSegmentId string) (types.ExternalNetworkV2Backings, error) {
 	var backings types.ExternalNetworkV2Backings
 	// var backing types.ExternalNetworkV2Backing
@@ -463,8 +565,12 @@ func setExternalNetworkV2Data(d *schema.ResourceData, net *types.ExternalNetwork
 	dSet(d, "description", net.Description)
 
 	// Loop over all subnets (known as ip_scope in UI)
+	var usedIpCount, totalIpCount int
 	subnetSlice := make([]interface{}, len(net.Subnets.Values))
 	for i, subnet := range net.Subnets.Values {
+		usedIpCount += subnet.UsedIPCount
+		totalIpCount += subnet.TotalIPCount
+
 		subnetMap := make(map[string]interface{})
 		subnetMap["gateway"] = subnet.Gateway
 		subnetMap["prefix_length"] = subnet.PrefixLength
@@ -494,6 +600,8 @@ func setExternalNetworkV2Data(d *schema.ResourceData, net *types.ExternalNetwork
 	if err != nil {
 		return fmt.Errorf("error setting 'ip_scope' block: %s", err)
 	}
+	dSet(d, "used_ip_count", usedIpCount)
+	dSet(d, "total_ip_count", totalIpCount)
 
 	// Switch on first value of backing ID. If it is NSX-T - it can be only one block (limited by schema).
 	// NSX-V can have more than one
@@ -556,3 +664,76 @@ func externalNetworkV2ValidateApiVersionFeatures(d *schema.ResourceData, vcdClie
 	}
 	return nil
 }
+
+// setExternalNetworkV2ExtendedData sets IP Space, dedicated Org and route advertisement fields. VCD
+// versions before 10.4.1 do not return them, therefore they are set to their defaults.
+func setExternalNetworkV2ExtendedData(d *schema.ResourceData, extNet *externalNetworkV2) {
+	useIpSpaces := false
+	if extNet.UsingIpSpace != nil {
+		useIpSpaces = *extNet.UsingIpSpace
+	}
+	dSet(d, "use_ip_spaces", useIpSpaces)
+
+	dedicatedOrgId := ""
+	if extNet.DedicatedOrg != nil {
+		dedicatedOrgId = extNet.DedicatedOrg.ID
+	}
+	dSet(d, "dedicated_org_id", dedicatedOrgId)
+	dSet(d, "route_advertisement_intention", extNet.NetworkRouteAdvertisementIntention)
+}
+
+func getExternalNetworkV2ById(client *govcd.Client, id string) (*externalNetworkV2, error) {
+	if id == "" {
+		return nil, fmt.Errorf("empty external network id")
+	}
+
+	extNet := &externalNetworkV2{}
+	err := openApiGetExtendedItem(client, externalNetworkV2ApiVersions, extNet,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointExternalNetworks, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return extNet, nil
+}
+
+// getExternalNetworkV2ByName returns an error if not exactly one network is found
+func getExternalNetworkV2ByName(client *govcd.Client, name string) (*externalNetworkV2, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+
+	allExtNets := []*externalNetworkV2{{}}
+	err := openApiGetAllExtendedItems(client, externalNetworkV2ApiVersions, openApiFilterAnd("name=="+name), &allExtNets,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointExternalNetworks)
+	if err != nil {
+		return nil, fmt.Errorf("could not find external network by name: %s", err)
+	}
+
+	if len(allExtNets) == 0 {
+		return nil, fmt.Errorf("%s: expected exactly one external network with name '%s'. Got %d", govcd.ErrorEntityNotFound, name, len(allExtNets))
+	}
+
+	if len(allExtNets) > 1 {
+		return nil, fmt.Errorf("expected exactly one external network with name '%s'. Got %d", name, len(allExtNets))
+	}
+
+	return allExtNets[0], nil
+}
+
+func createExternalNetworkV2(client *govcd.Client, extNet *externalNetworkV2) (*externalNetworkV2, error) {
+	createdExtNet := &externalNetworkV2{}
+	err := openApiPostExtendedItem(client, externalNetworkV2ApiVersions, extNet, createdExtNet,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointExternalNetworks)
+	if err != nil {
+		return nil, err
+	}
+
+	return createdExtNet, nil
+}
+
+func updateExternalNetworkV2(client *govcd.Client, extNet *externalNetworkV2) error {
+	updatedExtNet := &externalNetworkV2{}
+	return openApiPutExtendedItem(client, externalNetworkV2ApiVersions, extNet, updatedExtNet,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointExternalNetworks, extNet.ID)
+}
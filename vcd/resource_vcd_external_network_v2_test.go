@@ -93,6 +93,8 @@ func testAccVcdExternalNetworkV2Nsxt(t *testing.T, nsxtTier0Router string) {
 					resource.TestCheckResourceAttr(resourceName, "vsphere_network.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "nsxt_network.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "ip_scope.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "total_ip_count", "24"),
+					resource.TestCheckResourceAttr(resourceName, "used_ip_count", "0"),
 					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "ip_scope.*", map[string]string{
 						"dns1":          "",
 						"dns2":          "",
@@ -252,6 +254,92 @@ output "nsxt-tier0-router" {
 }
 `
 
+func TestAccVcdExternalNetworkV2NsxtIpSpaces(t *testing.T) {
+	preTestChecks(t)
+	if !usingSysAdmin() {
+		t.Skip(t.Name() + " requires system admin privileges")
+		return
+	}
+
+	vcdClient := createTemporaryVCDConnection(false)
+	if vcdClient.Client.APIVCDMaxVersionIs("< 37.1") {
+		t.Skip(t.Name() + " requires at least API v37.1 (VCD 10.4.1+)")
+	}
+
+	var params = StringMap{
+		"Org":                 testConfig.VCD.Org,
+		"NsxtManager":         testConfig.Nsxt.Manager,
+		"NsxtTier0Router":     testConfig.Nsxt.Tier0router,
+		"ExternalNetworkName": t.Name(),
+		"Intention":           "IP_SPACE_UPLINKS_ADVERTISED_STRICT",
+		"Tags":                "network extnetwork nsxt",
+	}
+	testParamsNotEmpty(t, params)
+
+	params["FuncName"] = t.Name()
+	configText := templateFill(testAccCheckVcdExternalNetworkV2NsxtIpSpaces, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText)
+
+	params["FuncName"] = t.Name() + "-step2"
+	params["Intention"] = "ALL_NETWORKS_ADVERTISED"
+	configText2 := templateFill(testAccCheckVcdExternalNetworkV2NsxtIpSpaces, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText2)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+	resourceName := "vcd_external_network_v2.ext-net-nsxt"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckExternalNetworkDestroyV2(t.Name()),
+		Steps: []resource.TestStep{
+			{
+				Config: configText,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", t.Name()),
+					resource.TestCheckResourceAttr(resourceName, "ip_scope.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "use_ip_spaces", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "dedicated_org_id", "data.vcd_org.org1", "id"),
+					resource.TestCheckResourceAttr(resourceName, "route_advertisement_intention", "IP_SPACE_UPLINKS_ADVERTISED_STRICT"),
+				),
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "route_advertisement_intention", "ALL_NETWORKS_ADVERTISED"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdTopHierarchy(t.Name()),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccCheckVcdExternalNetworkV2NsxtIpSpaces = testAccCheckVcdExternalNetworkV2NsxtDS + `
+data "vcd_org" "org1" {
+  name = "{{.Org}}"
+}
+
+resource "vcd_external_network_v2" "ext-net-nsxt" {
+  name = "{{.ExternalNetworkName}}"
+
+  nsxt_network {
+    nsxt_manager_id      = data.vcd_nsxt_manager.main.id
+    nsxt_tier0_router_id = data.vcd_nsxt_tier0_router.router.id
+  }
+
+  use_ip_spaces                 = true
+  dedicated_org_id              = data.vcd_org.org1.id
+  route_advertisement_intention = "{{.Intention}}"
+}
+`
+
 func TestAccVcdExternalNetworkV2Nsxv(t *testing.T) {
 	preTestChecks(t)
 	if vcdShortTest {
@@ -186,6 +186,8 @@ func TestAccVcdNsxtAlbVdcGroupIntegration(t *testing.T) {
 				Config: configText2,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestMatchResourceAttr("vcd_nsxt_alb_virtual_service.test", "id", regexp.MustCompile(`^urn:vcloud:loadBalancerVirtualService:`)),
+					resource.TestMatchResourceAttr("vcd_nsxt_alb_pool.test2", "id", regexp.MustCompile(`^urn:vcloud:loadBalancerPool:`)),
+					resource.TestMatchResourceAttr("vcd_nsxt_alb_pool.test3", "id", regexp.MustCompile(`^urn:vcloud:loadBalancerPool:`)),
 				),
 			},
 			// Test ALB resource imports using VDC Group name in lookup path. (Parent NSX-T Edge Gateway is in VDC Group)
@@ -420,6 +422,21 @@ resource "vcd_nsxt_alb_pool" "test" {
   edge_gateway_id = vcd_nsxt_alb_settings.test.edge_gateway_id
 }
 
+# Multiple pools are created in parallel to check that locking on parent VDC Group works
+resource "vcd_nsxt_alb_pool" "test2" {
+  org = "{{.Org}}"
+
+  name            = "{{.Name}}-pool2"
+  edge_gateway_id = vcd_nsxt_alb_settings.test.edge_gateway_id
+}
+
+resource "vcd_nsxt_alb_pool" "test3" {
+  org = "{{.Org}}"
+
+  name            = "{{.Name}}-pool3"
+  edge_gateway_id = vcd_nsxt_alb_settings.test.edge_gateway_id
+}
+
 resource "vcd_nsxt_alb_virtual_service" "test" {
   org = "{{.Org}}"
 
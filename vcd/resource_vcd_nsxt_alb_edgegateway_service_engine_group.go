@@ -77,12 +77,11 @@ func resourceVcdAlbEdgeGatewayServiceEngineGroup() *schema.Resource {
 
 func resourceVcdAlbEdgeGatewayServiceEngineGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	err := validateEdgeGatewayIdParent(d, vcdClient)
+	unlock, _, err := lockParentVdcGroupOrEdgeGateway(vcdClient, d, "ALB Service Engine Group assignment create")
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	vcdClient.lockParentEdgeGtw(d)
-	defer vcdClient.unLockParentEdgeGtw(d)
+	defer unlock()
 
 	edgeAlbServiceEngineGroupAssignmentConfig := getAlbServiceEngineGroupAssignmentType(d)
 	edgeAlbServiceEngineGroupAssignment, err := vcdClient.CreateAlbServiceEngineGroupAssignment(edgeAlbServiceEngineGroupAssignmentConfig)
@@ -96,12 +95,11 @@ func resourceVcdAlbEdgeGatewayServiceEngineGroupCreate(ctx context.Context, d *s
 
 func resourceVcdAlbEdgeGatewayServiceEngineGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	err := validateEdgeGatewayIdParent(d, vcdClient)
+	unlock, _, err := lockParentVdcGroupOrEdgeGateway(vcdClient, d, "ALB Service Engine Group assignment update")
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	vcdClient.lockParentEdgeGtw(d)
-	defer vcdClient.unLockParentEdgeGtw(d)
+	defer unlock()
 
 	edgeAlbServiceEngineGroupAssignment, err := vcdClient.GetAlbServiceEngineGroupAssignmentById(d.Id())
 	if err != nil {
@@ -133,12 +131,11 @@ func resourceVcdAlbEdgeGatewayServiceEngineGroupRead(ctx context.Context, d *sch
 
 func resourceVcdAlbEdgeGatewayServiceEngineGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	err := validateEdgeGatewayIdParent(d, vcdClient)
+	unlock, _, err := lockParentVdcGroupOrEdgeGateway(vcdClient, d, "ALB Service Engine Group assignment delete")
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	vcdClient.lockParentEdgeGtw(d)
-	defer vcdClient.unLockParentEdgeGtw(d)
+	defer unlock()
 
 	edgeAlbServiceEngineGroupAssignment, err := vcdClient.GetAlbServiceEngineGroupAssignmentById(d.Id())
 	if err != nil {
@@ -157,7 +154,7 @@ func resourceVcdAlbEdgeGatewayServiceEngineGroupImport(ctx context.Context, d *s
 
 	resourceURI := strings.Split(d.Id(), ImportSeparator)
 	if len(resourceURI) != 4 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.vdc-name.nsxt-edge-gw-name.se-group-name")
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name.se-group-name")
 	}
 	orgName, vdcOrVdcGroupName, edgeName, seGroupName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
@@ -215,19 +212,3 @@ func getAlbServiceEngineGroupAssignmentType(d *schema.ResourceData) *types.NsxtA
 
 	return edgeAlbServiceEngineAssignmentConfig
 }
-
-// validateEdgeGatewayIdParent validates if specified field `edge_gateway_id` exists in defined Org and VDC
-func validateEdgeGatewayIdParent(d *schema.ResourceData, vcdClient *VCDClient) error {
-	org, err := vcdClient.GetOrgFromResource(d)
-	if err != nil {
-		return fmt.Errorf("error retrieving Org and VDC")
-	}
-
-	_, err = vcdClient.GetNsxtEdgeGatewayFromResourceById(d, "edge_gateway_id")
-	if err != nil {
-		return fmt.Errorf("unable to locate NSX-T Edge Gateway with ID '%s' in Org '%s': %s",
-			d.Get("edge_gateway_id").(string), org.Org.Name, err)
-	}
-
-	return nil
-}
@@ -80,6 +80,10 @@ func resourceVcdAlbPool() *schema.Resource {
 				Description: "Default Port defines destination server port used by the traffic sent to the member (default 80)",
 				// Default even if no value is sent
 				Default: 80,
+				// Member group members receive traffic on the port set in 'member_group_port'
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Get("member_group_port").(int) != 0
+				},
 			},
 			//
 			"graceful_timeout_period": {
@@ -90,10 +94,25 @@ func resourceVcdAlbPool() *schema.Resource {
 				Default: 1,
 			},
 			"member": {
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Elem:        nsxtAlbPoolMember,
-				Description: "ALB Pool Members",
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          nsxtAlbPoolMember,
+				Description:   "ALB Pool Members",
+				ConflictsWith: []string{"member_group_id"},
+			},
+			"member_group_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "ID of Firewall Group (Security Group or IP Set) to use as pool members (VCD 10.4.0+)",
+				ConflictsWith: []string{"member"},
+			},
+			"member_group_port": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Description:   "Destination port used by the traffic sent to member group members. Overrides 'default_port' (VCD 10.4.0+)",
+				ValidateFunc:  validation.IntBetween(1, 65535),
+				RequiredWith:  []string{"member_group_id"},
+				ConflictsWith: []string{"member"},
 			},
 			"health_monitor": {
 				Type:     schema.TypeSet,
@@ -134,6 +153,12 @@ func resourceVcdAlbPool() *schema.Resource {
 				Default:     true,
 				Description: "Monitors if the traffic is accepted by node (default true)",
 			},
+			"ssl_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enables SSL for traffic sent to pool members (VCD 10.4.0+)",
+			},
 			// Read only information
 			"associated_virtual_service_ids": {
 				Type:        schema.TypeSet,
@@ -263,14 +288,28 @@ var nsxtAlbPoolPersistenceProfile = &schema.Resource{
 
 func resourceVcdAlbPoolCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	vcdClient.lockParentEdgeGtw(d)
-	defer vcdClient.unLockParentEdgeGtw(d)
+	unlock, _, err := lockParentVdcGroupOrEdgeGateway(vcdClient, d, "ALB Pool create")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
 
-	albPoolConfig, err := getNsxtAlbPoolType(d)
+	albPoolConfig, err := getNsxtAlbPoolExtendedType(vcdClient, d)
 	if err != nil {
 		return diag.Errorf("error getting NSX-T ALB Pool type: %s", err)
 	}
-	createdAlbPool, err := vcdClient.CreateNsxtAlbPool(albPoolConfig)
+
+	// SDK does not know member group and SSL fields therefore such pools are created directly
+	if albPoolConfig.usesExtendedFeatures() {
+		createdAlbPool, err := createNsxtAlbPool(&vcdClient.Client, albPoolConfig)
+		if err != nil {
+			return diag.Errorf("error setting NSX-T ALB Pool: %s", err)
+		}
+		d.SetId(createdAlbPool.ID)
+		return resourceVcdAlbPoolRead(ctx, d, meta)
+	}
+
+	createdAlbPool, err := vcdClient.CreateNsxtAlbPool(&albPoolConfig.NsxtAlbPool)
 	if err != nil {
 		return diag.Errorf("error setting NSX-T ALB Pool: %s", err)
 	}
@@ -282,21 +321,34 @@ func resourceVcdAlbPoolCreate(ctx context.Context, d *schema.ResourceData, meta
 
 func resourceVcdAlbPoolUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	vcdClient.lockParentEdgeGtw(d)
-	defer vcdClient.unLockParentEdgeGtw(d)
+	unlock, _, err := lockParentVdcGroupOrEdgeGateway(vcdClient, d, "ALB Pool update")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
 
 	albPool, err := vcdClient.GetAlbPoolById(d.Id())
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("could not retrieve NSX-T ALB Pool: %s", err))
 	}
 
-	updatePoolConfig, err := getNsxtAlbPoolType(d)
+	updatePoolConfig, err := getNsxtAlbPoolExtendedType(vcdClient, d)
 	if err != nil {
 		return diag.Errorf("error getting NSX-T ALB Pool type: %s", err)
 	}
 	updatePoolConfig.ID = d.Id()
 
-	_, err = albPool.Update(updatePoolConfig)
+	// Updating a pool using SDK types would remove fields that are not known to SDK in VCD versions
+	// that support them
+	if vcdClient.Client.APIVCDMaxVersionIs(">= " + nsxtAlbPoolMemberGroupMinApiVersion) {
+		err = updateNsxtAlbPool(&vcdClient.Client, updatePoolConfig)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error updating NSX-T ALB Pool: %s", err))
+		}
+		return resourceVcdAlbPoolRead(ctx, d, meta)
+	}
+
+	_, err = albPool.Update(&updatePoolConfig.NsxtAlbPool)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error updating NSX-T ALB Pool: %s", err))
 	}
@@ -307,7 +359,7 @@ func resourceVcdAlbPoolUpdate(ctx context.Context, d *schema.ResourceData, meta
 func resourceVcdAlbPoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
-	albPool, err := vcdClient.GetAlbPoolById(d.Id())
+	albPool, err := getNsxtAlbPoolById(&vcdClient.Client, d.Id())
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
 			d.SetId("")
@@ -316,18 +368,30 @@ func resourceVcdAlbPoolRead(ctx context.Context, d *schema.ResourceData, meta in
 		return diag.FromErr(fmt.Errorf("could not retrieve NSX-T ALB Pool: %s", err))
 	}
 
-	err = setNsxtAlbPoolData(d, albPool.NsxtAlbPool)
+	err = setNsxtAlbPoolData(d, &albPool.NsxtAlbPool)
 	if err != nil {
 		return diag.Errorf("error setting NSX-T ALB Pool data: %s", err)
 	}
-	d.SetId(albPool.NsxtAlbPool.ID)
+	setNsxtAlbPoolExtendedData(d, albPool)
+
+	// 'member_group_port' is only reported when it is used, as VCD stores it as the default port
+	memberGroupPort := 0
+	if albPool.MemberGroupRef != nil && d.Get("member_group_port").(int) != 0 && albPool.DefaultPort != nil {
+		memberGroupPort = *albPool.DefaultPort
+	}
+	dSet(d, "member_group_port", memberGroupPort)
+
+	d.SetId(albPool.ID)
 	return nil
 }
 
 func resourceVcdAlbPoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	vcdClient.lockParentEdgeGtw(d)
-	defer vcdClient.unLockParentEdgeGtw(d)
+	unlock, _, err := lockParentVdcGroupOrEdgeGateway(vcdClient, d, "ALB Pool delete")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
 
 	albPool, err := vcdClient.GetAlbPoolById(d.Id())
 	if err != nil {
@@ -379,6 +443,126 @@ func resourceVcdAlbPoolImport(ctx context.Context, d *schema.ResourceData, meta
 	return []*schema.ResourceData{d}, nil
 }
 
+// nsxtAlbPoolMemberGroupMinApiVersion is the first API version which supports member groups and
+// SSL for ALB Pools (VCD 10.4.0)
+const nsxtAlbPoolMemberGroupMinApiVersion = "37.0"
+
+// nsxtAlbPoolApiVersions lists API versions used for nsxtAlbPool type
+var nsxtAlbPoolApiVersions = []string{"35.0", nsxtAlbPoolMemberGroupMinApiVersion}
+
+// nsxtAlbPool extends types.NsxtAlbPool with member group and SSL fields
+type nsxtAlbPool struct {
+	types.NsxtAlbPool
+	// MemberGroupRef references a Firewall Group (Security Group or IP Set) which defines pool
+	// members. It cannot be used together with Members.
+	MemberGroupRef *types.OpenApiReference `json:"memberGroupRef,omitempty"`
+	// SslEnabled enables SSL for traffic sent to pool members
+	SslEnabled *bool `json:"sslEnabled,omitempty"`
+}
+
+// usesExtendedFeatures returns true if the ALB Pool cannot be handled by SDK types
+func (albPool *nsxtAlbPool) usesExtendedFeatures() bool {
+	return albPool.MemberGroupRef != nil || (albPool.SslEnabled != nil && *albPool.SslEnabled)
+}
+
+// getNsxtAlbPoolExtendedType wraps getNsxtAlbPoolType and adds member group and SSL fields
+func getNsxtAlbPoolExtendedType(vcdClient *VCDClient, d *schema.ResourceData) (*nsxtAlbPool, error) {
+	baseConfig, err := getNsxtAlbPoolType(d)
+	if err != nil {
+		return nil, err
+	}
+	albPoolConfig := &nsxtAlbPool{NsxtAlbPool: *baseConfig}
+
+	memberGroupId := d.Get("member_group_id").(string)
+	sslEnabled := d.Get("ssl_enabled").(bool)
+	if vcdClient.Client.APIVCDMaxVersionIs("< " + nsxtAlbPoolMemberGroupMinApiVersion) {
+		if memberGroupId != "" || sslEnabled {
+			return nil, fmt.Errorf("'member_group_id' and 'ssl_enabled' require VCD 10.4.0+")
+		}
+		return albPoolConfig, nil
+	}
+
+	if memberGroupId != "" {
+		if len(albPoolConfig.Members) > 0 {
+			return nil, fmt.Errorf("'member_group_id' cannot be used together with 'member' blocks")
+		}
+		albPoolConfig.MemberGroupRef = &types.OpenApiReference{ID: memberGroupId}
+		albPoolConfig.Members = nil
+		// Members of a member group receive traffic on the default port of the pool
+		if memberGroupPort := d.Get("member_group_port").(int); memberGroupPort != 0 {
+			albPoolConfig.DefaultPort = takeIntPointer(memberGroupPort)
+		}
+	}
+	albPoolConfig.SslEnabled = &sslEnabled
+
+	return albPoolConfig, nil
+}
+
+// setNsxtAlbPoolExtendedData stores member group and SSL fields. Older VCD versions do not return
+// them.
+func setNsxtAlbPoolExtendedData(d *schema.ResourceData, albPool *nsxtAlbPool) {
+	memberGroupId := ""
+	if albPool.MemberGroupRef != nil {
+		memberGroupId = albPool.MemberGroupRef.ID
+		// Members of a member group are managed by the Firewall Group itself and must not be
+		// reported as static 'member' blocks
+		dSet(d, "member", nil)
+	}
+	dSet(d, "member_group_id", memberGroupId)
+
+	sslEnabled := false
+	if albPool.SslEnabled != nil {
+		sslEnabled = *albPool.SslEnabled
+	}
+	dSet(d, "ssl_enabled", sslEnabled)
+}
+
+func getNsxtAlbPoolById(client *govcd.Client, id string) (*nsxtAlbPool, error) {
+	albPool := &nsxtAlbPool{}
+	err := openApiGetExtendedItem(client, nsxtAlbPoolApiVersions, albPool,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointAlbPools, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return albPool, nil
+}
+
+// getNsxtAlbPoolByName looks up ALB Pool summary by name and retrieves complete ALB Pool by ID
+func getNsxtAlbPoolByName(vcdClient *VCDClient, edgeGatewayId, name string) (*nsxtAlbPool, error) {
+	albPoolSummaries, err := vcdClient.GetAllAlbPoolSummaries(edgeGatewayId, openApiFilterAnd("name=="+name))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving ALB Pool with Name '%s': %s", name, err)
+	}
+
+	if len(albPoolSummaries) == 0 {
+		return nil, fmt.Errorf("%s: could not find ALB Pool with Name '%s'", govcd.ErrorEntityNotFound, name)
+	}
+
+	if len(albPoolSummaries) > 1 {
+		return nil, fmt.Errorf("found more than 1 ALB Pool with Name '%s'", name)
+	}
+
+	return getNsxtAlbPoolById(&vcdClient.Client, albPoolSummaries[0].NsxtAlbPool.ID)
+}
+
+func createNsxtAlbPool(client *govcd.Client, albPoolConfig *nsxtAlbPool) (*nsxtAlbPool, error) {
+	createdAlbPool := &nsxtAlbPool{}
+	err := openApiPostExtendedItem(client, nsxtAlbPoolApiVersions, albPoolConfig, createdAlbPool,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointAlbPools)
+	if err != nil {
+		return nil, err
+	}
+
+	return createdAlbPool, nil
+}
+
+func updateNsxtAlbPool(client *govcd.Client, albPoolConfig *nsxtAlbPool) error {
+	updatedAlbPool := &nsxtAlbPool{}
+	return openApiPutExtendedItem(client, nsxtAlbPoolApiVersions, albPoolConfig, updatedAlbPool,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointAlbPools, albPoolConfig.ID)
+}
+
 // getNsxtAlbPoolType is the main function for getting *types.NsxtAlbPool for API request. It nests multiple smaller
 // functions for smaller types.
 func getNsxtAlbPoolType(d *schema.ResourceData) (*types.NsxtAlbPool, error) {
@@ -1019,3 +1019,149 @@ data "vcd_nsxt_alb_pool" "test" {
   name            = "{{.PoolName}}"
 }
 `
+
+// TestAccVcdNsxtAlbPoolMemberGroup tests ALB Pool with an IP Set used as member group and SSL
+// enabled (VCD 10.4.0+)
+func TestAccVcdNsxtAlbPoolMemberGroup(t *testing.T) {
+	preTestChecks(t)
+	if !usingSysAdmin() {
+		t.Skip(t.Name() + " requires system admin privileges")
+		return
+	}
+
+	skipNoNsxtAlbConfiguration(t)
+
+	vcdClient := createTemporaryVCDConnection(false)
+	if vcdClient.Client.APIVCDMaxVersionIs("< " + nsxtAlbPoolMemberGroupMinApiVersion) {
+		t.Skipf("This test tests VCD 10.4.0+ (API V37.0+) features. Skipping.")
+	}
+
+	// String map to fill the template
+	var params = StringMap{
+		"PoolName":           t.Name(),
+		"ControllerName":     t.Name(),
+		"ControllerUrl":      testConfig.Nsxt.NsxtAlbControllerUrl,
+		"ControllerUsername": testConfig.Nsxt.NsxtAlbControllerUser,
+		"ControllerPassword": testConfig.Nsxt.NsxtAlbControllerPassword,
+		"ImportableCloud":    testConfig.Nsxt.NsxtAlbImportableCloud,
+		"ReservationModel":   "DEDICATED",
+		"Org":                testConfig.VCD.Org,
+		"NsxtVdc":            testConfig.Nsxt.Vdc,
+		"EdgeGw":             testConfig.Nsxt.EdgeGateway,
+		"IsActive":           "true",
+		"Tags":               "nsxt alb",
+	}
+	testParamsNotEmpty(t, params)
+
+	params["FuncName"] = t.Name() + "step1"
+	configText1 := templateFill(testAccVcdNsxtAlbPoolMemberGroupStep1, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "step2"
+	configText2 := templateFill(testAccVcdNsxtAlbPoolMemberGroupStep2, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	params["FuncName"] = t.Name() + "step3"
+	configText3 := templateFill(testAccVcdNsxtAlbPoolMemberGroupStep3, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 3: %s", configText3)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckVcdAlbControllerDestroy("vcd_nsxt_alb_controller.first"),
+			testAccCheckVcdAlbServiceEngineGroupDestroy("vcd_nsxt_alb_cloud.first"),
+			testAccCheckVcdAlbCloudDestroy("vcd_nsxt_alb_cloud.first"),
+			testAccCheckVcdNsxtEdgeGatewayAlbSettingsDestroy(params["EdgeGw"].(string)),
+			testAccCheckVcdAlbPoolDestroy("vcd_nsxt_alb_pool.test"),
+		),
+
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("vcd_nsxt_alb_pool.test", "member_group_id", "vcd_nsxt_ip_set.members", "id"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_pool.test", "member.#", "0"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_pool.test", "member_group_port", "8443"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_pool.test", "default_port", "8443"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_pool.test", "ssl_enabled", "true"),
+				),
+			},
+			{
+				ResourceName:      "vcd_nsxt_alb_pool.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdNsxtEdgeGatewayObject(testConfig, testConfig.Nsxt.EdgeGateway, params["PoolName"].(string)),
+				// VCD stores 'member_group_port' as 'default_port', which is the only one set on import
+				ImportStateVerifyIgnore: []string{"vdc", "member_group_port"},
+			},
+			{
+				Config: configText2, // Switch to static members
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_pool.test", "member_group_id", ""),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_pool.test", "member.#", "1"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_pool.test", "ssl_enabled", "false"),
+				),
+			},
+			{
+				Config: configText3, // Datasource check
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resourceFieldsEqual("data.vcd_nsxt_alb_pool.test", "vcd_nsxt_alb_pool.test", nil),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdNsxtAlbPoolMemberGroupIpSet = `
+resource "vcd_nsxt_ip_set" "members" {
+  org = "{{.Org}}"
+
+  edge_gateway_id = data.vcd_nsxt_edgegateway.existing.id
+
+  name         = "{{.PoolName}}"
+  ip_addresses = ["192.168.1.1", "192.168.1.10-192.168.1.12"]
+}
+`
+
+const testAccVcdNsxtAlbPoolMemberGroupStep1 = testAccVcdNsxtAlbPoolPrereqs + testAccVcdNsxtAlbPoolMemberGroupIpSet + `
+resource "vcd_nsxt_alb_pool" "test" {
+  org = "{{.Org}}"
+
+  name            = "{{.PoolName}}"
+  edge_gateway_id = vcd_nsxt_alb_settings.test.edge_gateway_id
+
+  member_group_id   = vcd_nsxt_ip_set.members.id
+  member_group_port = 8443
+  ssl_enabled       = true
+}
+`
+
+const testAccVcdNsxtAlbPoolMemberGroupStep2 = testAccVcdNsxtAlbPoolPrereqs + testAccVcdNsxtAlbPoolMemberGroupIpSet + `
+resource "vcd_nsxt_alb_pool" "test" {
+  org = "{{.Org}}"
+
+  name            = "{{.PoolName}}"
+  edge_gateway_id = vcd_nsxt_alb_settings.test.edge_gateway_id
+
+  member {
+    ip_address = "192.168.1.1"
+  }
+}
+`
+
+const testAccVcdNsxtAlbPoolMemberGroupStep3 = testAccVcdNsxtAlbPoolMemberGroupStep2 + `
+# skip-binary-test: Terraform resource cannot have resource and datasource in the same file
+
+data "vcd_nsxt_alb_pool" "test" {
+  org = "{{.Org}}"
+
+  edge_gateway_id = vcd_nsxt_alb_settings.test.edge_gateway_id
+  name            = vcd_nsxt_alb_pool.test.name
+}
+`
@@ -6,6 +6,8 @@ import (
 	"log"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
 	"github.com/vmware/go-vcloud-director/v2/govcd"
 
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
@@ -57,27 +59,80 @@ func resourceVcdAlbSettings() *schema.Resource {
 				Computed:    true,
 				Description: "Optional custom network CIDR definition for ALB Service Engine placement (VCD default is 192.168.255.1/25)",
 			},
+			"ipv6_service_network_specification": {
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Optional:    true,
+				Computed:    true,
+				Description: "Optional custom IPv6 network definition for ALB Service Engine placement (VCD 10.4.0+)",
+			},
+			"supported_feature_set": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Feature set of this Edge Gateway if ALB is enabled. One of 'STANDARD', 'PREMIUM' (VCD 10.4.0+)",
+				ValidateFunc: validation.StringInSlice([]string{"STANDARD", "PREMIUM"}, false),
+			},
+			"is_transparent_mode_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enables transparent mode (preserving client IP) for Virtual Services of this Edge Gateway (VCD 10.4.1+)",
+			},
+			"license_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "License type of the NSX-T ALB Controller backing this Edge Gateway. One of 'BASIC', 'ENTERPRISE'. Only read by System Administrators",
+			},
 		},
 	}
 }
 
+// nsxtAlbSettingsFeatureSetMinApiVersion is the first API version which supports feature set and
+// IPv6 service network in ALB settings of Edge Gateway (VCD 10.4.0)
+const nsxtAlbSettingsFeatureSetMinApiVersion = "37.0"
+
+// nsxtAlbSettingsTransparentModeMinApiVersion is the first API version which supports transparent
+// mode in ALB settings of Edge Gateway (VCD 10.4.1)
+const nsxtAlbSettingsTransparentModeMinApiVersion = "37.1"
+
+// nsxtAlbSettingsApiVersions lists API versions used for nsxtAlbConfig type
+var nsxtAlbSettingsApiVersions = []string{
+	"35.0",
+	nsxtAlbSettingsFeatureSetMinApiVersion,
+	nsxtAlbSettingsTransparentModeMinApiVersion,
+}
+
+// nsxtAlbConfig extends types.NsxtAlbConfig with feature set, IPv6 service network and transparent
+// mode fields
+type nsxtAlbConfig struct {
+	types.NsxtAlbConfig
+	// SupportedFeatureSet is one of 'STANDARD', 'PREMIUM'. It replaces LicenseType in VCD 10.4.0+
+	SupportedFeatureSet string `json:"supportedFeatureSet,omitempty"`
+	// Ipv6ServiceNetworkDefinition is an IPv6 network definition in Gateway CIDR format
+	Ipv6ServiceNetworkDefinition string `json:"ipv6ServiceNetworkDefinition,omitempty"`
+	// TransparentModeEnabled allows Virtual Services to preserve client IP
+	TransparentModeEnabled *bool `json:"transparentModeEnabled,omitempty"`
+}
+
 // resourceVcdAlbSettingsCreateUpdate covers Create and Update functionality for resource because the API
 // endpoint only supports PUT and GET
 func resourceVcdAlbSettingsCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	vcdClient.lockParentEdgeGtw(d)
-	defer vcdClient.unLockParentEdgeGtw(d)
+	unlock, nsxtEdge, err := lockParentVdcGroupOrEdgeGateway(vcdClient, d, "ALB General Settings create/update")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
 
-	orgName := d.Get("org").(string)
-	edgeGatewayId := d.Get("edge_gateway_id").(string)
+	edgeGatewayId := nsxtEdge.EdgeGateway.ID
 
-	nsxtEdge, err := vcdClient.GetNsxtEdgeGatewayById(orgName, edgeGatewayId)
+	albConfig, err := getNsxtAlbConfigurationExtendedType(vcdClient, d)
 	if err != nil {
-		return diag.Errorf("error retrieving Edge Gateway: %s", err)
+		return diag.Errorf("error getting NSX-T ALB General Settings type: %s", err)
 	}
 
-	albConfig := getNsxtAlbConfigurationType(d)
-	_, err = nsxtEdge.UpdateAlbSettings(albConfig)
+	err = updateNsxtAlbSettings(&vcdClient.Client, edgeGatewayId, albConfig)
 	if err != nil {
 		return diag.Errorf("error setting NSX-T ALB General Settings: %s", err)
 	}
@@ -111,12 +166,18 @@ func vcdAlbSettingsRead(meta interface{}, d *schema.ResourceData, resourceType s
 		return diag.Errorf("error retrieving Edge Gateway: %s", err)
 	}
 
-	albConfig, err := nsxtEdge.GetAlbSettings()
+	albConfig, err := getNsxtAlbSettings(&vcdClient.Client, nsxtEdge.EdgeGateway.ID)
 	if err != nil {
 		return diag.Errorf("error retrieve NSX-T ALB General Settings: %s", err)
 	}
 
 	setNsxtAlbConfigurationData(albConfig, d)
+
+	licenseType, err := getNsxtAlbControllerLicenseType(vcdClient, albConfig.LoadBalancerCloudRef)
+	if err != nil {
+		return diag.Errorf("error retrieving NSX-T ALB Controller license type: %s", err)
+	}
+	dSet(d, "license_type", licenseType)
 	d.SetId(edgeGatewayId)
 
 	return nil
@@ -124,16 +185,11 @@ func vcdAlbSettingsRead(meta interface{}, d *schema.ResourceData, resourceType s
 
 func resourceVcdAlbSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	vcdClient.lockParentEdgeGtw(d)
-	defer vcdClient.unLockParentEdgeGtw(d)
-
-	orgName := d.Get("org").(string)
-	edgeGatewayId := d.Get("edge_gateway_id").(string)
-
-	nsxtEdge, err := vcdClient.GetNsxtEdgeGatewayById(orgName, edgeGatewayId)
+	unlock, nsxtEdge, err := lockParentVdcGroupOrEdgeGateway(vcdClient, d, "ALB General Settings delete")
 	if err != nil {
-		return diag.Errorf("error retrieving Edge Gateway: %s", err)
+		return diag.FromErr(err)
 	}
+	defer unlock()
 
 	return diag.FromErr(nsxtEdge.DisableAlb())
 }
@@ -177,7 +233,85 @@ func getNsxtAlbConfigurationType(d *schema.ResourceData) *types.NsxtAlbConfig {
 	}
 }
 
-func setNsxtAlbConfigurationData(config *types.NsxtAlbConfig, d *schema.ResourceData) {
+// getNsxtAlbConfigurationExtendedType wraps getNsxtAlbConfigurationType and adds feature set, IPv6
+// service network and transparent mode fields. It returns an error if they are used in unsupported
+// VCD versions.
+func getNsxtAlbConfigurationExtendedType(vcdClient *VCDClient, d *schema.ResourceData) (*nsxtAlbConfig, error) {
+	albConfig := &nsxtAlbConfig{NsxtAlbConfig: *getNsxtAlbConfigurationType(d)}
+
+	supportedFeatureSet := d.Get("supported_feature_set").(string)
+	ipv6ServiceNetwork := d.Get("ipv6_service_network_specification").(string)
+	if vcdClient.Client.APIVCDMaxVersionIs("< " + nsxtAlbSettingsFeatureSetMinApiVersion) {
+		if supportedFeatureSet != "" || ipv6ServiceNetwork != "" {
+			return nil, fmt.Errorf("'supported_feature_set' and 'ipv6_service_network_specification' require VCD 10.4.0+")
+		}
+	}
+	albConfig.SupportedFeatureSet = supportedFeatureSet
+	albConfig.Ipv6ServiceNetworkDefinition = ipv6ServiceNetwork
+
+	isTransparentModeEnabled := d.Get("is_transparent_mode_enabled").(bool)
+	if vcdClient.Client.APIVCDMaxVersionIs(">= " + nsxtAlbSettingsTransparentModeMinApiVersion) {
+		albConfig.TransparentModeEnabled = &isTransparentModeEnabled
+	} else if isTransparentModeEnabled {
+		return nil, fmt.Errorf("'is_transparent_mode_enabled' requires VCD 10.4.1+")
+	}
+
+	return albConfig, nil
+}
+
+func setNsxtAlbConfigurationData(config *nsxtAlbConfig, d *schema.ResourceData) {
 	dSet(d, "is_active", config.Enabled)
 	dSet(d, "service_network_specification", config.ServiceNetworkDefinition)
+	dSet(d, "ipv6_service_network_specification", config.Ipv6ServiceNetworkDefinition)
+	dSet(d, "supported_feature_set", config.SupportedFeatureSet)
+
+	isTransparentModeEnabled := false
+	if config.TransparentModeEnabled != nil {
+		isTransparentModeEnabled = *config.TransparentModeEnabled
+	}
+	dSet(d, "is_transparent_mode_enabled", isTransparentModeEnabled)
+}
+
+// getNsxtAlbControllerLicenseType returns the license type of the NSX-T ALB Controller backing the
+// given ALB Cloud. The license type of Edge Gateway ALB settings is not populated in VCD 10.4.0+,
+// therefore it is read from the Controller itself. ALB Clouds and Controllers are only readable by
+// System Administrators, so an empty value is returned for other users.
+func getNsxtAlbControllerLicenseType(vcdClient *VCDClient, albCloudRef *types.OpenApiReference) (string, error) {
+	if !vcdClient.Client.IsSysAdmin || albCloudRef == nil || albCloudRef.ID == "" {
+		return "", nil
+	}
+
+	albCloud, err := vcdClient.GetAlbCloudById(albCloudRef.ID)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving NSX-T ALB Cloud '%s': %s", albCloudRef.ID, err)
+	}
+	controllerRef := albCloud.NsxtAlbCloud.LoadBalancerCloudBacking.LoadBalancerControllerRef
+	if controllerRef.ID == "" {
+		return "", nil
+	}
+
+	controllerId := controllerRef.ID
+	albController, err := vcdClient.GetAlbControllerById(controllerId)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving NSX-T ALB Controller '%s': %s", controllerId, err)
+	}
+
+	return albController.NsxtAlbController.LicenseType, nil
+}
+
+func getNsxtAlbSettings(client *govcd.Client, edgeGatewayId string) (*nsxtAlbConfig, error) {
+	albConfig := &nsxtAlbConfig{}
+	err := openApiGetExtendedItem(client, nsxtAlbSettingsApiVersions, albConfig,
+		types.OpenApiPathVersion1_0_0, fmt.Sprintf(types.OpenApiEndpointAlbEdgeGateway, edgeGatewayId))
+	if err != nil {
+		return nil, err
+	}
+
+	return albConfig, nil
+}
+
+func updateNsxtAlbSettings(client *govcd.Client, edgeGatewayId string, albConfig *nsxtAlbConfig) error {
+	updatedAlbConfig := &nsxtAlbConfig{}
+	return openApiPutExtendedItem(client, nsxtAlbSettingsApiVersions, albConfig, updatedAlbConfig,
+		types.OpenApiPathVersion1_0_0, fmt.Sprintf(types.OpenApiEndpointAlbEdgeGateway, edgeGatewayId))
 }
@@ -193,6 +193,135 @@ resource "vcd_nsxt_alb_settings" "test" {
 }
 `
 
+// TestAccVcdNsxtAlbSettingsFeatureSet tests supported feature set, IPv6 service network and
+// transparent mode settings of NSX-T ALB on Edge Gateway (VCD 10.4.1+)
+func TestAccVcdNsxtAlbSettingsFeatureSet(t *testing.T) {
+	preTestChecks(t)
+	if !usingSysAdmin() {
+		t.Skip(t.Name() + " requires system admin privileges")
+		return
+	}
+
+	skipNoNsxtAlbConfiguration(t)
+
+	vcdClient := createTemporaryVCDConnection(false)
+	if vcdClient.Client.APIVCDMaxVersionIs("< " + nsxtAlbSettingsTransparentModeMinApiVersion) {
+		t.Skipf("This test tests VCD 10.4.1+ (API V37.1+) features. Skipping.")
+	}
+
+	// String map to fill the template
+	var params = StringMap{
+		"ControllerName":     t.Name(),
+		"ControllerUrl":      testConfig.Nsxt.NsxtAlbControllerUrl,
+		"ControllerUsername": testConfig.Nsxt.NsxtAlbControllerUser,
+		"ControllerPassword": testConfig.Nsxt.NsxtAlbControllerPassword,
+		"ImportableCloud":    testConfig.Nsxt.NsxtAlbImportableCloud,
+		"ReservationModel":   "DEDICATED",
+		"Org":                testConfig.VCD.Org,
+		"NsxtVdc":            testConfig.Nsxt.Vdc,
+		"EdgeGw":             testConfig.Nsxt.EdgeGateway,
+		"Tags":               "nsxt alb",
+	}
+	testParamsNotEmpty(t, params)
+
+	params["FuncName"] = t.Name() + "step1"
+	params["FeatureSet"] = "PREMIUM"
+	params["TransparentMode"] = "true"
+	configText1 := templateFill(testAccVcdNsxtAlbGeneralSettingsFeatureSet, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "step2"
+	params["FeatureSet"] = "STANDARD"
+	params["TransparentMode"] = "false"
+	configText2 := templateFill(testAccVcdNsxtAlbGeneralSettingsFeatureSet, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	params["FuncName"] = t.Name() + "step3"
+	configText3 := templateFill(testAccVcdNsxtAlbGeneralSettingsFeatureSetDS, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 3: %s", configText3)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckVcdAlbControllerDestroy("vcd_nsxt_alb_controller.first"),
+			testAccCheckVcdAlbServiceEngineGroupDestroy("vcd_nsxt_alb_cloud.first"),
+			testAccCheckVcdAlbCloudDestroy("vcd_nsxt_alb_cloud.first"),
+			testAccCheckVcdNsxtEdgeGatewayAlbSettingsDestroy(params["EdgeGw"].(string)),
+		),
+
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_settings.test", "is_active", "true"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_settings.test", "supported_feature_set", "PREMIUM"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_settings.test", "is_transparent_mode_enabled", "true"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_settings.test", "ipv6_service_network_specification", "2001:db8::1/120"),
+					resource.TestCheckResourceAttrPair("vcd_nsxt_alb_settings.test", "license_type", "vcd_nsxt_alb_controller.first", "license_type"),
+				),
+			},
+			{
+				ResourceName:            "vcd_nsxt_alb_settings.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       importStateIdOrgNsxtVdcObject(testConfig, params["EdgeGw"].(string)),
+				ImportStateVerifyIgnore: []string{"vdc"},
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_settings.test", "supported_feature_set", "STANDARD"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_settings.test", "is_transparent_mode_enabled", "false"),
+				),
+			},
+			{
+				Config: configText3,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resourceFieldsEqual("data.vcd_nsxt_alb_settings.test", "vcd_nsxt_alb_settings.test", []string{"vdc"}),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdNsxtAlbGeneralSettingsFeatureSet = testAccVcdNsxtAlbProviderPrereqs + `
+data "vcd_nsxt_edgegateway" "existing" {
+  org = "{{.Org}}"
+  vdc = "{{.NsxtVdc}}"
+
+  name = "{{.EdgeGw}}"
+}
+
+resource "vcd_nsxt_alb_settings" "test" {
+  org = "{{.Org}}"
+
+  edge_gateway_id                    = data.vcd_nsxt_edgegateway.existing.id
+  is_active                          = true
+  supported_feature_set              = "{{.FeatureSet}}"
+  is_transparent_mode_enabled        = {{.TransparentMode}}
+  ipv6_service_network_specification = "2001:db8::1/120"
+
+  # This dependency is required to make sure that provider part of operations is done
+  depends_on = [vcd_nsxt_alb_service_engine_group.first]
+}
+`
+
+const testAccVcdNsxtAlbGeneralSettingsFeatureSetDS = testAccVcdNsxtAlbGeneralSettingsFeatureSet + `
+# skip-binary-test: Terraform resource cannot have resource and datasource in the same file
+
+data "vcd_nsxt_alb_settings" "test" {
+  org = "{{.Org}}"
+
+  edge_gateway_id = vcd_nsxt_alb_settings.test.edge_gateway_id
+}
+`
+
 func testAccCheckVcdNsxtEdgeGatewayAlbSettingsDestroy(edgeName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
@@ -82,6 +82,17 @@ func resourceVcdAlbVirtualService() *schema.Resource {
 				Required:    true,
 				Description: "Virtual IP address (VIP) for Virtual Service",
 			},
+			"ipv6_virtual_ip_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "IPv6 Virtual IP address (VIP) for Virtual Service (VCD 10.4.0+)",
+			},
+			"is_transparent_mode_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Preserves client IP on a Virtual Service (VCD 10.4.1+)",
+			},
 			"application_profile_type": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -92,10 +103,56 @@ func resourceVcdAlbVirtualService() *schema.Resource {
 				Optional: true,
 				Elem:     nsxtAlbVirtualServicePort,
 			},
+			"health_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Health status of Virtual Service",
+			},
+			"health_message": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Health message of Virtual Service",
+			},
+			"detailed_health_message": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Detailed health message of Virtual Service",
+			},
 		},
 	}
 }
 
+// nsxtAlbVirtualServiceIpv6MinApiVersion is the first API version which supports IPv6 Virtual IP
+// address for ALB Virtual Services (VCD 10.4.0)
+const nsxtAlbVirtualServiceIpv6MinApiVersion = "37.0"
+
+// nsxtAlbVirtualServiceTransparentModeMinApiVersion is the first API version which supports
+// transparent mode for ALB Virtual Services (VCD 10.4.1)
+const nsxtAlbVirtualServiceTransparentModeMinApiVersion = "37.1"
+
+// nsxtAlbVirtualServiceApiVersions lists API versions used for nsxtAlbVirtualService type
+var nsxtAlbVirtualServiceApiVersions = []string{
+	"35.0",
+	nsxtAlbVirtualServiceIpv6MinApiVersion,
+	nsxtAlbVirtualServiceTransparentModeMinApiVersion,
+}
+
+// nsxtAlbVirtualService extends types.NsxtAlbVirtualService with IPv6 Virtual IP address and
+// transparent mode fields
+type nsxtAlbVirtualService struct {
+	types.NsxtAlbVirtualService
+	// Ipv6VirtualIpAddress is an optional IPv6 Virtual IP address in addition to VirtualIpAddress
+	Ipv6VirtualIpAddress string `json:"ipv6VirtualIpAddress,omitempty"`
+	// TransparentModeEnabled preserves client IP address when forwarding traffic to pool members
+	TransparentModeEnabled *bool `json:"transparentModeEnabled,omitempty"`
+}
+
+// usesExtendedFeatures returns true if the Virtual Service cannot be handled by SDK types
+func (virtualService *nsxtAlbVirtualService) usesExtendedFeatures() bool {
+	return virtualService.Ipv6VirtualIpAddress != "" ||
+		(virtualService.TransparentModeEnabled != nil && *virtualService.TransparentModeEnabled)
+}
+
 var nsxtAlbVirtualServicePort = &schema.Resource{
 	Schema: map[string]*schema.Schema{
 		"start_port": {
@@ -124,14 +181,29 @@ var nsxtAlbVirtualServicePort = &schema.Resource{
 
 func resourceVcdAlbVirtualServiceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	vcdClient.lockParentEdgeGtw(d)
-	defer vcdClient.unLockParentEdgeGtw(d)
+	unlock, _, err := lockParentVdcGroupOrEdgeGateway(vcdClient, d, "ALB Virtual Service create")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
 
-	albVirtualServiceConfig, err := getNsxtAlbVirtualServiceType(d)
+	albVirtualServiceConfig, err := getNsxtAlbVirtualServiceExtendedType(vcdClient, d)
 	if err != nil {
 		return diag.Errorf("error getting NSX-T ALB Virtual Service type: %s", err)
 	}
-	createdAlbVirtualService, err := vcdClient.CreateNsxtAlbVirtualService(albVirtualServiceConfig)
+
+	// SDK does not know IPv6 and transparent mode fields therefore such Virtual Services are
+	// created directly
+	if albVirtualServiceConfig.usesExtendedFeatures() {
+		createdAlbVirtualService, err := createNsxtAlbVirtualService(&vcdClient.Client, albVirtualServiceConfig)
+		if err != nil {
+			return diag.Errorf("error setting NSX-T ALB Virtual Service: %s", err)
+		}
+		d.SetId(createdAlbVirtualService.ID)
+		return resourceVcdAlbVirtualServiceRead(ctx, d, meta)
+	}
+
+	createdAlbVirtualService, err := vcdClient.CreateNsxtAlbVirtualService(&albVirtualServiceConfig.NsxtAlbVirtualService)
 	if err != nil {
 		return diag.Errorf("error setting NSX-T ALB Virtual Service: %s", err)
 	}
@@ -143,21 +215,34 @@ func resourceVcdAlbVirtualServiceCreate(ctx context.Context, d *schema.ResourceD
 
 func resourceVcdAlbVirtualServiceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	vcdClient.lockParentEdgeGtw(d)
-	defer vcdClient.unLockParentEdgeGtw(d)
+	unlock, _, err := lockParentVdcGroupOrEdgeGateway(vcdClient, d, "ALB Virtual Service update")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
 
 	albVirtualService, err := vcdClient.GetAlbVirtualServiceById(d.Id())
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("could not retrieve NSX-T ALB Virtual Service: %s", err))
 	}
 
-	updateVirtualServiceConfig, err := getNsxtAlbVirtualServiceType(d)
+	updateVirtualServiceConfig, err := getNsxtAlbVirtualServiceExtendedType(vcdClient, d)
 	if err != nil {
 		return diag.Errorf("error getting NSX-T ALB Virtual Service type: %s", err)
 	}
 	updateVirtualServiceConfig.ID = d.Id()
 
-	_, err = albVirtualService.Update(updateVirtualServiceConfig)
+	// Updating a Virtual Service using SDK types would remove fields that are not known to SDK in
+	// VCD versions that support them
+	if vcdClient.Client.APIVCDMaxVersionIs(">= " + nsxtAlbVirtualServiceIpv6MinApiVersion) {
+		err = updateNsxtAlbVirtualService(&vcdClient.Client, updateVirtualServiceConfig)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error updating NSX-T ALB Virtual Service: %s", err))
+		}
+		return resourceVcdAlbVirtualServiceRead(ctx, d, meta)
+	}
+
+	_, err = albVirtualService.Update(&updateVirtualServiceConfig.NsxtAlbVirtualService)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error updating NSX-T ALB Virtual Service: %s", err))
 	}
@@ -168,7 +253,7 @@ func resourceVcdAlbVirtualServiceUpdate(ctx context.Context, d *schema.ResourceD
 func resourceVcdAlbVirtualServiceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
-	albVirtualService, err := vcdClient.GetAlbVirtualServiceById(d.Id())
+	albVirtualService, err := getNsxtAlbVirtualServiceById(&vcdClient.Client, d.Id())
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
 			d.SetId("")
@@ -177,18 +262,22 @@ func resourceVcdAlbVirtualServiceRead(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(fmt.Errorf("could not retrieve NSX-T ALB Virtual Service: %s", err))
 	}
 
-	err = setNsxtAlbVirtualServiceData(d, albVirtualService.NsxtAlbVirtualService)
+	err = setNsxtAlbVirtualServiceData(d, &albVirtualService.NsxtAlbVirtualService)
 	if err != nil {
 		return diag.Errorf("error setting NSX-T ALB Virtual Service data: %s", err)
 	}
-	d.SetId(albVirtualService.NsxtAlbVirtualService.ID)
+	setNsxtAlbVirtualServiceExtendedData(d, albVirtualService)
+	d.SetId(albVirtualService.ID)
 	return nil
 }
 
 func resourceVcdAlbVirtualServiceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	vcdClient.lockParentEdgeGtw(d)
-	defer vcdClient.unLockParentEdgeGtw(d)
+	unlock, _, err := lockParentVdcGroupOrEdgeGateway(vcdClient, d, "ALB Virtual Service delete")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
 
 	albPool, err := vcdClient.GetAlbVirtualServiceById(d.Id())
 	if err != nil {
@@ -265,6 +354,31 @@ func getNsxtAlbVirtualServiceType(d *schema.ResourceData) (*types.NsxtAlbVirtual
 	return albVirtualServiceConfig, nil
 }
 
+func getNsxtAlbVirtualServiceExtendedType(vcdClient *VCDClient, d *schema.ResourceData) (*nsxtAlbVirtualService, error) {
+	baseConfig, err := getNsxtAlbVirtualServiceType(d)
+	if err != nil {
+		return nil, err
+	}
+
+	albVirtualServiceConfig := &nsxtAlbVirtualService{NsxtAlbVirtualService: *baseConfig}
+
+	if ipv6VirtualIpAddress := d.Get("ipv6_virtual_ip_address").(string); ipv6VirtualIpAddress != "" {
+		if vcdClient.Client.APIVCDMaxVersionIs("< " + nsxtAlbVirtualServiceIpv6MinApiVersion) {
+			return nil, fmt.Errorf("'ipv6_virtual_ip_address' requires VCD 10.4.0+")
+		}
+		albVirtualServiceConfig.Ipv6VirtualIpAddress = ipv6VirtualIpAddress
+	}
+
+	isTransparentModeEnabled := d.Get("is_transparent_mode_enabled").(bool)
+	if vcdClient.Client.APIVCDMaxVersionIs(">= " + nsxtAlbVirtualServiceTransparentModeMinApiVersion) {
+		albVirtualServiceConfig.TransparentModeEnabled = &isTransparentModeEnabled
+	} else if isTransparentModeEnabled {
+		return nil, fmt.Errorf("'is_transparent_mode_enabled' requires VCD 10.4.1+")
+	}
+
+	return albVirtualServiceConfig, nil
+}
+
 func getNsxtAlbVirtualServicePortType(d *schema.ResourceData) ([]types.NsxtAlbVirtualServicePort, error) {
 	servicePortSet := d.Get("service_port").(*schema.Set)
 	servicePortSlice := make([]types.NsxtAlbVirtualServicePort, len(servicePortSet.List()))
@@ -302,6 +416,10 @@ func setNsxtAlbVirtualServiceData(d *schema.ResourceData, albVirtualService *typ
 
 	dSet(d, "application_profile_type", albVirtualService.ApplicationProfile.Type)
 
+	dSet(d, "health_status", albVirtualService.HealthStatus)
+	dSet(d, "health_message", albVirtualService.HealthMessage)
+	dSet(d, "detailed_health_message", albVirtualService.DetailedHealthMessage)
+
 	// Optional fields
 	if albVirtualService.CertificateRef != nil {
 		dSet(d, "ca_certificate_id", albVirtualService.CertificateRef.ID)
@@ -343,3 +461,61 @@ func setNsxtAlbVirtualServicePortData(d *schema.ResourceData, ports []types.Nsxt
 	}
 	return nil
 }
+
+// setNsxtAlbVirtualServiceExtendedData stores IPv6 Virtual IP address and transparent mode fields.
+// Older VCD versions do not return them.
+func setNsxtAlbVirtualServiceExtendedData(d *schema.ResourceData, albVirtualService *nsxtAlbVirtualService) {
+	dSet(d, "ipv6_virtual_ip_address", albVirtualService.Ipv6VirtualIpAddress)
+	isTransparentModeEnabled := false
+	if albVirtualService.TransparentModeEnabled != nil {
+		isTransparentModeEnabled = *albVirtualService.TransparentModeEnabled
+	}
+	dSet(d, "is_transparent_mode_enabled", isTransparentModeEnabled)
+}
+
+func getNsxtAlbVirtualServiceById(client *govcd.Client, id string) (*nsxtAlbVirtualService, error) {
+	albVirtualService := &nsxtAlbVirtualService{}
+	err := openApiGetExtendedItem(client, nsxtAlbVirtualServiceApiVersions, albVirtualService,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointAlbVirtualServices, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return albVirtualService, nil
+}
+
+// getNsxtAlbVirtualServiceByName looks up ALB Virtual Service summary by name and retrieves
+// complete ALB Virtual Service by ID
+func getNsxtAlbVirtualServiceByName(vcdClient *VCDClient, edgeGatewayId, name string) (*nsxtAlbVirtualService, error) {
+	albVirtualServiceSummaries, err := vcdClient.GetAllAlbVirtualServiceSummaries(edgeGatewayId, openApiFilterAnd("name=="+name))
+	if err != nil {
+		return nil, fmt.Errorf("error reading ALB Virtual Service with Name '%s': %s", name, err)
+	}
+
+	if len(albVirtualServiceSummaries) == 0 {
+		return nil, fmt.Errorf("%s: could not find ALB Virtual Service with Name '%s'", govcd.ErrorEntityNotFound, name)
+	}
+
+	if len(albVirtualServiceSummaries) > 1 {
+		return nil, fmt.Errorf("found more than 1 ALB Virtual Service with Name '%s'", name)
+	}
+
+	return getNsxtAlbVirtualServiceById(&vcdClient.Client, albVirtualServiceSummaries[0].NsxtAlbVirtualService.ID)
+}
+
+func createNsxtAlbVirtualService(client *govcd.Client, albVirtualServiceConfig *nsxtAlbVirtualService) (*nsxtAlbVirtualService, error) {
+	createdAlbVirtualService := &nsxtAlbVirtualService{}
+	err := openApiPostExtendedItem(client, nsxtAlbVirtualServiceApiVersions, albVirtualServiceConfig, createdAlbVirtualService,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointAlbVirtualServices)
+	if err != nil {
+		return nil, err
+	}
+
+	return createdAlbVirtualService, nil
+}
+
+func updateNsxtAlbVirtualService(client *govcd.Client, albVirtualServiceConfig *nsxtAlbVirtualService) error {
+	updatedAlbVirtualService := &nsxtAlbVirtualService{}
+	return openApiPutExtendedItem(client, nsxtAlbVirtualServiceApiVersions, albVirtualServiceConfig, updatedAlbVirtualService,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointAlbVirtualServices, albVirtualServiceConfig.ID)
+}
@@ -0,0 +1,290 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+func resourceVcdAlbVirtualServiceHttpReqRules() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdAlbVirtualServiceHttpReqRulesCreateUpdate,
+		ReadContext:   resourceVcdAlbVirtualServiceHttpReqRulesRead,
+		UpdateContext: resourceVcdAlbVirtualServiceHttpReqRulesCreateUpdate,
+		DeleteContext: resourceVcdAlbVirtualServiceHttpReqRulesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdAlbVirtualServiceHttpRulesImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"virtual_service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "NSX-T ALB Virtual Service ID",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "A list of HTTP request rules. Order defines rule precedence",
+				Elem:        nsxtAlbVirtualServiceHttpReqRule,
+			},
+		},
+	}
+}
+
+var nsxtAlbVirtualServiceHttpReqRule = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the rule",
+		},
+		"active": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Defines if the rule is active (default 'true')",
+		},
+		"logging": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Defines if requests matching the rule should be logged (default 'false')",
+		},
+		"match_criteria": albVsHttpMatchCriteriaSchema(false),
+		"actions": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Description: "Actions to perform on matching requests. Exactly one action type must be set",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"redirect": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Description: "Redirect request to a different location",
+						Elem:        albVsHttpRedirectActionSchema,
+					},
+					"modify_header": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Description: "Add, remove or replace HTTP request headers",
+						Elem:        albVsHttpHeaderActionSchema,
+					},
+					"rewrite_url": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Description: "Rewrite request URL",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"host_header": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "Host header to use for the rewritten URL",
+								},
+								"existing_path": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "Path to use for the rewritten URL",
+								},
+								"keep_query": {
+									Type:        schema.TypeBool,
+									Optional:    true,
+									Default:     true,
+									Description: "Preserve query string of the original request (default 'true')",
+								},
+								"query": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Description: "Query string to add to the rewritten URL",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+// albVsHttpRequestRules is the structure of HTTP request rules endpoint of ALB Virtual Service
+type albVsHttpRequestRules struct {
+	Values []albVsHttpRequestRule `json:"values"`
+}
+
+type albVsHttpRequestRule struct {
+	Name             string                     `json:"name"`
+	Active           bool                       `json:"active"`
+	Logging          bool                       `json:"logging"`
+	MatchCriteria    albVsHttpRuleMatchCriteria `json:"matchCriteria"`
+	HeaderActions    []albVsHttpHeaderAction    `json:"headerActions,omitempty"`
+	RedirectAction   *albVsHttpRedirectAction   `json:"redirectAction,omitempty"`
+	RewriteUrlAction *albVsHttpRewriteUrlAction `json:"rewriteUrlAction,omitempty"`
+}
+
+type albVsHttpRewriteUrlAction struct {
+	HostHeader   string `json:"hostHeader"`
+	ExistingPath string `json:"existingPath"`
+	KeepQuery    bool   `json:"keepQuery"`
+	Query        string `json:"query,omitempty"`
+}
+
+func resourceVcdAlbVirtualServiceHttpReqRulesCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	unlock, err := lockAlbVsHttpRulesParent(vcdClient, d, "ALB Virtual Service HTTP request rules create/update")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	virtualServiceId := d.Get("virtual_service_id").(string)
+
+	rules, err := getAlbVsHttpRequestRulesType(d)
+	if err != nil {
+		return diag.Errorf("error getting NSX-T ALB Virtual Service HTTP request rules type: %s", err)
+	}
+
+	err = updateAlbVsHttpRules(&vcdClient.Client, virtualServiceId, nsxtAlbVirtualServiceHttpRequestRulesPath, rules, &albVsHttpRequestRules{})
+	if err != nil {
+		return diag.Errorf("error setting NSX-T ALB Virtual Service HTTP request rules: %s", err)
+	}
+
+	d.SetId(virtualServiceId)
+
+	return resourceVcdAlbVirtualServiceHttpReqRulesRead(ctx, d, meta)
+}
+
+func resourceVcdAlbVirtualServiceHttpReqRulesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	rules := &albVsHttpRequestRules{}
+	err := getAlbVsHttpRules(&vcdClient.Client, d.Id(), nsxtAlbVirtualServiceHttpRequestRulesPath, rules)
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error retrieving NSX-T ALB Virtual Service HTTP request rules: %s", err)
+	}
+
+	err = setAlbVsHttpRequestRulesData(d, rules)
+	if err != nil {
+		return diag.Errorf("error storing NSX-T ALB Virtual Service HTTP request rules: %s", err)
+	}
+
+	return nil
+}
+
+func resourceVcdAlbVirtualServiceHttpReqRulesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	unlock, err := lockAlbVsHttpRulesParent(vcdClient, d, "ALB Virtual Service HTTP request rules delete")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	virtualServiceId := d.Get("virtual_service_id").(string)
+
+	err = updateAlbVsHttpRules(&vcdClient.Client, virtualServiceId, nsxtAlbVirtualServiceHttpRequestRulesPath,
+		&albVsHttpRequestRules{Values: []albVsHttpRequestRule{}}, &albVsHttpRequestRules{})
+	if err != nil {
+		return diag.Errorf("error removing NSX-T ALB Virtual Service HTTP request rules: %s", err)
+	}
+
+	return nil
+}
+
+func getAlbVsHttpRequestRulesType(d *schema.ResourceData) (*albVsHttpRequestRules, error) {
+	ruleList := d.Get("rule").([]interface{})
+	rules := &albVsHttpRequestRules{Values: make([]albVsHttpRequestRule, len(ruleList))}
+
+	for index, rule := range ruleList {
+		ruleMap := rule.(map[string]interface{})
+		singleRule := albVsHttpRequestRule{
+			Name:          ruleMap["name"].(string),
+			Active:        ruleMap["active"].(bool),
+			Logging:       ruleMap["logging"].(bool),
+			MatchCriteria: getAlbVsHttpMatchCriteriaType(ruleMap["match_criteria"].([]interface{}), false),
+		}
+
+		actions := ruleMap["actions"].([]interface{})
+		if len(actions) == 0 || actions[0] == nil {
+			return nil, fmt.Errorf("rule '%s' must have one of 'redirect', 'modify_header', 'rewrite_url' actions", singleRule.Name)
+		}
+		actionsMap := actions[0].(map[string]interface{})
+
+		singleRule.RedirectAction = getAlbVsHttpRedirectActionType(actionsMap["redirect"].([]interface{}))
+		singleRule.HeaderActions = getAlbVsHttpHeaderActionsType(actionsMap["modify_header"].([]interface{}))
+		if rewriteUrl := actionsMap["rewrite_url"].([]interface{}); len(rewriteUrl) > 0 && rewriteUrl[0] != nil {
+			rewriteUrlMap := rewriteUrl[0].(map[string]interface{})
+			singleRule.RewriteUrlAction = &albVsHttpRewriteUrlAction{
+				HostHeader:   rewriteUrlMap["host_header"].(string),
+				ExistingPath: rewriteUrlMap["existing_path"].(string),
+				KeepQuery:    rewriteUrlMap["keep_query"].(bool),
+				Query:        rewriteUrlMap["query"].(string),
+			}
+		}
+
+		actionCount := 0
+		for _, isSet := range []bool{singleRule.RedirectAction != nil, len(singleRule.HeaderActions) > 0, singleRule.RewriteUrlAction != nil} {
+			if isSet {
+				actionCount++
+			}
+		}
+		if actionCount != 1 {
+			return nil, fmt.Errorf("rule '%s' must have exactly one of 'redirect', 'modify_header', 'rewrite_url' actions", singleRule.Name)
+		}
+
+		rules.Values[index] = singleRule
+	}
+
+	return rules, nil
+}
+
+func setAlbVsHttpRequestRulesData(d *schema.ResourceData, rules *albVsHttpRequestRules) error {
+	ruleSlice := make([]interface{}, len(rules.Values))
+	for index, rule := range rules.Values {
+		actionsMap := map[string]interface{}{
+			"redirect":      getAlbVsHttpRedirectActionData(rule.RedirectAction),
+			"modify_header": getAlbVsHttpHeaderActionsData(rule.HeaderActions),
+		}
+		if rule.RewriteUrlAction != nil {
+			actionsMap["rewrite_url"] = []interface{}{map[string]interface{}{
+				"host_header":   rule.RewriteUrlAction.HostHeader,
+				"existing_path": rule.RewriteUrlAction.ExistingPath,
+				"keep_query":    rule.RewriteUrlAction.KeepQuery,
+				"query":         rule.RewriteUrlAction.Query,
+			}}
+		}
+
+		ruleSlice[index] = map[string]interface{}{
+			"name":           rule.Name,
+			"active":         rule.Active,
+			"logging":        rule.Logging,
+			"match_criteria": getAlbVsHttpMatchCriteriaData(rule.MatchCriteria, false),
+			"actions":        []interface{}{actionsMap},
+		}
+	}
+
+	dSet(d, "virtual_service_id", d.Id())
+	err := d.Set("rule", ruleSlice)
+	if err != nil {
+		return fmt.Errorf("error setting 'rule' block: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,289 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+func resourceVcdAlbVirtualServiceHttpRespRules() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdAlbVirtualServiceHttpRespRulesCreateUpdate,
+		ReadContext:   resourceVcdAlbVirtualServiceHttpRespRulesRead,
+		UpdateContext: resourceVcdAlbVirtualServiceHttpRespRulesCreateUpdate,
+		DeleteContext: resourceVcdAlbVirtualServiceHttpRespRulesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdAlbVirtualServiceHttpRulesImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"virtual_service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "NSX-T ALB Virtual Service ID",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "A list of HTTP response rules. Order defines rule precedence",
+				Elem:        nsxtAlbVirtualServiceHttpRespRule,
+			},
+		},
+	}
+}
+
+var nsxtAlbVirtualServiceHttpRespRule = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the rule",
+		},
+		"active": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Defines if the rule is active (default 'true')",
+		},
+		"logging": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Defines if responses matching the rule should be logged (default 'false')",
+		},
+		"match_criteria": albVsHttpMatchCriteriaSchema(true),
+		"actions": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Description: "Actions to perform on matching responses. Exactly one action type must be set",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"rewrite_location_header": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Description: "Rewrite location header of the response",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"protocol": {
+									Type:         schema.TypeString,
+									Required:     true,
+									Description:  "Protocol to use in location header - 'HTTP' or 'HTTPS'",
+									ValidateFunc: validation.StringInSlice([]string{"HTTP", "HTTPS"}, false),
+								},
+								"port": {
+									Type:        schema.TypeInt,
+									Optional:    true,
+									Description: "Port to use in location header",
+								},
+								"host": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Description: "Host to use in location header",
+								},
+								"path": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Description: "Path to use in location header",
+								},
+								"keep_query": {
+									Type:        schema.TypeBool,
+									Optional:    true,
+									Default:     true,
+									Description: "Preserve query string (default 'true')",
+								},
+							},
+						},
+					},
+					"modify_header": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Description: "Add, remove or replace HTTP response headers",
+						Elem:        albVsHttpHeaderActionSchema,
+					},
+				},
+			},
+		},
+	},
+}
+
+// albVsHttpResponseRules is the structure of HTTP response rules endpoint of ALB Virtual Service
+type albVsHttpResponseRules struct {
+	Values []albVsHttpResponseRule `json:"values"`
+}
+
+type albVsHttpResponseRule struct {
+	Name                        string                                `json:"name"`
+	Active                      bool                                  `json:"active"`
+	Logging                     bool                                  `json:"logging"`
+	MatchCriteria               albVsHttpRuleMatchCriteria            `json:"matchCriteria"`
+	HeaderActions               []albVsHttpHeaderAction               `json:"headerActions,omitempty"`
+	RewriteLocationHeaderAction *albVsHttpRewriteLocationHeaderAction `json:"rewriteLocationHeaderAction,omitempty"`
+}
+
+type albVsHttpRewriteLocationHeaderAction struct {
+	Protocol  string `json:"protocol"`
+	Port      *int   `json:"port,omitempty"`
+	Host      string `json:"host,omitempty"`
+	Path      string `json:"path,omitempty"`
+	KeepQuery bool   `json:"keepQuery"`
+}
+
+func resourceVcdAlbVirtualServiceHttpRespRulesCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	unlock, err := lockAlbVsHttpRulesParent(vcdClient, d, "ALB Virtual Service HTTP response rules create/update")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	virtualServiceId := d.Get("virtual_service_id").(string)
+
+	rules, err := getAlbVsHttpResponseRulesType(d)
+	if err != nil {
+		return diag.Errorf("error getting NSX-T ALB Virtual Service HTTP response rules type: %s", err)
+	}
+
+	err = updateAlbVsHttpRules(&vcdClient.Client, virtualServiceId, nsxtAlbVirtualServiceHttpResponseRulesPath, rules, &albVsHttpResponseRules{})
+	if err != nil {
+		return diag.Errorf("error setting NSX-T ALB Virtual Service HTTP response rules: %s", err)
+	}
+
+	d.SetId(virtualServiceId)
+
+	return resourceVcdAlbVirtualServiceHttpRespRulesRead(ctx, d, meta)
+}
+
+func resourceVcdAlbVirtualServiceHttpRespRulesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	rules := &albVsHttpResponseRules{}
+	err := getAlbVsHttpRules(&vcdClient.Client, d.Id(), nsxtAlbVirtualServiceHttpResponseRulesPath, rules)
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error retrieving NSX-T ALB Virtual Service HTTP response rules: %s", err)
+	}
+
+	err = setAlbVsHttpResponseRulesData(d, rules)
+	if err != nil {
+		return diag.Errorf("error storing NSX-T ALB Virtual Service HTTP response rules: %s", err)
+	}
+
+	return nil
+}
+
+func resourceVcdAlbVirtualServiceHttpRespRulesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	unlock, err := lockAlbVsHttpRulesParent(vcdClient, d, "ALB Virtual Service HTTP response rules delete")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	virtualServiceId := d.Get("virtual_service_id").(string)
+
+	err = updateAlbVsHttpRules(&vcdClient.Client, virtualServiceId, nsxtAlbVirtualServiceHttpResponseRulesPath,
+		&albVsHttpResponseRules{Values: []albVsHttpResponseRule{}}, &albVsHttpResponseRules{})
+	if err != nil {
+		return diag.Errorf("error removing NSX-T ALB Virtual Service HTTP response rules: %s", err)
+	}
+
+	return nil
+}
+
+func getAlbVsHttpResponseRulesType(d *schema.ResourceData) (*albVsHttpResponseRules, error) {
+	ruleList := d.Get("rule").([]interface{})
+	rules := &albVsHttpResponseRules{Values: make([]albVsHttpResponseRule, len(ruleList))}
+
+	for index, rule := range ruleList {
+		ruleMap := rule.(map[string]interface{})
+		singleRule := albVsHttpResponseRule{
+			Name:          ruleMap["name"].(string),
+			Active:        ruleMap["active"].(bool),
+			Logging:       ruleMap["logging"].(bool),
+			MatchCriteria: getAlbVsHttpMatchCriteriaType(ruleMap["match_criteria"].([]interface{}), true),
+		}
+
+		actions := ruleMap["actions"].([]interface{})
+		if len(actions) == 0 || actions[0] == nil {
+			return nil, fmt.Errorf("rule '%s' must have one of 'rewrite_location_header', 'modify_header' actions", singleRule.Name)
+		}
+		actionsMap := actions[0].(map[string]interface{})
+
+		singleRule.HeaderActions = getAlbVsHttpHeaderActionsType(actionsMap["modify_header"].([]interface{}))
+		if rewrite := actionsMap["rewrite_location_header"].([]interface{}); len(rewrite) > 0 && rewrite[0] != nil {
+			rewriteMap := rewrite[0].(map[string]interface{})
+			singleRule.RewriteLocationHeaderAction = &albVsHttpRewriteLocationHeaderAction{
+				Protocol:  rewriteMap["protocol"].(string),
+				Host:      rewriteMap["host"].(string),
+				Path:      rewriteMap["path"].(string),
+				KeepQuery: rewriteMap["keep_query"].(bool),
+			}
+			if port := rewriteMap["port"].(int); port != 0 {
+				singleRule.RewriteLocationHeaderAction.Port = takeIntPointer(port)
+			}
+		}
+
+		if (singleRule.RewriteLocationHeaderAction != nil) == (len(singleRule.HeaderActions) > 0) {
+			return nil, fmt.Errorf("rule '%s' must have exactly one of 'rewrite_location_header', 'modify_header' actions", singleRule.Name)
+		}
+
+		rules.Values[index] = singleRule
+	}
+
+	return rules, nil
+}
+
+func setAlbVsHttpResponseRulesData(d *schema.ResourceData, rules *albVsHttpResponseRules) error {
+	ruleSlice := make([]interface{}, len(rules.Values))
+	for index, rule := range rules.Values {
+		actionsMap := map[string]interface{}{
+			"modify_header": getAlbVsHttpHeaderActionsData(rule.HeaderActions),
+		}
+		if rule.RewriteLocationHeaderAction != nil {
+			rewriteMap := map[string]interface{}{
+				"protocol":   rule.RewriteLocationHeaderAction.Protocol,
+				"host":       rule.RewriteLocationHeaderAction.Host,
+				"path":       rule.RewriteLocationHeaderAction.Path,
+				"keep_query": rule.RewriteLocationHeaderAction.KeepQuery,
+			}
+			if rule.RewriteLocationHeaderAction.Port != nil {
+				rewriteMap["port"] = *rule.RewriteLocationHeaderAction.Port
+			}
+			actionsMap["rewrite_location_header"] = []interface{}{rewriteMap}
+		}
+
+		ruleSlice[index] = map[string]interface{}{
+			"name":           rule.Name,
+			"active":         rule.Active,
+			"logging":        rule.Logging,
+			"match_criteria": getAlbVsHttpMatchCriteriaData(rule.MatchCriteria, true),
+			"actions":        []interface{}{actionsMap},
+		}
+	}
+
+	dSet(d, "virtual_service_id", d.Id())
+	err := d.Set("rule", ruleSlice)
+	if err != nil {
+		return fmt.Errorf("error setting 'rule' block: %s", err)
+	}
+
+	return nil
+}
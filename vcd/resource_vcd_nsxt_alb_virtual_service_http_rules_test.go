@@ -0,0 +1,297 @@
+//go:build nsxt || alb || ALL || functional
+// +build nsxt alb ALL functional
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccVcdNsxtAlbVirtualServiceHttpRules tests HTTP request, response and security rules of an
+// ALB Virtual Service (VCD 10.5.0+)
+func TestAccVcdNsxtAlbVirtualServiceHttpRules(t *testing.T) {
+	preTestChecks(t)
+	if !usingSysAdmin() {
+		t.Skip(t.Name() + " requires system admin privileges")
+		return
+	}
+
+	skipNoNsxtAlbConfiguration(t)
+
+	vcdClient := createTemporaryVCDConnection(false)
+	if vcdClient.Client.APIVCDMaxVersionIs("< " + nsxtAlbVirtualServiceHttpRulesMinApiVersion) {
+		t.Skipf("This test tests VCD 10.5.0+ (API V38.0+) features. Skipping.")
+	}
+
+	// String map to fill the template
+	var params = StringMap{
+		"VirtualServiceName": t.Name(),
+		"ControllerName":     t.Name(),
+		"ControllerUrl":      testConfig.Nsxt.NsxtAlbControllerUrl,
+		"ControllerUsername": testConfig.Nsxt.NsxtAlbControllerUser,
+		"ControllerPassword": testConfig.Nsxt.NsxtAlbControllerPassword,
+		"ImportableCloud":    testConfig.Nsxt.NsxtAlbImportableCloud,
+		"ReservationModel":   "DEDICATED",
+		"Org":                testConfig.VCD.Org,
+		"NsxtVdc":            testConfig.Nsxt.Vdc,
+		"EdgeGw":             testConfig.Nsxt.EdgeGateway,
+		"IsActive":           "true",
+		"Tags":               "nsxt alb",
+	}
+	testParamsNotEmpty(t, params)
+
+	params["FuncName"] = t.Name() + "step1"
+	configText1 := templateFill(testAccVcdNsxtAlbVirtualServiceHttpRulesStep1, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "step2"
+	configText2 := templateFill(testAccVcdNsxtAlbVirtualServiceHttpRulesStep2, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckVcdAlbControllerDestroy("vcd_nsxt_alb_controller.first"),
+			testAccCheckVcdAlbServiceEngineGroupDestroy("vcd_nsxt_alb_cloud.first"),
+			testAccCheckVcdAlbCloudDestroy("vcd_nsxt_alb_cloud.first"),
+			testAccCheckVcdNsxtEdgeGatewayAlbSettingsDestroy(params["EdgeGw"].(string)),
+			testAccCheckVcdAlbVirtualServiceDestroy("vcd_nsxt_alb_virtual_service.test"),
+		),
+
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("vcd_nsxt_alb_virtual_service_http_req_rules.test", "id", "vcd_nsxt_alb_virtual_service.test", "id"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_req_rules.test", "rule.#", "3"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_req_rules.test", "rule.0.name", "redirect"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_req_rules.test", "rule.0.actions.0.redirect.0.status_code", "302"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_req_rules.test", "rule.1.name", "modify-header"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_req_rules.test", "rule.1.actions.0.modify_header.#", "2"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_req_rules.test", "rule.2.name", "rewrite-url"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_req_rules.test", "rule.2.actions.0.rewrite_url.0.existing_path", "/new"),
+
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_resp_rules.test", "rule.#", "1"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_resp_rules.test", "rule.0.match_criteria.0.status_code.0.criteria", "IS_IN"),
+
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_sec_rules.test", "rule.#", "3"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_sec_rules.test", "rule.0.actions.0.connections", "CLOSE"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_sec_rules.test", "rule.1.actions.0.rate_limit.0.count", "100"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_sec_rules.test", "rule.2.actions.0.redirect_to_https", "443"),
+				),
+			},
+			{
+				ResourceName:      "vcd_nsxt_alb_virtual_service_http_req_rules.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdNsxtEdgeGatewayObject(testConfig, testConfig.Nsxt.EdgeGateway, t.Name()),
+			},
+			{
+				ResourceName:      "vcd_nsxt_alb_virtual_service_http_resp_rules.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdNsxtEdgeGatewayObject(testConfig, testConfig.Nsxt.EdgeGateway, t.Name()),
+			},
+			{
+				ResourceName:      "vcd_nsxt_alb_virtual_service_http_sec_rules.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdNsxtEdgeGatewayObject(testConfig, testConfig.Nsxt.EdgeGateway, t.Name()),
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_req_rules.test", "rule.#", "1"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_req_rules.test", "rule.0.name", "rewrite-url"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_req_rules.test", "rule.0.active", "false"),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdNsxtAlbVirtualServiceHttpRulesPrereqs = testAccVcdNsxtAlbVirtualServicePrereqs + `
+resource "vcd_nsxt_alb_virtual_service" "test" {
+  org = "{{.Org}}"
+
+  name            = "{{.VirtualServiceName}}"
+  edge_gateway_id = vcd_nsxt_alb_settings.test.edge_gateway_id
+
+  pool_id                  = vcd_nsxt_alb_pool.test.id
+  service_engine_group_id  = vcd_nsxt_alb_edgegateway_service_engine_group.assignment.service_engine_group_id
+  virtual_ip_address       = tolist(data.vcd_nsxt_edgegateway.existing.subnet)[0].primary_ip
+  application_profile_type = "HTTP"
+  service_port {
+    start_port = 80
+    type       = "TCP_PROXY"
+  }
+}
+
+resource "vcd_nsxt_alb_virtual_service_http_resp_rules" "test" {
+  org                = "{{.Org}}"
+  virtual_service_id = vcd_nsxt_alb_virtual_service.test.id
+
+  rule {
+    name = "rewrite-location"
+
+    match_criteria {
+      status_code {
+        criteria          = "IS_IN"
+        http_status_codes = ["301", "302"]
+      }
+    }
+
+    actions {
+      rewrite_location_header {
+        protocol = "HTTPS"
+        port     = 443
+        host     = "example.com"
+      }
+    }
+  }
+}
+
+resource "vcd_nsxt_alb_virtual_service_http_sec_rules" "test" {
+  org                = "{{.Org}}"
+  virtual_service_id = vcd_nsxt_alb_virtual_service.test.id
+
+  rule {
+    name = "deny-network"
+
+    match_criteria {
+      client_ip_address {
+        criteria     = "IS_IN"
+        ip_addresses = ["10.10.10.0/24"]
+      }
+    }
+
+    actions {
+      connections = "CLOSE"
+    }
+  }
+
+  rule {
+    name    = "rate-limit"
+    logging = true
+
+    actions {
+      rate_limit {
+        count  = 100
+        period = 60
+
+        action_local_response {
+          status_code  = 429
+          content_type = "text/plain"
+        }
+      }
+    }
+  }
+
+  rule {
+    name = "https-only"
+
+    match_criteria {
+      protocol_type = "HTTP"
+    }
+
+    actions {
+      redirect_to_https = 443
+    }
+  }
+}
+`
+
+const testAccVcdNsxtAlbVirtualServiceHttpRulesStep1 = testAccVcdNsxtAlbVirtualServiceHttpRulesPrereqs + `
+resource "vcd_nsxt_alb_virtual_service_http_req_rules" "test" {
+  org                = "{{.Org}}"
+  virtual_service_id = vcd_nsxt_alb_virtual_service.test.id
+
+  rule {
+    name = "redirect"
+
+    match_criteria {
+      path {
+        criteria = "BEGINS_WITH"
+        paths    = ["/old"]
+      }
+
+      http_methods {
+        criteria = "IS_IN"
+        methods  = ["GET", "HEAD"]
+      }
+    }
+
+    actions {
+      redirect {
+        protocol    = "HTTPS"
+        port        = 443
+        status_code = 302
+        path        = "/new"
+      }
+    }
+  }
+
+  rule {
+    name = "modify-header"
+
+    match_criteria {
+      request_headers {
+        criteria = "EXISTS"
+        name     = "X-Debug"
+      }
+    }
+
+    actions {
+      modify_header {
+        action = "REMOVE"
+        name   = "X-Debug"
+      }
+
+      modify_header {
+        action = "ADD"
+        name   = "X-Terraform"
+        value  = "true"
+      }
+    }
+  }
+
+  rule {
+    name = "rewrite-url"
+
+    actions {
+      rewrite_url {
+        host_header   = "internal.example.com"
+        existing_path = "/new"
+        keep_query    = false
+      }
+    }
+  }
+}
+`
+
+const testAccVcdNsxtAlbVirtualServiceHttpRulesStep2 = testAccVcdNsxtAlbVirtualServiceHttpRulesPrereqs + `
+resource "vcd_nsxt_alb_virtual_service_http_req_rules" "test" {
+  org                = "{{.Org}}"
+  virtual_service_id = vcd_nsxt_alb_virtual_service.test.id
+
+  rule {
+    name   = "rewrite-url"
+    active = false
+
+    actions {
+      rewrite_url {
+        host_header   = "internal.example.com"
+        existing_path = "/new"
+      }
+    }
+  }
+}
+`
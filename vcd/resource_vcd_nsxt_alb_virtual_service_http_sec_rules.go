@@ -0,0 +1,353 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+func resourceVcdAlbVirtualServiceHttpSecRules() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdAlbVirtualServiceHttpSecRulesCreateUpdate,
+		ReadContext:   resourceVcdAlbVirtualServiceHttpSecRulesRead,
+		UpdateContext: resourceVcdAlbVirtualServiceHttpSecRulesCreateUpdate,
+		DeleteContext: resourceVcdAlbVirtualServiceHttpSecRulesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdAlbVirtualServiceHttpRulesImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"virtual_service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "NSX-T ALB Virtual Service ID",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "A list of HTTP security rules. Order defines rule precedence",
+				Elem:        nsxtAlbVirtualServiceHttpSecRule,
+			},
+		},
+	}
+}
+
+var nsxtAlbVirtualServiceHttpSecRule = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the rule",
+		},
+		"active": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Defines if the rule is active (default 'true')",
+		},
+		"logging": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Defines if requests matching the rule should be logged (default 'false')",
+		},
+		"match_criteria": albVsHttpMatchCriteriaSchema(false),
+		"actions": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Description: "Actions to perform on matching requests. Exactly one action type must be set",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"redirect_to_https": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Description:  "Port to use for redirecting HTTP requests to HTTPS",
+						ValidateFunc: validation.IsPortNumber,
+					},
+					"connections": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Description:  "Allow or close connections - 'ALLOW' or 'CLOSE'",
+						ValidateFunc: validation.StringInSlice([]string{"ALLOW", "CLOSE"}, false),
+					},
+					"send_response": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Description: "Respond to request directly from the Virtual Service",
+						Elem:        albVsHttpLocalResponseActionSchema,
+					},
+					"rate_limit": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Description: "Limit the rate of matching requests",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"count": {
+									Type:         schema.TypeInt,
+									Required:     true,
+									Description:  "Maximum number of connections, requests or packets permitted per period",
+									ValidateFunc: validation.IntAtLeast(1),
+								},
+								"period": {
+									Type:         schema.TypeInt,
+									Required:     true,
+									Description:  "Time value in seconds to enforce rate count",
+									ValidateFunc: validation.IntAtLeast(1),
+								},
+								"action_close_connection": {
+									Type:        schema.TypeBool,
+									Optional:    true,
+									Description: "Close connection when rate limit is exceeded",
+								},
+								"action_redirect": {
+									Type:        schema.TypeList,
+									Optional:    true,
+									MaxItems:    1,
+									Description: "Redirect request when rate limit is exceeded",
+									Elem:        albVsHttpRedirectActionSchema,
+								},
+								"action_local_response": {
+									Type:        schema.TypeList,
+									Optional:    true,
+									MaxItems:    1,
+									Description: "Send local response when rate limit is exceeded",
+									Elem:        albVsHttpLocalResponseActionSchema,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+// albVsHttpSecurityRules is the structure of HTTP security rules endpoint of ALB Virtual Service
+type albVsHttpSecurityRules struct {
+	Values []albVsHttpSecurityRule `json:"values"`
+}
+
+type albVsHttpSecurityRule struct {
+	Name                  string                          `json:"name"`
+	Active                bool                            `json:"active"`
+	Logging               bool                            `json:"logging"`
+	MatchCriteria         albVsHttpRuleMatchCriteria      `json:"matchCriteria"`
+	AllowOrDenyAction     *albVsHttpAllowOrDenyAction     `json:"allowOrDenyAction,omitempty"`
+	RateLimitAction       *albVsHttpRateLimitAction       `json:"rateLimitAction,omitempty"`
+	RedirectToHttpsAction *albVsHttpRedirectToHttpsAction `json:"redirectToHTTPSAction,omitempty"`
+	LocalResponseAction   *albVsHttpLocalResponseAction   `json:"localResponseAction,omitempty"`
+}
+
+type albVsHttpAllowOrDenyAction struct {
+	AllowOrDeny string `json:"allowOrDeny"`
+}
+
+type albVsHttpRedirectToHttpsAction struct {
+	Port int `json:"port"`
+}
+
+type albVsHttpRateLimitAction struct {
+	Count                 int                           `json:"count"`
+	Period                int                           `json:"period"`
+	CloseConnectionAction string                        `json:"closeConnectionAction,omitempty"`
+	RedirectAction        *albVsHttpRedirectAction      `json:"redirectAction,omitempty"`
+	LocalResponseAction   *albVsHttpLocalResponseAction `json:"localResponseAction,omitempty"`
+}
+
+func resourceVcdAlbVirtualServiceHttpSecRulesCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	unlock, err := lockAlbVsHttpRulesParent(vcdClient, d, "ALB Virtual Service HTTP security rules create/update")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	virtualServiceId := d.Get("virtual_service_id").(string)
+
+	rules, err := getAlbVsHttpSecurityRulesType(d)
+	if err != nil {
+		return diag.Errorf("error getting NSX-T ALB Virtual Service HTTP security rules type: %s", err)
+	}
+
+	err = updateAlbVsHttpRules(&vcdClient.Client, virtualServiceId, nsxtAlbVirtualServiceHttpSecurityRulesPath, rules, &albVsHttpSecurityRules{})
+	if err != nil {
+		return diag.Errorf("error setting NSX-T ALB Virtual Service HTTP security rules: %s", err)
+	}
+
+	d.SetId(virtualServiceId)
+
+	return resourceVcdAlbVirtualServiceHttpSecRulesRead(ctx, d, meta)
+}
+
+func resourceVcdAlbVirtualServiceHttpSecRulesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	rules := &albVsHttpSecurityRules{}
+	err := getAlbVsHttpRules(&vcdClient.Client, d.Id(), nsxtAlbVirtualServiceHttpSecurityRulesPath, rules)
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error retrieving NSX-T ALB Virtual Service HTTP security rules: %s", err)
+	}
+
+	err = setAlbVsHttpSecurityRulesData(d, rules)
+	if err != nil {
+		return diag.Errorf("error storing NSX-T ALB Virtual Service HTTP security rules: %s", err)
+	}
+
+	return nil
+}
+
+func resourceVcdAlbVirtualServiceHttpSecRulesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	unlock, err := lockAlbVsHttpRulesParent(vcdClient, d, "ALB Virtual Service HTTP security rules delete")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	virtualServiceId := d.Get("virtual_service_id").(string)
+
+	err = updateAlbVsHttpRules(&vcdClient.Client, virtualServiceId, nsxtAlbVirtualServiceHttpSecurityRulesPath,
+		&albVsHttpSecurityRules{Values: []albVsHttpSecurityRule{}}, &albVsHttpSecurityRules{})
+	if err != nil {
+		return diag.Errorf("error removing NSX-T ALB Virtual Service HTTP security rules: %s", err)
+	}
+
+	return nil
+}
+
+func getAlbVsHttpSecurityRulesType(d *schema.ResourceData) (*albVsHttpSecurityRules, error) {
+	ruleList := d.Get("rule").([]interface{})
+	rules := &albVsHttpSecurityRules{Values: make([]albVsHttpSecurityRule, len(ruleList))}
+
+	for index, rule := range ruleList {
+		ruleMap := rule.(map[string]interface{})
+		singleRule := albVsHttpSecurityRule{
+			Name:          ruleMap["name"].(string),
+			Active:        ruleMap["active"].(bool),
+			Logging:       ruleMap["logging"].(bool),
+			MatchCriteria: getAlbVsHttpMatchCriteriaType(ruleMap["match_criteria"].([]interface{}), false),
+		}
+
+		actions := ruleMap["actions"].([]interface{})
+		if len(actions) == 0 || actions[0] == nil {
+			return nil, fmt.Errorf("rule '%s' must have one of 'redirect_to_https', 'connections', 'send_response', 'rate_limit' actions", singleRule.Name)
+		}
+		actionsMap := actions[0].(map[string]interface{})
+
+		actionCount := 0
+		if port := actionsMap["redirect_to_https"].(int); port != 0 {
+			singleRule.RedirectToHttpsAction = &albVsHttpRedirectToHttpsAction{Port: port}
+			actionCount++
+		}
+		if connections := actionsMap["connections"].(string); connections != "" {
+			singleRule.AllowOrDenyAction = &albVsHttpAllowOrDenyAction{AllowOrDeny: connections}
+			actionCount++
+		}
+		if localResponse := getAlbVsHttpLocalResponseActionType(actionsMap["send_response"].([]interface{})); localResponse != nil {
+			singleRule.LocalResponseAction = localResponse
+			actionCount++
+		}
+		if rateLimit := actionsMap["rate_limit"].([]interface{}); len(rateLimit) > 0 && rateLimit[0] != nil {
+			rateLimitAction, err := getAlbVsHttpRateLimitActionType(rateLimit[0].(map[string]interface{}))
+			if err != nil {
+				return nil, fmt.Errorf("rule '%s': %s", singleRule.Name, err)
+			}
+			singleRule.RateLimitAction = rateLimitAction
+			actionCount++
+		}
+
+		if actionCount != 1 {
+			return nil, fmt.Errorf("rule '%s' must have exactly one of 'redirect_to_https', 'connections', 'send_response', 'rate_limit' actions", singleRule.Name)
+		}
+
+		rules.Values[index] = singleRule
+	}
+
+	return rules, nil
+}
+
+func getAlbVsHttpRateLimitActionType(rateLimitMap map[string]interface{}) (*albVsHttpRateLimitAction, error) {
+	rateLimitAction := &albVsHttpRateLimitAction{
+		Count:               rateLimitMap["count"].(int),
+		Period:              rateLimitMap["period"].(int),
+		RedirectAction:      getAlbVsHttpRedirectActionType(rateLimitMap["action_redirect"].([]interface{})),
+		LocalResponseAction: getAlbVsHttpLocalResponseActionType(rateLimitMap["action_local_response"].([]interface{})),
+	}
+
+	actionCount := 0
+	if rateLimitMap["action_close_connection"].(bool) {
+		rateLimitAction.CloseConnectionAction = "CLOSE"
+		actionCount++
+	}
+	if rateLimitAction.RedirectAction != nil {
+		actionCount++
+	}
+	if rateLimitAction.LocalResponseAction != nil {
+		actionCount++
+	}
+	if actionCount > 1 {
+		return nil, fmt.Errorf("only one of 'action_close_connection', 'action_redirect', 'action_local_response' can be set in 'rate_limit'")
+	}
+
+	return rateLimitAction, nil
+}
+
+func setAlbVsHttpSecurityRulesData(d *schema.ResourceData, rules *albVsHttpSecurityRules) error {
+	ruleSlice := make([]interface{}, len(rules.Values))
+	for index, rule := range rules.Values {
+		actionsMap := map[string]interface{}{
+			"send_response": getAlbVsHttpLocalResponseActionData(rule.LocalResponseAction),
+		}
+		if rule.RedirectToHttpsAction != nil {
+			actionsMap["redirect_to_https"] = rule.RedirectToHttpsAction.Port
+		}
+		if rule.AllowOrDenyAction != nil {
+			actionsMap["connections"] = rule.AllowOrDenyAction.AllowOrDeny
+		}
+		if rule.RateLimitAction != nil {
+			actionsMap["rate_limit"] = []interface{}{map[string]interface{}{
+				"count":                   rule.RateLimitAction.Count,
+				"period":                  rule.RateLimitAction.Period,
+				"action_close_connection": rule.RateLimitAction.CloseConnectionAction == "CLOSE",
+				"action_redirect":         getAlbVsHttpRedirectActionData(rule.RateLimitAction.RedirectAction),
+				"action_local_response":   getAlbVsHttpLocalResponseActionData(rule.RateLimitAction.LocalResponseAction),
+			}}
+		}
+
+		ruleSlice[index] = map[string]interface{}{
+			"name":           rule.Name,
+			"active":         rule.Active,
+			"logging":        rule.Logging,
+			"match_criteria": getAlbVsHttpMatchCriteriaData(rule.MatchCriteria, false),
+			"actions":        []interface{}{actionsMap},
+		}
+	}
+
+	dSet(d, "virtual_service_id", d.Id())
+	err := d.Set("rule", ruleSlice)
+	if err != nil {
+		return fmt.Errorf("error setting 'rule' block: %s", err)
+	}
+
+	return nil
+}
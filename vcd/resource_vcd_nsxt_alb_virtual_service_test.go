@@ -750,3 +750,123 @@ func testAccCheckVcdAlbVirtualServiceDestroy(resource string) resource.TestCheck
 		return nil
 	}
 }
+
+// TestAccVcdNsxtAlbVirtualServiceTransparentMode tests transparent mode (preserve client IP) and
+// computed health fields of NSX-T ALB Virtual Service (VCD 10.4.1+)
+func TestAccVcdNsxtAlbVirtualServiceTransparentMode(t *testing.T) {
+	preTestChecks(t)
+	if !usingSysAdmin() {
+		t.Skip(t.Name() + " requires system admin privileges")
+		return
+	}
+
+	skipNoNsxtAlbConfiguration(t)
+
+	vcdClient := createTemporaryVCDConnection(false)
+	if vcdClient.Client.APIVCDMaxVersionIs("< " + nsxtAlbVirtualServiceTransparentModeMinApiVersion) {
+		t.Skipf("This test tests VCD 10.4.1+ (API V37.1+) features. Skipping.")
+	}
+
+	// String map to fill the template
+	var params = StringMap{
+		"VirtualServiceName": t.Name(),
+		"ControllerName":     t.Name(),
+		"ControllerUrl":      testConfig.Nsxt.NsxtAlbControllerUrl,
+		"ControllerUsername": testConfig.Nsxt.NsxtAlbControllerUser,
+		"ControllerPassword": testConfig.Nsxt.NsxtAlbControllerPassword,
+		"ImportableCloud":    testConfig.Nsxt.NsxtAlbImportableCloud,
+		"ReservationModel":   "DEDICATED",
+		"Org":                testConfig.VCD.Org,
+		"NsxtVdc":            testConfig.Nsxt.Vdc,
+		"EdgeGw":             testConfig.Nsxt.EdgeGateway,
+		"IsActive":           "true",
+		"TransparentMode":    "true",
+		"Tags":               "nsxt alb",
+	}
+	testParamsNotEmpty(t, params)
+
+	params["FuncName"] = t.Name() + "step1"
+	configText1 := templateFill(testAccVcdNsxtAlbVirtualServiceTransparentModeStep1, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "step2"
+	params["TransparentMode"] = "false"
+	configText2 := templateFill(testAccVcdNsxtAlbVirtualServiceTransparentModeStep1, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	params["FuncName"] = t.Name() + "step3"
+	configText3 := templateFill(testAccVcdNsxtAlbVirtualServiceTransparentModeStep3, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 3: %s", configText3)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckVcdAlbControllerDestroy("vcd_nsxt_alb_controller.first"),
+			testAccCheckVcdAlbServiceEngineGroupDestroy("vcd_nsxt_alb_cloud.first"),
+			testAccCheckVcdAlbCloudDestroy("vcd_nsxt_alb_cloud.first"),
+			testAccCheckVcdNsxtEdgeGatewayAlbSettingsDestroy(params["EdgeGw"].(string)),
+			testAccCheckVcdAlbVirtualServiceDestroy("vcd_nsxt_alb_virtual_service.test"),
+		),
+
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("vcd_nsxt_alb_virtual_service.test", "id", regexp.MustCompile(`^urn:vcloud:loadBalancerVirtualService:`)),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service.test", "is_transparent_mode_enabled", "true"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service.test", "ipv6_virtual_ip_address", ""),
+					resource.TestCheckResourceAttrSet("vcd_nsxt_alb_virtual_service.test", "health_status"),
+				),
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service.test", "is_transparent_mode_enabled", "false"),
+					resource.TestCheckResourceAttrSet("vcd_nsxt_alb_virtual_service.test", "health_status"),
+				),
+			},
+			{
+				ResourceName:            "vcd_nsxt_alb_virtual_service.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       importStateIdNsxtEdgeGatewayObject(testConfig, testConfig.Nsxt.EdgeGateway, params["VirtualServiceName"].(string)),
+				ImportStateVerifyIgnore: []string{"vdc", "health_status", "health_message", "detailed_health_message"},
+			},
+			{
+				Config: configText3, // Datasource check
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resourceFieldsEqual("data.vcd_nsxt_alb_virtual_service.test", "vcd_nsxt_alb_virtual_service.test",
+						[]string{"health_status", "health_message", "detailed_health_message"}),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdNsxtAlbVirtualServiceTransparentModeStep1 = testAccVcdNsxtAlbVirtualServicePrereqs + `
+resource "vcd_nsxt_alb_virtual_service" "test" {
+  org = "{{.Org}}"
+  vdc = "{{.NsxtVdc}}"
+
+  name            = "{{.VirtualServiceName}}"
+  edge_gateway_id = vcd_nsxt_alb_settings.test.edge_gateway_id
+
+  pool_id                     = vcd_nsxt_alb_pool.test.id
+  service_engine_group_id     = vcd_nsxt_alb_edgegateway_service_engine_group.assignment.service_engine_group_id
+  virtual_ip_address          = tolist(data.vcd_nsxt_edgegateway.existing.subnet)[0].primary_ip
+  is_transparent_mode_enabled = {{.TransparentMode}}
+  application_profile_type    = "HTTP"
+  service_port {
+    start_port = 80
+    type       = "TCP_PROXY"
+  }
+}
+`
+
+const testAccVcdNsxtAlbVirtualServiceTransparentModeStep3 = testAccVcdNsxtAlbVirtualServiceTransparentModeStep1 + testAccVcdNsxtAlbVirtualServiceDS
@@ -265,42 +265,7 @@ func setDistributedFirewallData(vcdClient *VCDClient, dfwRules *types.Distribute
 
 	result := make([]interface{}, len(dfwRules.Values))
 	for index, value := range dfwRules.Values {
-		sourceSlice := extractIdsFromOpenApiReferences(value.SourceFirewallGroups)
-		sourceSet := convertStringsToTypeSet(sourceSlice)
-
-		destinationSlice := extractIdsFromOpenApiReferences(value.DestinationFirewallGroups)
-		destinationSet := convertStringsToTypeSet(destinationSlice)
-
-		appPortProfileSlice := extractIdsFromOpenApiReferences(value.ApplicationPortProfiles)
-		appPortProfileSet := convertStringsToTypeSet(appPortProfileSlice)
-
-		netContextProfileSlice := extractIdsFromOpenApiReferences(value.NetworkContextProfiles)
-		netPortProfileSet := convertStringsToTypeSet(netContextProfileSlice)
-
-		var actionFieldValue string
-		if vcdClient.Client.APIVCDMaxVersionIs(">= 35.2") {
-			actionFieldValue = value.ActionValue
-		} else { // TODO remove this when VCD 10.2 support is dropped
-			actionFieldValue = value.Action
-		}
-
-		result[index] = map[string]interface{}{
-			"id":                          value.ID,
-			"name":                        value.Name,
-			"description":                 value.Description,
-			"comment":                     value.Comments,
-			"action":                      actionFieldValue,
-			"enabled":                     value.Enabled,
-			"ip_protocol":                 value.IpProtocol,
-			"direction":                   value.Direction,
-			"logging":                     value.Logging,
-			"source_ids":                  sourceSet,
-			"destination_ids":             destinationSet,
-			"app_port_profile_ids":        appPortProfileSet,
-			"network_context_profile_ids": netPortProfileSet,
-			"source_groups_excluded":      value.SourceGroupsExcluded,
-			"destination_groups_excluded": value.DestinationGroupsExcluded,
-		}
+		result[index] = getDistributedFirewallRuleMap(vcdClient, value)
 	}
 
 	return d.Set("rule", result)
@@ -311,87 +276,137 @@ func getDistributedFirewallTypes(vcdClient *VCDClient, d *schema.ResourceData) (
 	if len(ruleInterfaceSlice) > 0 {
 		sliceOfRules := make([]*types.DistributedFirewallRule, len(ruleInterfaceSlice))
 		for index, oneRule := range ruleInterfaceSlice {
-			oneRuleMapInterface := oneRule.(map[string]interface{})
-
-			sliceOfRules[index] = &types.DistributedFirewallRule{
-				Name:        oneRuleMapInterface["name"].(string),
-				Description: oneRuleMapInterface["description"].(string),
-				ActionValue: oneRuleMapInterface["action"].(string),
-				Enabled:     oneRuleMapInterface["enabled"].(bool),
-				IpProtocol:  oneRuleMapInterface["ip_protocol"].(string),
-				Logging:     oneRuleMapInterface["logging"].(bool),
-				Direction:   oneRuleMapInterface["direction"].(string),
-				Version:     nil,
+			rule, err := getDistributedFirewallRuleType(vcdClient, oneRule.(map[string]interface{}))
+			if err != nil {
+				return nil, err
 			}
+			sliceOfRules[index] = rule
+		}
 
-			if oneRuleMapInterface["source_ids"] != nil {
-				sourceGroupIds := convertSchemaSetToSliceOfStrings(oneRuleMapInterface["source_ids"].(*schema.Set))
-				sliceOfRules[index].SourceFirewallGroups = convertSliceOfStringsToOpenApiReferenceIds(sourceGroupIds)
-			}
+		return &types.DistributedFirewallRules{Values: sliceOfRules}, nil
+	}
 
-			if oneRuleMapInterface["destination_ids"] != nil {
-				destinationGroupIds := convertSchemaSetToSliceOfStrings(oneRuleMapInterface["destination_ids"].(*schema.Set))
-				sliceOfRules[index].DestinationFirewallGroups = convertSliceOfStringsToOpenApiReferenceIds(destinationGroupIds)
-			}
+	return nil, nil
+}
 
-			if oneRuleMapInterface["app_port_profile_ids"] != nil {
-				appPortProfileIds := convertSchemaSetToSliceOfStrings(oneRuleMapInterface["app_port_profile_ids"].(*schema.Set))
-				sliceOfRules[index].ApplicationPortProfiles = convertSliceOfStringsToOpenApiReferenceIds(appPortProfileIds)
-			}
+// getDistributedFirewallRuleMap converts a single Distributed Firewall Rule to a map which matches
+// rule schema
+func getDistributedFirewallRuleMap(vcdClient *VCDClient, value *types.DistributedFirewallRule) map[string]interface{} {
+	sourceSlice := extractIdsFromOpenApiReferences(value.SourceFirewallGroups)
+	sourceSet := convertStringsToTypeSet(sourceSlice)
 
-			if oneRuleMapInterface["network_context_profile_ids"] != nil {
-				networkContextPortProfileIds := convertSchemaSetToSliceOfStrings(oneRuleMapInterface["network_context_profile_ids"].(*schema.Set))
-				sliceOfRules[index].NetworkContextProfiles = convertSliceOfStringsToOpenApiReferenceIds(networkContextPortProfileIds)
-			}
+	destinationSlice := extractIdsFromOpenApiReferences(value.DestinationFirewallGroups)
+	destinationSet := convertStringsToTypeSet(destinationSlice)
 
-			// Perform version specific conversion
-			// TODO remove when VCD 10.2 is not supported anymore
+	appPortProfileSlice := extractIdsFromOpenApiReferences(value.ApplicationPortProfiles)
+	appPortProfileSet := convertStringsToTypeSet(appPortProfileSlice)
 
-			// ActionValue was introduced in API V35.2 (VCD 10.2.2), for 10.2.0 Action field must
-			// still be used
-			if vcdClient.Client.APIVCDMaxVersionIs("< 35.2") {
-				sliceOfRules[index].Action = sliceOfRules[index].ActionValue
-				sliceOfRules[index].ActionValue = ""
-			}
+	netContextProfileSlice := extractIdsFromOpenApiReferences(value.NetworkContextProfiles)
+	netPortProfileSet := convertStringsToTypeSet(netContextProfileSlice)
 
-			// Fields requiring 10.3.2+
-			// TODO remove when VCD 10.3 is not supported anymore
-			comment := oneRuleMapInterface["comment"].(string)
-			sourceGroupsExcluded := oneRuleMapInterface["source_groups_excluded"].(bool)
-			destinationGroupsExcluded := oneRuleMapInterface["destination_groups_excluded"].(bool)
-			if vcdClient.Client.APIVCDMaxVersionIs(">= 36.2") {
-				sliceOfRules[index].Comments = comment
-
-				if sourceGroupsExcluded {
-					sliceOfRules[index].SourceGroupsExcluded = &sourceGroupsExcluded
-				}
-
-				if destinationGroupsExcluded {
-					sliceOfRules[index].DestinationGroupsExcluded = &destinationGroupsExcluded
-				}
-			} else {
-				if comment != "" {
-					return nil, fmt.Errorf("field 'comment' can only be set in VCD 10.3.2+")
-				}
-
-				// Two below checks will only throw an error if 'true' value has been set. False
-				// will be ignored (when either set, or not set at all), because the only somewhat
-				// reliable way is to use d.GetOkExists which has been deprecated in SDK with no
-				// reliable replacement. There is no real need to use it here so just leaving one
-				// less place to fix in future if d.GetOkExists is removed from SDK.
-				if sourceGroupsExcluded {
-					return nil, fmt.Errorf("field 'source_groups_excluded' can only be enabled in VCD 10.3.2+")
-				}
-
-				if destinationGroupsExcluded {
-					return nil, fmt.Errorf("field 'source_groups_excluded' can only be enabled in VCD 10.3.2+")
-				}
-			}
+	var actionFieldValue string
+	if vcdClient.Client.APIVCDMaxVersionIs(">= 35.2") {
+		actionFieldValue = value.ActionValue
+	} else { // TODO remove this when VCD 10.2 support is dropped
+		actionFieldValue = value.Action
+	}
+
+	return map[string]interface{}{
+		"id":                          value.ID,
+		"name":                        value.Name,
+		"description":                 value.Description,
+		"comment":                     value.Comments,
+		"action":                      actionFieldValue,
+		"enabled":                     value.Enabled,
+		"ip_protocol":                 value.IpProtocol,
+		"direction":                   value.Direction,
+		"logging":                     value.Logging,
+		"source_ids":                  sourceSet,
+		"destination_ids":             destinationSet,
+		"app_port_profile_ids":        appPortProfileSet,
+		"network_context_profile_ids": netPortProfileSet,
+		"source_groups_excluded":      value.SourceGroupsExcluded,
+		"destination_groups_excluded": value.DestinationGroupsExcluded,
+	}
+}
 
+// getDistributedFirewallRuleType converts a map matching rule schema to a single Distributed
+// Firewall Rule
+func getDistributedFirewallRuleType(vcdClient *VCDClient, oneRuleMapInterface map[string]interface{}) (*types.DistributedFirewallRule, error) {
+	rule := &types.DistributedFirewallRule{
+		Name:        oneRuleMapInterface["name"].(string),
+		Description: oneRuleMapInterface["description"].(string),
+		ActionValue: oneRuleMapInterface["action"].(string),
+		Enabled:     oneRuleMapInterface["enabled"].(bool),
+		IpProtocol:  oneRuleMapInterface["ip_protocol"].(string),
+		Logging:     oneRuleMapInterface["logging"].(bool),
+		Direction:   oneRuleMapInterface["direction"].(string),
+		Version:     nil,
+	}
+
+	if oneRuleMapInterface["source_ids"] != nil {
+		sourceGroupIds := convertSchemaSetToSliceOfStrings(oneRuleMapInterface["source_ids"].(*schema.Set))
+		rule.SourceFirewallGroups = convertSliceOfStringsToOpenApiReferenceIds(sourceGroupIds)
+	}
+
+	if oneRuleMapInterface["destination_ids"] != nil {
+		destinationGroupIds := convertSchemaSetToSliceOfStrings(oneRuleMapInterface["destination_ids"].(*schema.Set))
+		rule.DestinationFirewallGroups = convertSliceOfStringsToOpenApiReferenceIds(destinationGroupIds)
+	}
+
+	if oneRuleMapInterface["app_port_profile_ids"] != nil {
+		appPortProfileIds := convertSchemaSetToSliceOfStrings(oneRuleMapInterface["app_port_profile_ids"].(*schema.Set))
+		rule.ApplicationPortProfiles = convertSliceOfStringsToOpenApiReferenceIds(appPortProfileIds)
+	}
+
+	if oneRuleMapInterface["network_context_profile_ids"] != nil {
+		networkContextPortProfileIds := convertSchemaSetToSliceOfStrings(oneRuleMapInterface["network_context_profile_ids"].(*schema.Set))
+		rule.NetworkContextProfiles = convertSliceOfStringsToOpenApiReferenceIds(networkContextPortProfileIds)
+	}
+
+	// Perform version specific conversion
+	// TODO remove when VCD 10.2 is not supported anymore
+
+	// ActionValue was introduced in API V35.2 (VCD 10.2.2), for 10.2.0 Action field must
+	// still be used
+	if vcdClient.Client.APIVCDMaxVersionIs("< 35.2") {
+		rule.Action = rule.ActionValue
+		rule.ActionValue = ""
+	}
+
+	// Fields requiring 10.3.2+
+	// TODO remove when VCD 10.3 is not supported anymore
+	comment := oneRuleMapInterface["comment"].(string)
+	sourceGroupsExcluded := oneRuleMapInterface["source_groups_excluded"].(bool)
+	destinationGroupsExcluded := oneRuleMapInterface["destination_groups_excluded"].(bool)
+	if vcdClient.Client.APIVCDMaxVersionIs(">= 36.2") {
+		rule.Comments = comment
+
+		if sourceGroupsExcluded {
+			rule.SourceGroupsExcluded = &sourceGroupsExcluded
 		}
 
-		return &types.DistributedFirewallRules{Values: sliceOfRules}, nil
+		if destinationGroupsExcluded {
+			rule.DestinationGroupsExcluded = &destinationGroupsExcluded
+		}
+	} else {
+		if comment != "" {
+			return nil, fmt.Errorf("field 'comment' can only be set in VCD 10.3.2+")
+		}
+
+		// Two below checks will only throw an error if 'true' value has been set. False
+		// will be ignored (when either set, or not set at all), because the only somewhat
+		// reliable way is to use d.GetOkExists which has been deprecated in SDK with no
+		// reliable replacement. There is no real need to use it here so just leaving one
+		// less place to fix in future if d.GetOkExists is removed from SDK.
+		if sourceGroupsExcluded {
+			return nil, fmt.Errorf("field 'source_groups_excluded' can only be enabled in VCD 10.3.2+")
+		}
+
+		if destinationGroupsExcluded {
+			return nil, fmt.Errorf("field 'source_groups_excluded' can only be enabled in VCD 10.3.2+")
+		}
 	}
 
-	return nil, nil
+	return rule, nil
 }
@@ -0,0 +1,458 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// distributedFirewallRuleFields lists all schema fields which describe a single Distributed Firewall
+// Rule. They match fields of 'rule' block in 'vcd_nsxt_distributed_firewall'
+var distributedFirewallRuleFields = []string{
+	"name", "description", "comment", "direction", "ip_protocol", "action", "enabled", "logging",
+	"source_ids", "destination_ids", "app_port_profile_ids", "network_context_profile_ids",
+	"source_groups_excluded", "destination_groups_excluded",
+}
+
+func resourceVcdNsxtDistributedFirewallRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdNsxtDistributedFirewallRuleCreate,
+		ReadContext:   resourceVcdNsxtDistributedFirewallRuleRead,
+		UpdateContext: resourceVcdNsxtDistributedFirewallRuleUpdate,
+		DeleteContext: resourceVcdNsxtDistributedFirewallRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdNsxtDistributedFirewallRuleImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc_group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of VDC Group for Distributed Firewall",
+			},
+			"above_rule_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "An ID of existing rule above which this rule should be created. Rule is placed at the bottom when empty",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Firewall Rule name",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description is not shown in UI",
+			},
+			"comment": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Comment that is shown next to rule in UI (VCD 10.3.2+)",
+			},
+			"direction": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Direction on which Firewall Rule applies (One of 'IN', 'OUT', 'IN_OUT')",
+				Default:      "IN_OUT",
+				ValidateFunc: validation.StringInSlice([]string{"IN", "OUT", "IN_OUT"}, false),
+			},
+			"ip_protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Firewall Rule Protocol (One of 'IPV4', 'IPV6', 'IPV4_IPV6')",
+				Default:      "IPV4_IPV6",
+				ValidateFunc: validation.StringInSlice([]string{"IPV4", "IPV6", "IPV4_IPV6"}, false),
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Defines if the rule should 'ALLOW', 'DROP', 'REJECT' matching traffic",
+				ValidateFunc: validation.StringInSlice([]string{"ALLOW", "DROP", "REJECT"}, false),
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Defined if Firewall Rule is active",
+			},
+			"logging": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Defines if matching traffic should be logged",
+			},
+			"source_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A set of Source Firewall Group IDs (IP Sets or Security Groups). Leaving it empty means 'Any'",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"destination_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A set of Destination Firewall Group IDs (IP Sets or Security Groups). Leaving it empty means 'Any'",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"app_port_profile_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A set of Application Port Profile IDs. Leaving it empty means 'Any'",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"network_context_profile_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A set of Network Context Profile IDs. Leaving it empty means 'Any'",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"source_groups_excluded": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Reverses firewall matching for to match all except Source Groups specified in 'source_ids' (VCD 10.3.2+)",
+			},
+			"destination_groups_excluded": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Reverses firewall matching for to match all except Destinations Groups specified in 'destination_ids' (VCD 10.3.2+)",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Version of the rule in VCD at last read. Updates are refused if the rule was changed since then",
+			},
+		},
+	}
+}
+
+// resourceVcdNsxtDistributedFirewallRuleCreate creates a single Distributed Firewall Rule. There is
+// no API endpoint to create a single rule, therefore all rules are retrieved, the new one is
+// inserted above 'above_rule_id' (or at the bottom) and the whole list is sent back while holding a
+// lock on the parent VDC Group.
+func resourceVcdNsxtDistributedFirewallRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	vcdClient.lockParentVdcGroup(d)
+	defer vcdClient.unlockParentVdcGroup(d)
+
+	org, err := vcdClient.GetOrgFromResource(d)
+	if err != nil {
+		return diag.Errorf("[Distributed Firewall Rule Create] error retrieving Org: %s", err)
+	}
+
+	vdcGroup, err := org.GetVdcGroupById(d.Get("vdc_group_id").(string))
+	if err != nil {
+		return diag.Errorf("[Distributed Firewall Rule Create] error retrieving VDC Group: %s", err)
+	}
+
+	newRule, err := getDistributedFirewallRuleType(vcdClient, getDistributedFirewallRuleSchemaMap(d))
+	if err != nil {
+		return diag.Errorf("[Distributed Firewall Rule Create] error getting Distributed Firewall Rule type: %s", err)
+	}
+
+	dfw, err := vdcGroup.GetDistributedFirewall()
+	if err != nil {
+		return diag.Errorf("[Distributed Firewall Rule Create] error retrieving Distributed Firewall Rules: %s", err)
+	}
+
+	existingRules := dfw.DistributedFirewallRuleContainer.Values
+	existingRuleIds := make(map[string]bool, len(existingRules))
+	for _, rule := range existingRules {
+		existingRuleIds[rule.ID] = true
+	}
+
+	allRules, err := insertDistributedFirewallRuleAbove(existingRules, newRule, d.Get("above_rule_id").(string))
+	if err != nil {
+		return diag.Errorf("[Distributed Firewall Rule Create] %s", err)
+	}
+
+	updatedDfw, err := vdcGroup.UpdateDistributedFirewall(&types.DistributedFirewallRules{Values: allRules})
+	if err != nil {
+		return diag.Errorf("[Distributed Firewall Rule Create] error creating Distributed Firewall Rule '%s': %s", newRule.Name, err)
+	}
+
+	// The only rule which did not exist before update is the newly created one
+	for _, rule := range updatedDfw.DistributedFirewallRuleContainer.Values {
+		if !existingRuleIds[rule.ID] {
+			d.SetId(rule.ID)
+			break
+		}
+	}
+
+	if d.Id() == "" {
+		return diag.Errorf("[Distributed Firewall Rule Create] unable to find ID of created Distributed Firewall Rule '%s'", newRule.Name)
+	}
+
+	return resourceVcdNsxtDistributedFirewallRuleRead(ctx, d, meta)
+}
+
+// resourceVcdNsxtDistributedFirewallRuleUpdate updates only the targeted rule. It uses optimistic
+// concurrency on the rule version stored in state at last read: the update is refused if the rule
+// in VCD has a different version, and the stored version is sent with the update so that VCD also
+// rejects it if the rule changes between this check and the update itself.
+func resourceVcdNsxtDistributedFirewallRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	vcdClient.lockParentVdcGroup(d)
+	defer vcdClient.unlockParentVdcGroup(d)
+
+	vdcGroupId := d.Get("vdc_group_id").(string)
+	storedVersion := d.Get("version").(int)
+	currentRule, err := getDistributedFirewallRuleById(&vcdClient.Client, vdcGroupId, d.Id())
+	if err != nil {
+		return diag.Errorf("[Distributed Firewall Rule Update] error retrieving Distributed Firewall Rule: %s", err)
+	}
+	err = checkDistributedFirewallRuleVersion(currentRule, storedVersion)
+	if err != nil {
+		return diag.Errorf("[Distributed Firewall Rule Update] %s", err)
+	}
+
+	updatedRule, err := getDistributedFirewallRuleType(vcdClient, getDistributedFirewallRuleSchemaMap(d))
+	if err != nil {
+		return diag.Errorf("[Distributed Firewall Rule Update] error getting Distributed Firewall Rule type: %s", err)
+	}
+	updatedRule.ID = currentRule.ID
+	updatedRule.Version = &types.DistributedFirewallRuleVersion{Version: storedVersion}
+
+	_, err = updateDistributedFirewallRule(&vcdClient.Client, vdcGroupId, updatedRule)
+	if err != nil {
+		// A version conflict detected by VCD is reported with the same message as the one above
+		latestRule, getErr := getDistributedFirewallRuleById(&vcdClient.Client, vdcGroupId, d.Id())
+		if getErr == nil {
+			if versionErr := checkDistributedFirewallRuleVersion(latestRule, storedVersion); versionErr != nil {
+				return diag.Errorf("[Distributed Firewall Rule Update] %s", versionErr)
+			}
+		}
+		return diag.Errorf("[Distributed Firewall Rule Update] error updating Distributed Firewall Rule '%s': %s", updatedRule.Name, err)
+	}
+
+	return resourceVcdNsxtDistributedFirewallRuleRead(ctx, d, meta)
+}
+
+// checkDistributedFirewallRuleVersion returns an error if the rule in VCD doesn't have the version
+// stored in state, which means that it was modified outside of Terraform since the last read
+func checkDistributedFirewallRuleVersion(rule *types.DistributedFirewallRule, storedVersion int) error {
+	currentVersion := 0
+	if rule.Version != nil {
+		currentVersion = rule.Version.Version
+	}
+	if currentVersion != storedVersion {
+		return fmt.Errorf("distributed Firewall Rule '%s' was modified outside of Terraform (version %d in state, %d in VCD). "+
+			"Refresh the state and review the plan before updating it", rule.Name, storedVersion, currentVersion)
+	}
+	return nil
+}
+
+func resourceVcdNsxtDistributedFirewallRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	rule, err := getDistributedFirewallRuleById(&vcdClient.Client, d.Get("vdc_group_id").(string), d.Id())
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] Distributed Firewall Rule with ID '%s' not found. Removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[Distributed Firewall Rule Read] error retrieving Distributed Firewall Rule: %s", err)
+	}
+
+	ruleMap := getDistributedFirewallRuleMap(vcdClient, rule)
+	for _, field := range distributedFirewallRuleFields {
+		err = d.Set(field, ruleMap[field])
+		if err != nil {
+			return diag.Errorf("[Distributed Firewall Rule Read] error setting '%s': %s", field, err)
+		}
+	}
+	if rule.Version != nil {
+		dSet(d, "version", rule.Version.Version)
+	}
+
+	return nil
+}
+
+func resourceVcdNsxtDistributedFirewallRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	vcdClient.lockParentVdcGroup(d)
+	defer vcdClient.unlockParentVdcGroup(d)
+
+	err := deleteDistributedFirewallRule(&vcdClient.Client, d.Get("vdc_group_id").(string), d.Id())
+	if err != nil {
+		return diag.Errorf("[Distributed Firewall Rule Delete] %s", err)
+	}
+
+	return nil
+}
+
+func resourceVcdNsxtDistributedFirewallRuleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Printf("[TRACE] NSX-T Distributed Firewall Rule import initiated")
+
+	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	if len(resourceURI) != 3 {
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-group-name.rule-name")
+	}
+
+	orgName, vdcGroupName, ruleName := resourceURI[0], resourceURI[1], resourceURI[2]
+
+	vcdClient := meta.(*VCDClient)
+	adminOrg, err := vcdClient.GetAdminOrg(orgName)
+	if err != nil {
+		return nil, fmt.Errorf("[Distributed Firewall Rule Import] error retrieving org %s: %s", orgName, err)
+	}
+
+	vdcGroup, err := adminOrg.GetVdcGroupByName(vdcGroupName)
+	if err != nil {
+		return nil, fmt.Errorf("[Distributed Firewall Rule Import] error retrieving VDC Group '%s': %s", vdcGroupName, err)
+	}
+
+	dfw, err := vdcGroup.GetDistributedFirewall()
+	if err != nil {
+		return nil, fmt.Errorf("[Distributed Firewall Rule Import] error retrieving Distributed Firewall Rules: %s", err)
+	}
+
+	// Rule names are not enforced to be unique therefore import must fail if more than one rule
+	// with the same name exists
+	var foundRules []*types.DistributedFirewallRule
+	for _, rule := range dfw.DistributedFirewallRuleContainer.Values {
+		if rule.Name == ruleName {
+			foundRules = append(foundRules, rule)
+		}
+	}
+
+	if len(foundRules) != 1 {
+		return nil, fmt.Errorf("[Distributed Firewall Rule Import] expected exactly one Distributed Firewall Rule with name '%s'. Got %d", ruleName, len(foundRules))
+	}
+
+	dSet(d, "org", orgName)
+	dSet(d, "vdc_group_id", vdcGroup.VdcGroup.Id)
+	d.SetId(foundRules[0].ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// getDistributedFirewallRuleSchemaMap collects rule fields into a map which has the same structure
+// as 'rule' block in 'vcd_nsxt_distributed_firewall' so that conversion functions can be shared
+func getDistributedFirewallRuleSchemaMap(d *schema.ResourceData) map[string]interface{} {
+	ruleMap := make(map[string]interface{}, len(distributedFirewallRuleFields))
+	for _, field := range distributedFirewallRuleFields {
+		ruleMap[field] = d.Get(field)
+	}
+	return ruleMap
+}
+
+// insertDistributedFirewallRuleAbove inserts a rule above the rule with aboveRuleId or appends it
+// to the end of the list if aboveRuleId is empty
+func insertDistributedFirewallRuleAbove(rules []*types.DistributedFirewallRule, rule *types.DistributedFirewallRule, aboveRuleId string) ([]*types.DistributedFirewallRule, error) {
+	if aboveRuleId == "" {
+		return append(rules, rule), nil
+	}
+
+	for index := range rules {
+		if rules[index].ID == aboveRuleId {
+			result := make([]*types.DistributedFirewallRule, 0, len(rules)+1)
+			result = append(result, rules[:index]...)
+			result = append(result, rule)
+			result = append(result, rules[index:]...)
+			return result, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unable to find Distributed Firewall Rule with ID '%s' specified in 'above_rule_id'", aboveRuleId)
+}
+
+// distributedFirewallRuleApiVersion returns the highest API version which is supported by VCD for
+// Distributed Firewall Rules. It matches the version elevation done by SDK for the whole rule list.
+func distributedFirewallRuleApiVersion(client *govcd.Client) string {
+	switch {
+	case client.APIVCDMaxVersionIs(">= 36.2"):
+		return "36.2"
+	case client.APIVCDMaxVersionIs(">= 35.2"):
+		return "35.2"
+	default:
+		return "35.0"
+	}
+}
+
+// getDistributedFirewallRuleUrl builds URL for a single Distributed Firewall Rule in the default
+// policy of a VDC Group
+func getDistributedFirewallRuleUrl(client *govcd.Client, vdcGroupId, ruleId string) (*url.URL, error) {
+	if vdcGroupId == "" || ruleId == "" {
+		return nil, fmt.Errorf("empty VDC Group ID or Distributed Firewall Rule ID")
+	}
+
+	return client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0,
+		fmt.Sprintf(types.OpenApiEndpointVdcGroupsDfwRules, vdcGroupId, types.DistributedFirewallPolicyDefault),
+		"/", ruleId)
+}
+
+// getDistributedFirewallRuleById retrieves a single Distributed Firewall Rule
+func getDistributedFirewallRuleById(client *govcd.Client, vdcGroupId, ruleId string) (*types.DistributedFirewallRule, error) {
+	urlRef, err := getDistributedFirewallRuleUrl(client, vdcGroupId, ruleId)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &types.DistributedFirewallRule{}
+	err = client.OpenApiGetItem(distributedFirewallRuleApiVersion(client), urlRef, nil, rule, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// updateDistributedFirewallRule updates a single Distributed Firewall Rule. The rule must contain
+// a 'Version', as VCD refuses to update a rule when it doesn't match the current one.
+func updateDistributedFirewallRule(client *govcd.Client, vdcGroupId string, rule *types.DistributedFirewallRule) (*types.DistributedFirewallRule, error) {
+	urlRef, err := getDistributedFirewallRuleUrl(client, vdcGroupId, rule.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedRule := &types.DistributedFirewallRule{}
+	err = client.OpenApiPutItem(distributedFirewallRuleApiVersion(client), urlRef, nil, rule, updatedRule, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedRule, nil
+}
+
+// deleteDistributedFirewallRule removes a single Distributed Firewall Rule
+func deleteDistributedFirewallRule(client *govcd.Client, vdcGroupId, ruleId string) error {
+	urlRef, err := getDistributedFirewallRuleUrl(client, vdcGroupId, ruleId)
+	if err != nil {
+		return err
+	}
+
+	err = client.OpenApiDeleteItem(distributedFirewallRuleApiVersion(client), urlRef, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting Distributed Firewall Rule with ID '%s': %s", ruleId, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+//go:build gateway || nsxt || ALL || functional || vdcGroup
+// +build gateway nsxt ALL functional vdcGroup
+
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccVcdDistributedFirewallRule tests individual Distributed Firewall Rules, their placement
+// using 'above_rule_id' and in-place update of a single rule
+func TestAccVcdDistributedFirewallRule(t *testing.T) {
+	preTestChecks(t)
+	if !usingSysAdmin() {
+		t.Skip(t.Name() + " requires system admin privileges")
+		return
+	}
+
+	// String map to fill the template
+	var params = StringMap{
+		"Org":                       testConfig.VCD.Org,
+		"Name":                      t.Name(),
+		"ProviderVdc":               testConfig.VCD.NsxtProviderVdc.Name,
+		"NetworkPool":               testConfig.VCD.NsxtProviderVdc.NetworkPool,
+		"ProviderVdcStorageProfile": testConfig.VCD.ProviderVdc.StorageProfile,
+		"Dfw":                       "true",
+		"DefaultPolicy":             "true",
+		"TestName":                  t.Name(),
+		"Action":                    "DROP",
+
+		"NsxtManager":     testConfig.Nsxt.Manager,
+		"ExternalNetwork": testConfig.Nsxt.ExternalNetwork,
+
+		"Tags": "vdcGroup gateway nsxt",
+	}
+	testParamsNotEmpty(t, params)
+
+	params["FuncName"] = t.Name() + "-newVdc"
+	configTextPre := templateFill(testAccVcdVdcGroupNew, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configTextPre)
+
+	params["FuncName"] = t.Name() + "-step2"
+	configText2 := templateFill(dfwRuleStep2, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	params["FuncName"] = t.Name() + "-step3"
+	configText3 := templateFill(dfwRuleStep3DS, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 3: %s", configText3)
+
+	params["FuncName"] = t.Name() + "-step4"
+	params["Action"] = "REJECT"
+	configText4 := templateFill(dfwRuleStep3DS, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 4: %s", configText4)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// Setup prerequisites
+				Config: configTextPre,
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcd_nsxt_distributed_firewall_rule.bottom", "id"),
+					resource.TestCheckResourceAttr("vcd_nsxt_distributed_firewall_rule.bottom", "name", "rule-bottom"),
+					resource.TestCheckResourceAttr("vcd_nsxt_distributed_firewall_rule.bottom", "action", "ALLOW"),
+					resource.TestCheckResourceAttr("vcd_nsxt_distributed_firewall_rule.above", "name", "rule-above"),
+					resource.TestCheckResourceAttr("vcd_nsxt_distributed_firewall_rule.above", "action", "DROP"),
+					resource.TestCheckResourceAttr("vcd_nsxt_distributed_firewall_rule.above", "direction", "IN"),
+				),
+			},
+			{
+				Config: configText3,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vcd_nsxt_distributed_firewall.t1", "rule.#", "2"),
+					resource.TestCheckResourceAttrPair("vcd_nsxt_distributed_firewall_rule.above", "id", "data.vcd_nsxt_distributed_firewall.t1", "rule.0.id"),
+					resource.TestCheckResourceAttrPair("vcd_nsxt_distributed_firewall_rule.bottom", "id", "data.vcd_nsxt_distributed_firewall.t1", "rule.1.id"),
+				),
+			},
+			{
+				// Updating a rule must not change its ID or position
+				Config: configText4,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_nsxt_distributed_firewall_rule.above", "action", "REJECT"),
+					resource.TestCheckResourceAttr("data.vcd_nsxt_distributed_firewall.t1", "rule.#", "2"),
+					resource.TestCheckResourceAttrPair("vcd_nsxt_distributed_firewall_rule.above", "id", "data.vcd_nsxt_distributed_firewall.t1", "rule.0.id"),
+					resource.TestCheckResourceAttr("data.vcd_nsxt_distributed_firewall.t1", "rule.0.action", "REJECT"),
+					resource.TestCheckResourceAttrSet("vcd_nsxt_distributed_firewall_rule.above", "version"),
+					// Modifies the rule outside of Terraform and checks that an update with the
+					// stale version from state is refused
+					testAccCheckDistributedFirewallRuleOutOfBandUpdate("vcd_nsxt_distributed_firewall_rule.above"),
+				),
+				// The description changed outside of Terraform shows up in the plan after this step
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// The refresh picks up the new version and the description changed outside of
+				// Terraform is restored
+				Config: configText4,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_nsxt_distributed_firewall_rule.above", "description", "placed above rule-bottom"),
+					resource.TestCheckResourceAttr("data.vcd_nsxt_distributed_firewall.t1", "rule.0.description", "placed above rule-bottom"),
+				),
+			},
+			{
+				ResourceName:            "vcd_nsxt_distributed_firewall_rule.above",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       importStateIdOrgNsxtVdcGroupObject(testConfig, t.Name(), "rule-above"),
+				ImportStateVerifyIgnore: []string{"above_rule_id"},
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+// testAccCheckDistributedFirewallRuleOutOfBandUpdate changes the description of the rule directly
+// in VCD and then expects an update based on the (now stale) state to fail
+func testAccCheckDistributedFirewallRuleOutOfBandUpdate(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+		vcdClient := testAccProvider.Meta().(*VCDClient)
+		vdcGroupId := rs.Primary.Attributes["vdc_group_id"]
+
+		rule, err := getDistributedFirewallRuleById(&vcdClient.Client, vdcGroupId, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error retrieving rule %s: %s", rs.Primary.ID, err)
+		}
+		rule.Description = "changed outside of Terraform"
+		_, err = updateDistributedFirewallRule(&vcdClient.Client, vdcGroupId, rule)
+		if err != nil {
+			return fmt.Errorf("error updating rule %s outside of Terraform: %s", rs.Primary.ID, err)
+		}
+
+		d := resourceVcdNsxtDistributedFirewallRule().Data(rs.Primary)
+		diags := resourceVcdNsxtDistributedFirewallRuleUpdate(context.Background(), d, testAccProvider.Meta())
+		if !diags.HasError() {
+			return fmt.Errorf("update of rule %s with a stale version succeeded", rs.Primary.ID)
+		}
+		if !strings.Contains(diags[0].Summary, "modified outside of Terraform") {
+			return fmt.Errorf("unexpected error when updating rule %s with a stale version: %s", rs.Primary.ID, diags[0].Summary)
+		}
+		return nil
+	}
+}
+
+const dfwRuleStep2 = testAccVcdVdcGroupNew + `
+resource "vcd_nsxt_distributed_firewall_rule" "bottom" {
+  org          = "{{.Org}}"
+  vdc_group_id = vcd_vdc_group.test1.id
+
+  name   = "rule-bottom"
+  action = "ALLOW"
+}
+
+resource "vcd_nsxt_distributed_firewall_rule" "above" {
+  org           = "{{.Org}}"
+  vdc_group_id  = vcd_vdc_group.test1.id
+  above_rule_id = vcd_nsxt_distributed_firewall_rule.bottom.id
+
+  name        = "rule-above"
+  action      = "{{.Action}}"
+  direction   = "IN"
+  description = "placed above rule-bottom"
+}
+`
+
+const dfwRuleStep3DS = dfwRuleStep2 + `
+# skip-binary-test: Data Source test
+data "vcd_nsxt_distributed_firewall" "t1" {
+  org          = "{{.Org}}"
+  vdc_group_id = vcd_vdc_group.test1.id
+
+  depends_on = [vcd_nsxt_distributed_firewall_rule.bottom, vcd_nsxt_distributed_firewall_rule.above]
+}
+`
@@ -0,0 +1,460 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// nsxtFirewallGroupTypeVmCriteria is the 'typeValue' of a Dynamic Security Group (API V36.0+)
+const nsxtFirewallGroupTypeVmCriteria = "VM_CRITERIA"
+
+// nsxtDynamicSecurityGroupMinApiVersion is the first API version which supports VM_CRITERIA
+// Firewall Groups (VCD 10.3.0)
+const nsxtDynamicSecurityGroupMinApiVersion = "36.0"
+
+func resourceVcdDynamicSecurityGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdDynamicSecurityGroupCreate,
+		ReadContext:   resourceVcdDynamicSecurityGroupRead,
+		UpdateContext: resourceVcdDynamicSecurityGroupUpdate,
+		DeleteContext: resourceVcdDynamicSecurityGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdDynamicSecurityGroupImport,
+		},
+		CustomizeDiff: resourceVcdDynamicSecurityGroupCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc_group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of VDC Group in which Dynamic Security Group is located",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Dynamic Security Group name",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Dynamic Security Group description",
+			},
+			"criteria": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				MaxItems:    3,
+				Description: "Up to 3 criteria for matching VMs. VM matching any of the criteria becomes a member",
+				Elem:        nsxtDynamicSecurityGroupCriteria,
+			},
+			"member_vms": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Set of VM IDs",
+				Elem:        nsxtFirewallGroupMemberVms,
+			},
+		},
+	}
+}
+
+var nsxtDynamicSecurityGroupCriteria = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"rule": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			MaxItems:    4,
+			Description: "Up to 4 rules for matching VMs. VM must match all rules within one criteria",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "Type of object matching 'VM_TAG', 'VM_NAME' or 'OS_NAME'",
+						ValidateFunc: validation.StringInSlice([]string{"VM_TAG", "VM_NAME", "OS_NAME"}, false),
+					},
+					"operator": {
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "Operator can be one of 'EQUALS', 'CONTAINS', 'STARTS_WITH', 'ENDS_WITH'",
+						ValidateFunc: validation.StringInSlice([]string{"EQUALS", "CONTAINS", "STARTS_WITH", "ENDS_WITH"}, false),
+					},
+					"value": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Filter value",
+					},
+				},
+			},
+		},
+	},
+}
+
+// nsxtDynamicSecurityGroup is a Firewall Group of type VM_CRITERIA. It differs from
+// types.NsxtFirewallGroup in 'typeValue' and 'vmCriteria' fields which are only available in API
+// V36.0+
+type nsxtDynamicSecurityGroup struct {
+	ID          string                        `json:"id,omitempty"`
+	Name        string                        `json:"name"`
+	Description string                        `json:"description"`
+	OwnerRef    *types.OpenApiReference       `json:"ownerRef,omitempty"`
+	TypeValue   string                        `json:"typeValue"`
+	VmCriteria  []nsxtFirewallGroupVmCriteria `json:"vmCriteria,omitempty"`
+}
+
+// nsxtFirewallGroupVmCriteria holds up to 4 rules. A VM must match all of them to become a member
+type nsxtFirewallGroupVmCriteria struct {
+	VmCriteriaRule []nsxtFirewallGroupVmCriteriaRule `json:"rules,omitempty"`
+}
+
+// nsxtFirewallGroupVmCriteriaRule defines a single matching rule
+type nsxtFirewallGroupVmCriteriaRule struct {
+	AttributeType  string `json:"attributeType"`
+	AttributeValue string `json:"attributeValue"`
+	Operator       string `json:"operator"`
+}
+
+func resourceVcdDynamicSecurityGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	vcdClient.lockParentVdcGroup(d)
+	defer vcdClient.unlockParentVdcGroup(d)
+
+	dynamicSecurityGroup, err := getNsxtDynamicSecurityGroupType(d)
+	if err != nil {
+		return diag.Errorf("[nsxt dynamic security group create] %s", err)
+	}
+
+	createdGroup, err := createNsxtDynamicSecurityGroup(&vcdClient.Client, dynamicSecurityGroup)
+	if err != nil {
+		return diag.Errorf("[nsxt dynamic security group create] error creating NSX-T Dynamic Security Group '%s': %s", dynamicSecurityGroup.Name, err)
+	}
+
+	d.SetId(createdGroup.ID)
+
+	return resourceVcdDynamicSecurityGroupRead(ctx, d, meta)
+}
+
+func resourceVcdDynamicSecurityGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	vcdClient.lockParentVdcGroup(d)
+	defer vcdClient.unlockParentVdcGroup(d)
+
+	updateDynamicSecurityGroup, err := getNsxtDynamicSecurityGroupType(d)
+	if err != nil {
+		return diag.Errorf("[nsxt dynamic security group update] %s", err)
+	}
+
+	// Inject existing ID for update
+	updateDynamicSecurityGroup.ID = d.Id()
+
+	_, err = updateNsxtDynamicSecurityGroup(&vcdClient.Client, updateDynamicSecurityGroup)
+	if err != nil {
+		return diag.Errorf("[nsxt dynamic security group update] error updating NSX-T Dynamic Security Group '%s': %s", updateDynamicSecurityGroup.Name, err)
+	}
+
+	return resourceVcdDynamicSecurityGroupRead(ctx, d, meta)
+}
+
+func resourceVcdDynamicSecurityGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	dynamicSecurityGroup, err := getNsxtDynamicSecurityGroupById(&vcdClient.Client, d.Id())
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[nsxt dynamic security group read] error getting NSX-T Dynamic Security Group with ID '%s': %s", d.Id(), err)
+	}
+
+	err = setNsxtDynamicSecurityGroupData(d, dynamicSecurityGroup)
+	if err != nil {
+		return diag.Errorf("[nsxt dynamic security group read] error reading NSX-T Dynamic Security Group: %s", err)
+	}
+
+	// A separate GET call is required to get all associated VMs
+	associatedVms, err := getNsxtDynamicSecurityGroupAssociatedVms(&vcdClient.Client, dynamicSecurityGroup.ID)
+	if err != nil {
+		return diag.Errorf("[nsxt dynamic security group read] error getting associated VMs for Dynamic Security Group '%s': %s", dynamicSecurityGroup.Name, err)
+	}
+
+	err = setNsxtSecurityGroupAssociatedVmsData(d, associatedVms)
+	if err != nil {
+		return diag.Errorf("[nsxt dynamic security group read] error setting associated VMs for Dynamic Security Group '%s': %s", dynamicSecurityGroup.Name, err)
+	}
+
+	return nil
+}
+
+func resourceVcdDynamicSecurityGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	vcdClient.lockParentVdcGroup(d)
+	defer vcdClient.unlockParentVdcGroup(d)
+
+	org, err := vcdClient.GetOrgFromResource(d)
+	if err != nil {
+		return diag.Errorf("[nsxt dynamic security group delete] error retrieving Org: %s", err)
+	}
+
+	// Deletion does not depend on group type therefore SDK method can be used
+	securityGroup, err := org.GetNsxtFirewallGroupById(d.Id())
+	if err != nil {
+		return diag.Errorf("[nsxt dynamic security group delete] error getting NSX-T Dynamic Security Group: %s", err)
+	}
+
+	err = securityGroup.Delete()
+	if err != nil {
+		return diag.Errorf("[nsxt dynamic security group delete] error deleting NSX-T Dynamic Security Group: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceVcdDynamicSecurityGroupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Printf("[TRACE] NSX-T Dynamic Security Group import initiated")
+
+	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	if len(resourceURI) != 3 {
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-group-name.dynamic-security-group-name")
+	}
+	orgName, vdcGroupName, securityGroupName := resourceURI[0], resourceURI[1], resourceURI[2]
+
+	vcdClient := meta.(*VCDClient)
+	adminOrg, err := vcdClient.GetAdminOrg(orgName)
+	if err != nil {
+		return nil, fmt.Errorf("[nsxt dynamic security group import] error retrieving Admin Org for '%s': %s", orgName, err)
+	}
+
+	vdcGroup, err := adminOrg.GetVdcGroupByName(vdcGroupName)
+	if err != nil {
+		return nil, fmt.Errorf("[nsxt dynamic security group import] error retrieving VDC Group '%s': %s", vdcGroupName, err)
+	}
+
+	dynamicSecurityGroup, err := getNsxtDynamicSecurityGroupByName(&vcdClient.Client, vdcGroup.VdcGroup.Id, securityGroupName)
+	if err != nil {
+		return nil, fmt.Errorf("[nsxt dynamic security group import] error retrieving NSX-T Dynamic Security Group '%s': %s", securityGroupName, err)
+	}
+
+	dSet(d, "org", orgName)
+	dSet(d, "vdc_group_id", vdcGroup.VdcGroup.Id)
+	d.SetId(dynamicSecurityGroup.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceVcdDynamicSecurityGroupCustomizeDiff validates criteria rule operators during plan
+func resourceVcdDynamicSecurityGroupCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	criteriaSet := d.Get("criteria").(*schema.Set)
+	for _, criteria := range criteriaSet.List() {
+		criteriaMap := criteria.(map[string]interface{})
+		ruleSet := criteriaMap["rule"].(*schema.Set)
+		for _, rule := range ruleSet.List() {
+			ruleMap := rule.(map[string]interface{})
+			err := validateNsxtDynamicSecurityGroupRuleOperator(ruleMap["type"].(string), ruleMap["operator"].(string))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateNsxtDynamicSecurityGroupRuleOperator checks that 'EQUALS' and 'ENDS_WITH' operators are
+// only used in rules of type 'VM_TAG', as VCD allows them for no other rule type
+func validateNsxtDynamicSecurityGroupRuleOperator(ruleType, operator string) error {
+	// Values which are not known during plan are empty
+	if ruleType == "" || operator == "" {
+		return nil
+	}
+
+	if ruleType != "VM_TAG" && (operator == "EQUALS" || operator == "ENDS_WITH") {
+		return fmt.Errorf("operator '%s' is only supported for rule type 'VM_TAG', got '%s'", operator, ruleType)
+	}
+	return nil
+}
+
+func getNsxtDynamicSecurityGroupType(d *schema.ResourceData) (*nsxtDynamicSecurityGroup, error) {
+	dynamicSecurityGroup := &nsxtDynamicSecurityGroup{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		OwnerRef:    &types.OpenApiReference{ID: d.Get("vdc_group_id").(string)},
+		TypeValue:   nsxtFirewallGroupTypeVmCriteria,
+	}
+
+	criteriaSet := d.Get("criteria").(*schema.Set)
+	for _, criteria := range criteriaSet.List() {
+		criteriaMap := criteria.(map[string]interface{})
+		ruleSet := criteriaMap["rule"].(*schema.Set)
+
+		vmCriteria := nsxtFirewallGroupVmCriteria{}
+		for _, rule := range ruleSet.List() {
+			ruleMap := rule.(map[string]interface{})
+			vmCriteriaRule := nsxtFirewallGroupVmCriteriaRule{
+				AttributeType:  ruleMap["type"].(string),
+				Operator:       ruleMap["operator"].(string),
+				AttributeValue: ruleMap["value"].(string),
+			}
+
+			vmCriteria.VmCriteriaRule = append(vmCriteria.VmCriteriaRule, vmCriteriaRule)
+		}
+
+		dynamicSecurityGroup.VmCriteria = append(dynamicSecurityGroup.VmCriteria, vmCriteria)
+	}
+
+	return dynamicSecurityGroup, nil
+}
+
+func setNsxtDynamicSecurityGroupData(d *schema.ResourceData, dynamicSecurityGroup *nsxtDynamicSecurityGroup) error {
+	dSet(d, "name", dynamicSecurityGroup.Name)
+	dSet(d, "description", dynamicSecurityGroup.Description)
+	if dynamicSecurityGroup.OwnerRef != nil {
+		dSet(d, "vdc_group_id", dynamicSecurityGroup.OwnerRef.ID)
+	}
+
+	criteriaSlice := make([]interface{}, len(dynamicSecurityGroup.VmCriteria))
+	for criteriaIndex, criteria := range dynamicSecurityGroup.VmCriteria {
+		ruleSlice := make([]interface{}, len(criteria.VmCriteriaRule))
+		for ruleIndex, rule := range criteria.VmCriteriaRule {
+			ruleSlice[ruleIndex] = map[string]interface{}{
+				"type":     rule.AttributeType,
+				"operator": rule.Operator,
+				"value":    rule.AttributeValue,
+			}
+		}
+
+		ruleSchema := nsxtDynamicSecurityGroupCriteria.Schema["rule"].Elem.(*schema.Resource)
+		criteriaSlice[criteriaIndex] = map[string]interface{}{
+			"rule": schema.NewSet(schema.HashResource(ruleSchema), ruleSlice),
+		}
+	}
+
+	criteriaSet := schema.NewSet(schema.HashResource(nsxtDynamicSecurityGroupCriteria), criteriaSlice)
+	err := d.Set("criteria", criteriaSet)
+	if err != nil {
+		return fmt.Errorf("error setting 'criteria': %s", err)
+	}
+
+	return nil
+}
+
+func createNsxtDynamicSecurityGroup(client *govcd.Client, dynamicSecurityGroup *nsxtDynamicSecurityGroup) (*nsxtDynamicSecurityGroup, error) {
+	urlRef, err := openApiBuildEndpointWithVersion(client, nsxtDynamicSecurityGroupMinApiVersion,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointFirewallGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	createdGroup := &nsxtDynamicSecurityGroup{}
+	err = client.OpenApiPostItem(nsxtDynamicSecurityGroupMinApiVersion, urlRef, nil, dynamicSecurityGroup, createdGroup, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return createdGroup, nil
+}
+
+func updateNsxtDynamicSecurityGroup(client *govcd.Client, dynamicSecurityGroup *nsxtDynamicSecurityGroup) (*nsxtDynamicSecurityGroup, error) {
+	if dynamicSecurityGroup.ID == "" {
+		return nil, fmt.Errorf("cannot update NSX-T Dynamic Security Group without ID")
+	}
+
+	urlRef, err := openApiBuildEndpointWithVersion(client, nsxtDynamicSecurityGroupMinApiVersion,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointFirewallGroups, dynamicSecurityGroup.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedGroup := &nsxtDynamicSecurityGroup{}
+	err = client.OpenApiPutItem(nsxtDynamicSecurityGroupMinApiVersion, urlRef, nil, dynamicSecurityGroup, updatedGroup, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedGroup, nil
+}
+
+func getNsxtDynamicSecurityGroupById(client *govcd.Client, id string) (*nsxtDynamicSecurityGroup, error) {
+	if id == "" {
+		return nil, fmt.Errorf("empty NSX-T Dynamic Security Group ID specified")
+	}
+
+	urlRef, err := openApiBuildEndpointWithVersion(client, nsxtDynamicSecurityGroupMinApiVersion,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointFirewallGroups, id)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicSecurityGroup := &nsxtDynamicSecurityGroup{}
+	err = client.OpenApiGetItem(nsxtDynamicSecurityGroupMinApiVersion, urlRef, nil, dynamicSecurityGroup, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if dynamicSecurityGroup.TypeValue != nsxtFirewallGroupTypeVmCriteria {
+		return nil, fmt.Errorf("firewall group '%s' is not a Dynamic Security Group, but '%s'",
+			dynamicSecurityGroup.Name, dynamicSecurityGroup.TypeValue)
+	}
+
+	return dynamicSecurityGroup, nil
+}
+
+func getNsxtDynamicSecurityGroupByName(client *govcd.Client, vdcGroupId, name string) (*nsxtDynamicSecurityGroup, error) {
+	// This Object does not follow regular REST scheme and for get the endpoint must be
+	// 1.0.0/firewallGroups/summaries therefore bellow "summaries" is appended to the path
+	urlRef, err := openApiBuildEndpointWithVersion(client, nsxtDynamicSecurityGroupMinApiVersion,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointFirewallGroups, "summaries")
+	if err != nil {
+		return nil, err
+	}
+
+	queryParameters := openApiFilterAnd("name=="+name, "typeValue=="+nsxtFirewallGroupTypeVmCriteria, "ownerRef.id=="+vdcGroupId)
+	allGroups := []*nsxtDynamicSecurityGroup{{}}
+	err = client.OpenApiGetAllItems(nsxtDynamicSecurityGroupMinApiVersion, urlRef, queryParameters, &allGroups, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(allGroups) == 0 {
+		return nil, fmt.Errorf("%s: expected exactly one NSX-T Dynamic Security Group with name '%s'. Got %d", govcd.ErrorEntityNotFound, name, len(allGroups))
+	}
+
+	if len(allGroups) > 1 {
+		return nil, fmt.Errorf("expected exactly one NSX-T Dynamic Security Group with name '%s'. Got %d", name, len(allGroups))
+	}
+
+	// Summaries endpoint does not return all fields (e.g. 'vmCriteria') therefore the group must be
+	// retrieved once again using its direct endpoint
+	return getNsxtDynamicSecurityGroupById(client, allGroups[0].ID)
+}
+
+func getNsxtDynamicSecurityGroupAssociatedVms(client *govcd.Client, id string) ([]*types.NsxtFirewallGroupMemberVms, error) {
+	urlRef, err := openApiBuildEndpointWithVersion(client, nsxtDynamicSecurityGroupMinApiVersion,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointFirewallGroups, id, "/associatedVMs")
+	if err != nil {
+		return nil, err
+	}
+
+	associatedVms := []*types.NsxtFirewallGroupMemberVms{{}}
+	err = client.OpenApiGetAllItems(nsxtDynamicSecurityGroupMinApiVersion, urlRef, nil, &associatedVms, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving associated VMs: %s", err)
+	}
+
+	return associatedVms, nil
+}
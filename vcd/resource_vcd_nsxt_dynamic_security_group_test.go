@@ -0,0 +1,207 @@
+//go:build network || nsxt || ALL || functional || vdcGroup
+// +build network nsxt ALL functional vdcGroup
+
+package vcd
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccVcdNsxtDynamicSecurityGroup tests out creation, update, import and data source of
+// Dynamic Security Groups which are only supported in VDC Groups on VCD 10.3+
+func TestAccVcdNsxtDynamicSecurityGroup(t *testing.T) {
+	preTestChecks(t)
+	if !usingSysAdmin() {
+		t.Skip(t.Name() + " requires system admin privileges")
+		return
+	}
+
+	vcdClient := createTemporaryVCDConnection(false)
+	if vcdClient.Client.APIVCDMaxVersionIs("< 36.0") {
+		t.Skipf("This test tests VCD 10.3.0+ (API V36.0+) features. Skipping.")
+	}
+
+	// String map to fill the template
+	var params = StringMap{
+		"Org":                       testConfig.VCD.Org,
+		"Name":                      t.Name(),
+		"ProviderVdc":               testConfig.VCD.NsxtProviderVdc.Name,
+		"NetworkPool":               testConfig.VCD.NsxtProviderVdc.NetworkPool,
+		"ProviderVdcStorageProfile": testConfig.VCD.ProviderVdc.StorageProfile,
+		"Dfw":                       "false",
+		"DefaultPolicy":             "false",
+		"TestName":                  t.Name(),
+
+		"Tags": "vdcGroup network nsxt",
+	}
+	testParamsNotEmpty(t, params)
+
+	params["FuncName"] = t.Name() + "-newVdc"
+	configTextPre := templateFill(testAccVcdVdcGroupNew, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configTextPre)
+
+	params["FuncName"] = t.Name() + "-step2"
+	configText2 := templateFill(testAccVcdNsxtDynamicSecurityGroupStep2, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	params["FuncName"] = t.Name() + "-step3"
+	configText3 := templateFill(testAccVcdNsxtDynamicSecurityGroupStep3, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 3: %s", configText3)
+
+	params["FuncName"] = t.Name() + "-step4"
+	configText4 := templateFill(testAccVcdNsxtDynamicSecurityGroupStep4Invalid, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 4: %s", configText4)
+
+	params["FuncName"] = t.Name() + "-step5"
+	configText5 := templateFill(testAccVcdNsxtDynamicSecurityGroupStep5DS, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 5: %s", configText5)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// Setup prerequisites
+				Config: configTextPre,
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("vcd_nsxt_dynamic_security_group.group1", "id", regexp.MustCompile(`^urn:vcloud:firewallGroup:`)),
+					resource.TestCheckResourceAttr("vcd_nsxt_dynamic_security_group.group1", "name", "dynamic-group-1"),
+					resource.TestCheckResourceAttr("vcd_nsxt_dynamic_security_group.group1", "description", ""),
+					resource.TestCheckResourceAttr("vcd_nsxt_dynamic_security_group.group1", "criteria.#", "0"),
+					resource.TestCheckResourceAttr("vcd_nsxt_dynamic_security_group.group1", "member_vms.#", "0"),
+				),
+			},
+			{
+				Config: configText3,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("vcd_nsxt_dynamic_security_group.group1", "id", regexp.MustCompile(`^urn:vcloud:firewallGroup:`)),
+					resource.TestCheckResourceAttr("vcd_nsxt_dynamic_security_group.group1", "name", "dynamic-group-1-updated"),
+					resource.TestCheckResourceAttr("vcd_nsxt_dynamic_security_group.group1", "description", "description"),
+					resource.TestCheckResourceAttr("vcd_nsxt_dynamic_security_group.group1", "criteria.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs("vcd_nsxt_dynamic_security_group.group1", "criteria.*.rule.*", map[string]string{
+						"type":     "VM_TAG",
+						"operator": "EQUALS",
+						"value":    "tag-1",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("vcd_nsxt_dynamic_security_group.group1", "criteria.*.rule.*", map[string]string{
+						"type":     "VM_NAME",
+						"operator": "STARTS_WITH",
+						"value":    "web-",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("vcd_nsxt_dynamic_security_group.group1", "criteria.*.rule.*", map[string]string{
+						"type":     "OS_NAME",
+						"operator": "CONTAINS",
+						"value":    "Ubuntu",
+					}),
+				),
+			},
+			{
+				ResourceName:      "vcd_nsxt_dynamic_security_group.group1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdOrgObject(testConfig, t.Name()+ImportSeparator+"dynamic-group-1-updated"),
+			},
+			{
+				// 'EQUALS' operator is only allowed for 'VM_TAG' and must fail during plan
+				Config:      configText4,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`operator 'EQUALS' is only supported for rule type 'VM_TAG'`),
+			},
+			{
+				Config: configText5,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resourceFieldsEqual("vcd_nsxt_dynamic_security_group.group1", "data.vcd_nsxt_dynamic_security_group.group1", nil),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdNsxtDynamicSecurityGroupStep2 = testAccVcdVdcGroupNew + `
+resource "vcd_nsxt_dynamic_security_group" "group1" {
+  org          = "{{.Org}}"
+  vdc_group_id = vcd_vdc_group.test1.id
+
+  name = "dynamic-group-1"
+}
+`
+
+const testAccVcdNsxtDynamicSecurityGroupStep3 = testAccVcdVdcGroupNew + `
+resource "vcd_nsxt_dynamic_security_group" "group1" {
+  org          = "{{.Org}}"
+  vdc_group_id = vcd_vdc_group.test1.id
+
+  name        = "dynamic-group-1-updated"
+  description = "description"
+
+  criteria {
+    rule {
+      type     = "VM_TAG"
+      operator = "EQUALS"
+      value    = "tag-1"
+    }
+  }
+
+  criteria {
+    rule {
+      type     = "VM_NAME"
+      operator = "STARTS_WITH"
+      value    = "web-"
+    }
+
+    rule {
+      type     = "OS_NAME"
+      operator = "CONTAINS"
+      value    = "Ubuntu"
+    }
+  }
+
+  criteria {
+    rule {
+      type     = "VM_TAG"
+      operator = "ENDS_WITH"
+      value    = "-web"
+    }
+  }
+}
+`
+
+const testAccVcdNsxtDynamicSecurityGroupStep4Invalid = testAccVcdVdcGroupNew + `
+# skip-binary-test: expected to fail during plan
+resource "vcd_nsxt_dynamic_security_group" "group1" {
+  org          = "{{.Org}}"
+  vdc_group_id = vcd_vdc_group.test1.id
+
+  name        = "dynamic-group-1-updated"
+  description = "description"
+
+  criteria {
+    rule {
+      type     = "VM_NAME"
+      operator = "EQUALS"
+      value    = "web-1"
+    }
+  }
+}
+`
+
+const testAccVcdNsxtDynamicSecurityGroupStep5DS = testAccVcdNsxtDynamicSecurityGroupStep3 + `
+# skip-binary-test: Data Source test
+data "vcd_nsxt_dynamic_security_group" "group1" {
+  org          = "{{.Org}}"
+  vdc_group_id = vcd_vdc_group.test1.id
+
+  name = vcd_nsxt_dynamic_security_group.group1.name
+}
+`
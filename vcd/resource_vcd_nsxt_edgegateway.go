@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math"
+	"math/big"
+	"net"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v2/govcd"
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 )
@@ -52,6 +56,32 @@ var nsxtEdgeSubnet = &schema.Resource{
 	},
 }
 
+var nsxtEdgeSubnetWithIpCount = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"gateway": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Gateway address for a subnet",
+		},
+		"prefix_length": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			Description: "Netmask address for a subnet (e.g. 24 for /24)",
+		},
+		"primary_ip": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Primary IP address for the edge gateway - will be auto-assigned if not defined. The auto-assigned one is only available in top level 'primary_ip'",
+		},
+		"allocated_ip_count": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			Description:  "Number of IP addresses to auto-allocate from the subnet",
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+	},
+}
+
 func resourceVcdNsxtEdgeGateway() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceVcdNsxtEdgeGatewayCreate,
@@ -61,6 +91,7 @@ func resourceVcdNsxtEdgeGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdNsxtEdgeGatewayImport,
 		},
+		CustomizeDiff: resourceVcdNsxtEdgeGatewayCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"org": {
@@ -112,10 +143,19 @@ func resourceVcdNsxtEdgeGateway() *schema.Resource {
 				Description: "External network ID",
 			},
 			"subnet": {
-				Type:        schema.TypeSet,
-				Required:    true,
-				Description: "One or more blocks with external network information to be attached to this gateway's interface",
-				Elem:        nsxtEdgeSubnet,
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Computed:     true,
+				Description:  "One or more blocks with external network information to be attached to this gateway's interface",
+				Elem:         nsxtEdgeSubnet,
+				ExactlyOneOf: []string{"subnet", "subnet_with_ip_count"},
+			},
+			"subnet_with_ip_count": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Description:  "One or more blocks with external network subnets from which a number of IP addresses should be auto-allocated",
+				Elem:         nsxtEdgeSubnetWithIpCount,
+				ExactlyOneOf: []string{"subnet", "subnet_with_ip_count"},
 			},
 			"primary_ip": {
 				Type:        schema.TypeString,
@@ -132,6 +172,28 @@ func resourceVcdNsxtEdgeGateway() *schema.Resource {
 	}
 }
 
+// resourceVcdNsxtEdgeGatewayCustomizeDiff marks 'subnet' and 'primary_ip' as unknown when
+// 'subnet_with_ip_count' changes. VCD picks the allocated IP addresses during apply, therefore
+// resources referencing them must not plan against the previous values.
+func resourceVcdNsxtEdgeGatewayCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("subnet_with_ip_count") {
+		return nil
+	}
+
+	// When switching back to explicit 'subnet' its configured value is used
+	subnetsWithIpCount, ok := d.Get("subnet_with_ip_count").(*schema.Set)
+	if !ok || subnetsWithIpCount.Len() == 0 {
+		return nil
+	}
+
+	err := d.SetNewComputed("subnet")
+	if err != nil {
+		return fmt.Errorf("error marking 'subnet' as computed: %s", err)
+	}
+
+	return d.SetNewComputed("primary_ip")
+}
+
 func resourceVcdNsxtEdgeGatewayCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[TRACE] NSX-T Edge Gateway creation initiated")
 
@@ -465,7 +527,13 @@ func getNsxtEdgeGatewayUplinksType(d *schema.ResourceData) []types.OpenAPIEdgeGa
 	var isPrimaryIpSet bool
 	var primaryIpIndex int
 
+	// 'subnet' is Optional and Computed therefore it always has a value. 'subnet_with_ip_count' being
+	// set means that IP ranges must be auto-allocated.
+	isIpCountMode := isNsxtEdgeGatewayIpCountMode(d)
 	extNetworks := d.Get("subnet").(*schema.Set).List()
+	if isIpCountMode {
+		extNetworks = d.Get("subnet_with_ip_count").(*schema.Set).List()
+	}
 	subnetSlice := make([]types.OpenAPIEdgeGatewaySubnetValue, len(extNetworks))
 
 	for index, singleSubnet := range extNetworks {
@@ -481,8 +549,11 @@ func getNsxtEdgeGatewayUplinksType(d *schema.ResourceData) []types.OpenAPIEdgeGa
 			primaryIpIndex = index
 		}
 
-		// Only feed in ip range allocations if they are defined
-		if ipRanges := getNsxtEdgeGatewayUplinkRangeTypes(subnetMap); ipRanges != nil {
+		if isIpCountMode {
+			singleSubnet.AutoAllocateIPRanges = true
+			singleSubnet.TotalIPCount = subnetMap["allocated_ip_count"].(int)
+		} else if ipRanges := getNsxtEdgeGatewayUplinkRangeTypes(subnetMap); ipRanges != nil {
+			// Only feed in ip range allocations if they are defined
 			singleSubnet.IPRanges = &types.OpenApiIPRanges{Values: ipRanges}
 		}
 
@@ -502,6 +573,14 @@ func getNsxtEdgeGatewayUplinksType(d *schema.ResourceData) []types.OpenAPIEdgeGa
 	return subnetSlice
 }
 
+// isNsxtEdgeGatewayIpCountMode checks if IP addresses are auto-allocated using 'subnet_with_ip_count'
+// instead of explicit 'allocated_ips' ranges in 'subnet'. Data source does not have
+// 'subnet_with_ip_count' field at all.
+func isNsxtEdgeGatewayIpCountMode(d *schema.ResourceData) bool {
+	subnetsWithIpCount, ok := d.Get("subnet_with_ip_count").(*schema.Set)
+	return ok && subnetsWithIpCount.Len() > 0
+}
+
 func getNsxtEdgeGatewayUplinkRangeTypes(subnetMap map[string]interface{}) []types.OpenApiIPRangeValues {
 	suballocatePoolSchema := subnetMap["allocated_ips"].(*schema.Set)
 	subnetRanges := make([]types.OpenApiIPRangeValues, len(suballocatePoolSchema.List()))
@@ -541,7 +620,10 @@ func setNsxtEdgeGatewayData(edgeGateway *types.OpenAPIEdgeGateway, d *schema.Res
 	dSet(d, "external_network_id", edgeUplink.UplinkID)
 
 	// subnets
-	subnets := make([]interface{}, 1)
+	subnets := make([]interface{}, 0)
+	subnetsWithIpCount := make([]interface{}, 0)
+	isIpCountMode := isNsxtEdgeGatewayIpCountMode(d)
+	configuredPrimaryIps := getNsxtEdgeGatewayConfiguredPrimaryIps(d)
 	for _, subnetValue := range edgeUplink.Subnets.Values {
 
 		// Edge Gateway API returns all subnets defined on external network. However, if they don't have "ranges"
@@ -576,8 +658,30 @@ func setNsxtEdgeGatewayData(edgeGateway *types.OpenAPIEdgeGateway, d *schema.Res
 		ipRangeSet := schema.NewSet(schema.HashResource(nsxtEdgeSubnetRange), allIpRanges)
 		oneSubnet["allocated_ips"] = ipRangeSet
 		subnets = append(subnets, oneSubnet)
+
+		// IP count is only needed (and can only be stored) when 'subnet_with_ip_count' is used. Large
+		// IPv6 ranges can't be counted
+		if isIpCountMode {
+			ipCount, err := countIpsInRanges(subnetValue.IPRanges.Values)
+			if err != nil {
+				return fmt.Errorf("error counting allocated IPs in subnet '%s/%d': %s", subnetValue.Gateway, subnetValue.PrefixLength, err)
+			}
+			oneSubnetWithIpCount := map[string]interface{}{
+				"gateway":            subnetValue.Gateway,
+				"prefix_length":      subnetValue.PrefixLength,
+				"allocated_ip_count": ipCount,
+			}
+			// 'primary_ip' in 'subnet_with_ip_count' is not computed, therefore an auto-assigned one
+			// would cause a permanent diff. It is only stored when it is configured for this subnet.
+			if configuredPrimaryIps[fmt.Sprintf("%s/%d", subnetValue.Gateway, subnetValue.PrefixLength)] {
+				oneSubnetWithIpCount["primary_ip"] = subnetValue.PrimaryIP
+			}
+			subnetsWithIpCount = append(subnetsWithIpCount, oneSubnetWithIpCount)
+		}
 	}
 
+	// All subnets with allocations are stored so that subnets and IP ranges that are added or
+	// changed outside of Terraform show up as a diff
 	subnetSet := schema.NewSet(schema.HashResource(nsxtEdgeSubnet), subnets)
 
 	err := d.Set("subnet", subnetSet)
@@ -585,6 +689,16 @@ func setNsxtEdgeGatewayData(edgeGateway *types.OpenAPIEdgeGateway, d *schema.Res
 		return fmt.Errorf("error setting NSX-T Edge Gateway subnets after read: %s", err)
 	}
 
+	// 'subnet_with_ip_count' is only populated when it is used in configuration. 'subnet' is still
+	// populated as computed so that exact allocations are visible
+	if isIpCountMode {
+		subnetWithIpCountSet := schema.NewSet(schema.HashResource(nsxtEdgeSubnetWithIpCount), subnetsWithIpCount)
+		err = d.Set("subnet_with_ip_count", subnetWithIpCountSet)
+		if err != nil {
+			return fmt.Errorf("error setting NSX-T Edge Gateway subnets with IP count after read: %s", err)
+		}
+	}
+
 	return nil
 }
 
@@ -594,3 +708,44 @@ type vdcOrVdcGroupVerifier interface {
 	IsNsxt() bool
 	GetNsxtEdgeGatewayByName(name string) (*govcd.NsxtEdgeGateway, error)
 }
+
+// getNsxtEdgeGatewayConfiguredPrimaryIps returns a map of 'gateway/prefix_length' keys for subnets in
+// 'subnet_with_ip_count' which have 'primary_ip' set
+func getNsxtEdgeGatewayConfiguredPrimaryIps(d *schema.ResourceData) map[string]bool {
+	configuredPrimaryIps := make(map[string]bool)
+	if !isNsxtEdgeGatewayIpCountMode(d) {
+		return configuredPrimaryIps
+	}
+	for _, subnet := range d.Get("subnet_with_ip_count").(*schema.Set).List() {
+		subnetMap := subnet.(map[string]interface{})
+		if subnetMap["primary_ip"].(string) != "" {
+			configuredPrimaryIps[fmt.Sprintf("%s/%d", subnetMap["gateway"].(string), subnetMap["prefix_length"].(int))] = true
+		}
+	}
+	return configuredPrimaryIps
+}
+
+// countIpsInRanges returns total number of IP addresses in given IP ranges. It returns an error if
+// the total does not fit into 'allocated_ip_count', which can happen for large IPv6 ranges.
+func countIpsInRanges(ipRanges []types.OpenApiIPRangeValues) (int, error) {
+	count := new(big.Int)
+	for _, ipRange := range ipRanges {
+		endAddress := ipRange.EndAddress
+		if endAddress == "" {
+			endAddress = ipRange.StartAddress
+		}
+		startIp, endIp := net.ParseIP(ipRange.StartAddress), net.ParseIP(endAddress)
+		if startIp == nil || endIp == nil {
+			return 0, fmt.Errorf("invalid IP range '%s-%s'", ipRange.StartAddress, endAddress)
+		}
+
+		rangeSize := new(big.Int).Sub(ipToBigInt(endIp), ipToBigInt(startIp))
+		count.Add(count, rangeSize.Add(rangeSize, big.NewInt(1)))
+	}
+
+	if !count.IsInt64() || count.Int64() > math.MaxInt32 {
+		return 0, fmt.Errorf("IP ranges contain %s IP addresses, which is more than the supported maximum of %d", count, math.MaxInt32)
+	}
+
+	return int(count.Int64()), nil
+}
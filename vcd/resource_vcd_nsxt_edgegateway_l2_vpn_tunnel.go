@@ -0,0 +1,461 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// nsxtL2VpnTunnelMinApiVersion is the first API version which supports NSX-T L2 VPN (VCD 10.4.0)
+const nsxtL2VpnTunnelMinApiVersion = "37.0"
+
+var nsxtL2VpnStretchedNetwork = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"network_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Org VDC network ID which is stretched over the tunnel",
+		},
+		"tunnel_id": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			Description:  "Tunnel ID of the network. Required for CLIENT sessions, assigned automatically for SERVER sessions if not set",
+			ValidateFunc: validation.IntBetween(1, 4093),
+		},
+	},
+}
+
+// resourceVcdNsxtL2VpnStretchedNetworkHash only hashes 'network_id' so that a 'tunnel_id' assigned
+// by VCD in SERVER session mode does not cause a perpetual diff for networks configured without it
+func resourceVcdNsxtL2VpnStretchedNetworkHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	return hashcodeString(m["network_id"].(string))
+}
+
+func resourceVcdNsxtEdgegatewayL2VpnTunnel() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdNsxtEdgegatewayL2VpnTunnelCreate,
+		ReadContext:   resourceVcdNsxtEdgegatewayL2VpnTunnelRead,
+		UpdateContext: resourceVcdNsxtEdgegatewayL2VpnTunnelUpdate,
+		DeleteContext: resourceVcdNsxtEdgegatewayL2VpnTunnelDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdNsxtEdgegatewayL2VpnTunnelImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"edge_gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Edge Gateway ID in which L2 VPN Tunnel is located",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of L2 VPN Tunnel",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of L2 VPN Tunnel",
+			},
+			"session_mode": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Mode of the Edge Gateway in L2 VPN session ('SERVER' or 'CLIENT')",
+				ValidateFunc: validation.StringInSlice([]string{"SERVER", "CLIENT"}, false),
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Enables or disables L2 VPN Tunnel (default 'true')",
+			},
+			"connector_initiation_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Connector initiation mode of the session for SERVER mode ('INITIATOR', 'RESPOND_ONLY', 'ON_DEMAND')",
+				ValidateFunc: validation.StringInSlice([]string{"INITIATOR", "RESPOND_ONLY", "ON_DEMAND"}, false),
+			},
+			"local_endpoint_ip": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Local endpoint IP of the tunnel. Must be a sub-allocated IP of the Edge Gateway",
+			},
+			"remote_endpoint_ip": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Remote endpoint IP of the tunnel",
+			},
+			"tunnel_interface": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Network CIDR block over which the session interfaces for SERVER mode",
+			},
+			"pre_shared_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Pre-shared key used for authentication",
+			},
+			"peer_code": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Base64 encoded peer code. Generated for SERVER mode and required for CLIENT mode",
+			},
+			"stretched_network": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Org VDC networks which are stretched over the tunnel",
+				Elem:        nsxtL2VpnStretchedNetwork,
+				Set:         resourceVcdNsxtL2VpnStretchedNetworkHash,
+			},
+			"logging": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enables or disables logging for the tunnel (default 'false')",
+			},
+		},
+	}
+}
+
+// nsxtL2VpnTunnel defines an NSX-T L2 VPN Tunnel on an Edge Gateway
+type nsxtL2VpnTunnel struct {
+	ID                      string                       `json:"id,omitempty"`
+	Name                    string                       `json:"name"`
+	Description             string                       `json:"description,omitempty"`
+	SessionMode             string                       `json:"sessionMode"`
+	Enabled                 bool                         `json:"enabled"`
+	ConnectorInitiationMode string                       `json:"connectorInitiationMode,omitempty"`
+	LocalEndpointIp         string                       `json:"localEndpointIp"`
+	RemoteEndpointIp        string                       `json:"remoteEndpointIp"`
+	TunnelInterface         string                       `json:"tunnelInterface,omitempty"`
+	PreSharedKey            string                       `json:"preSharedKey,omitempty"`
+	PeerCode                string                       `json:"peerCode,omitempty"`
+	StretchedNetworks       []nsxtL2VpnStretchedNetworks `json:"stretchedNetworks,omitempty"`
+	Logging                 bool                         `json:"logging"`
+	Version                 *struct {
+		Version int `json:"version"`
+	} `json:"version,omitempty"`
+}
+
+// nsxtL2VpnStretchedNetworks binds an Org VDC network to a tunnel ID
+type nsxtL2VpnStretchedNetworks struct {
+	NetworkRef types.OpenApiReference `json:"networkRef"`
+	TunnelID   int                    `json:"tunnelId,omitempty"`
+}
+
+func resourceVcdNsxtEdgegatewayL2VpnTunnelCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	vcdClient.lockParentEdgeGtw(d)
+	defer vcdClient.unLockParentEdgeGtw(d)
+
+	l2VpnTunnel, err := getNsxtL2VpnTunnelType(d)
+	if err != nil {
+		return diag.Errorf("[l2 vpn tunnel create] error getting NSX-T L2 VPN Tunnel type: %s", err)
+	}
+
+	createdTunnel, err := createNsxtL2VpnTunnel(&vcdClient.Client, d.Get("edge_gateway_id").(string), l2VpnTunnel)
+	if err != nil {
+		return diag.Errorf("[l2 vpn tunnel create] error creating NSX-T L2 VPN Tunnel '%s': %s", l2VpnTunnel.Name, err)
+	}
+
+	d.SetId(createdTunnel.ID)
+
+	return resourceVcdNsxtEdgegatewayL2VpnTunnelRead(ctx, d, meta)
+}
+
+func resourceVcdNsxtEdgegatewayL2VpnTunnelUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	vcdClient.lockParentEdgeGtw(d)
+	defer vcdClient.unLockParentEdgeGtw(d)
+
+	edgeGatewayId := d.Get("edge_gateway_id").(string)
+	existingTunnel, err := getNsxtL2VpnTunnelById(&vcdClient.Client, edgeGatewayId, d.Id())
+	if err != nil {
+		return diag.Errorf("[l2 vpn tunnel update] error retrieving NSX-T L2 VPN Tunnel: %s", err)
+	}
+
+	l2VpnTunnel, err := getNsxtL2VpnTunnelType(d)
+	if err != nil {
+		return diag.Errorf("[l2 vpn tunnel update] error getting NSX-T L2 VPN Tunnel type: %s", err)
+	}
+	l2VpnTunnel.ID = d.Id()
+	l2VpnTunnel.Version = existingTunnel.Version
+
+	_, err = updateNsxtL2VpnTunnel(&vcdClient.Client, edgeGatewayId, l2VpnTunnel)
+	if err != nil {
+		return diag.Errorf("[l2 vpn tunnel update] error updating NSX-T L2 VPN Tunnel '%s': %s", l2VpnTunnel.Name, err)
+	}
+
+	return resourceVcdNsxtEdgegatewayL2VpnTunnelRead(ctx, d, meta)
+}
+
+func resourceVcdNsxtEdgegatewayL2VpnTunnelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	l2VpnTunnel, err := getNsxtL2VpnTunnelById(&vcdClient.Client, d.Get("edge_gateway_id").(string), d.Id())
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] NSX-T L2 VPN Tunnel with ID '%s' not found. Removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[l2 vpn tunnel read] error retrieving NSX-T L2 VPN Tunnel: %s", err)
+	}
+
+	err = setNsxtL2VpnTunnelData(d, l2VpnTunnel)
+	if err != nil {
+		return diag.Errorf("[l2 vpn tunnel read] error storing NSX-T L2 VPN Tunnel to schema: %s", err)
+	}
+
+	return nil
+}
+
+func resourceVcdNsxtEdgegatewayL2VpnTunnelDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	vcdClient.lockParentEdgeGtw(d)
+	defer vcdClient.unLockParentEdgeGtw(d)
+
+	err := deleteNsxtL2VpnTunnel(&vcdClient.Client, d.Get("edge_gateway_id").(string), d.Id())
+	if err != nil {
+		return diag.Errorf("[l2 vpn tunnel delete] error deleting NSX-T L2 VPN Tunnel: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceVcdNsxtEdgegatewayL2VpnTunnelImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Printf("[TRACE] NSX-T L2 VPN Tunnel import initiated")
+
+	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	if len(resourceURI) != 4 {
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name.l2-vpn-tunnel-name")
+	}
+	orgName, vdcOrVdcGroupName, edgeName, tunnelName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
+
+	vcdClient := meta.(*VCDClient)
+	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(vcdClient, orgName, vdcOrVdcGroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	edge, err := vdcOrVdcGroup.GetNsxtEdgeGatewayByName(edgeName)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve NSX-T Edge Gateway '%s': %s", edgeName, err)
+	}
+
+	l2VpnTunnel, err := getNsxtL2VpnTunnelByName(&vcdClient.Client, edge.EdgeGateway.ID, tunnelName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find NSX-T L2 VPN Tunnel '%s': %s", tunnelName, err)
+	}
+
+	dSet(d, "org", orgName)
+	dSet(d, "edge_gateway_id", edge.EdgeGateway.ID)
+	d.SetId(l2VpnTunnel.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func getNsxtL2VpnTunnelType(d *schema.ResourceData) (*nsxtL2VpnTunnel, error) {
+	l2VpnTunnel := &nsxtL2VpnTunnel{
+		Name:                    d.Get("name").(string),
+		Description:             d.Get("description").(string),
+		SessionMode:             d.Get("session_mode").(string),
+		Enabled:                 d.Get("enabled").(bool),
+		ConnectorInitiationMode: d.Get("connector_initiation_mode").(string),
+		LocalEndpointIp:         d.Get("local_endpoint_ip").(string),
+		RemoteEndpointIp:        d.Get("remote_endpoint_ip").(string),
+		TunnelInterface:         d.Get("tunnel_interface").(string),
+		PreSharedKey:            d.Get("pre_shared_key").(string),
+		Logging:                 d.Get("logging").(bool),
+	}
+
+	switch l2VpnTunnel.SessionMode {
+	case "SERVER":
+		// Peer code is generated by SERVER and is only read back
+	case "CLIENT":
+		l2VpnTunnel.PeerCode = d.Get("peer_code").(string)
+		if l2VpnTunnel.PeerCode == "" {
+			return nil, fmt.Errorf("'peer_code' must be set for CLIENT session mode")
+		}
+		// Computed values of SERVER only fields may be present in state and must not be sent
+		if d.HasChange("connector_initiation_mode") && l2VpnTunnel.ConnectorInitiationMode != "" {
+			return nil, fmt.Errorf("'connector_initiation_mode' can only be set for SERVER session mode")
+		}
+		if d.HasChange("tunnel_interface") && l2VpnTunnel.TunnelInterface != "" {
+			return nil, fmt.Errorf("'tunnel_interface' can only be set for SERVER session mode")
+		}
+		l2VpnTunnel.ConnectorInitiationMode = ""
+		l2VpnTunnel.TunnelInterface = ""
+	}
+
+	stretchedNetworks := d.Get("stretched_network").(*schema.Set).List()
+	for _, stretchedNetwork := range stretchedNetworks {
+		stretchedNetworkMap := stretchedNetwork.(map[string]interface{})
+		tunnelId := stretchedNetworkMap["tunnel_id"].(int)
+		if l2VpnTunnel.SessionMode == "CLIENT" && tunnelId == 0 {
+			return nil, fmt.Errorf("'tunnel_id' must be set for all stretched networks in CLIENT session mode")
+		}
+
+		l2VpnTunnel.StretchedNetworks = append(l2VpnTunnel.StretchedNetworks, nsxtL2VpnStretchedNetworks{
+			NetworkRef: types.OpenApiReference{ID: stretchedNetworkMap["network_id"].(string)},
+			TunnelID:   tunnelId,
+		})
+	}
+
+	return l2VpnTunnel, nil
+}
+
+func setNsxtL2VpnTunnelData(d *schema.ResourceData, l2VpnTunnel *nsxtL2VpnTunnel) error {
+	dSet(d, "name", l2VpnTunnel.Name)
+	dSet(d, "description", l2VpnTunnel.Description)
+	dSet(d, "session_mode", l2VpnTunnel.SessionMode)
+	dSet(d, "enabled", l2VpnTunnel.Enabled)
+	dSet(d, "connector_initiation_mode", l2VpnTunnel.ConnectorInitiationMode)
+	dSet(d, "local_endpoint_ip", l2VpnTunnel.LocalEndpointIp)
+	dSet(d, "remote_endpoint_ip", l2VpnTunnel.RemoteEndpointIp)
+	dSet(d, "tunnel_interface", l2VpnTunnel.TunnelInterface)
+	dSet(d, "logging", l2VpnTunnel.Logging)
+
+	// Pre-shared key is not always returned by the API. Only overwrite it when it is available.
+	if l2VpnTunnel.PreSharedKey != "" {
+		dSet(d, "pre_shared_key", l2VpnTunnel.PreSharedKey)
+	}
+	if l2VpnTunnel.PeerCode != "" {
+		dSet(d, "peer_code", l2VpnTunnel.PeerCode)
+	}
+
+	stretchedNetworks := make([]interface{}, len(l2VpnTunnel.StretchedNetworks))
+	for index, stretchedNetwork := range l2VpnTunnel.StretchedNetworks {
+		stretchedNetworks[index] = map[string]interface{}{
+			"network_id": stretchedNetwork.NetworkRef.ID,
+			"tunnel_id":  stretchedNetwork.TunnelID,
+		}
+	}
+
+	return d.Set("stretched_network", schema.NewSet(resourceVcdNsxtL2VpnStretchedNetworkHash, stretchedNetworks))
+}
+
+func createNsxtL2VpnTunnel(client *govcd.Client, edgeGatewayId string, l2VpnTunnel *nsxtL2VpnTunnel) (*nsxtL2VpnTunnel, error) {
+	urlRef, err := openApiBuildEndpointWithVersion(client, nsxtL2VpnTunnelMinApiVersion,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointEdgeGateways, edgeGatewayId, "/l2vpn/tunnels")
+	if err != nil {
+		return nil, err
+	}
+
+	createdTunnel := &nsxtL2VpnTunnel{}
+	err = client.OpenApiPostItem(nsxtL2VpnTunnelMinApiVersion, urlRef, nil, l2VpnTunnel, createdTunnel, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return createdTunnel, nil
+}
+
+func updateNsxtL2VpnTunnel(client *govcd.Client, edgeGatewayId string, l2VpnTunnel *nsxtL2VpnTunnel) (*nsxtL2VpnTunnel, error) {
+	urlRef, err := openApiBuildEndpointWithVersion(client, nsxtL2VpnTunnelMinApiVersion,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointEdgeGateways, edgeGatewayId, "/l2vpn/tunnels/", l2VpnTunnel.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedTunnel := &nsxtL2VpnTunnel{}
+	err = client.OpenApiPutItem(nsxtL2VpnTunnelMinApiVersion, urlRef, nil, l2VpnTunnel, updatedTunnel, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedTunnel, nil
+}
+
+func getNsxtL2VpnTunnelById(client *govcd.Client, edgeGatewayId, id string) (*nsxtL2VpnTunnel, error) {
+	if id == "" {
+		return nil, fmt.Errorf("empty NSX-T L2 VPN Tunnel ID")
+	}
+
+	urlRef, err := openApiBuildEndpointWithVersion(client, nsxtL2VpnTunnelMinApiVersion,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointEdgeGateways, edgeGatewayId, "/l2vpn/tunnels/", id)
+	if err != nil {
+		return nil, err
+	}
+
+	l2VpnTunnel := &nsxtL2VpnTunnel{}
+	err = client.OpenApiGetItem(nsxtL2VpnTunnelMinApiVersion, urlRef, nil, l2VpnTunnel, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return l2VpnTunnel, nil
+}
+
+// getNsxtL2VpnTunnelByName finds L2 VPN Tunnel by name. Names are not enforced to be unique,
+// therefore an error is returned if more than one tunnel matches.
+func getNsxtL2VpnTunnelByName(client *govcd.Client, edgeGatewayId, name string) (*nsxtL2VpnTunnel, error) {
+	urlRef, err := openApiBuildEndpointWithVersion(client, nsxtL2VpnTunnelMinApiVersion,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointEdgeGateways, edgeGatewayId, "/l2vpn/tunnels")
+	if err != nil {
+		return nil, err
+	}
+
+	allTunnels := []*nsxtL2VpnTunnel{{}}
+	err = client.OpenApiGetAllItems(nsxtL2VpnTunnelMinApiVersion, urlRef, nil, &allTunnels, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var foundTunnels []*nsxtL2VpnTunnel
+	for _, tunnel := range allTunnels {
+		if tunnel.Name == name {
+			foundTunnels = append(foundTunnels, tunnel)
+		}
+	}
+
+	if len(foundTunnels) == 0 {
+		return nil, fmt.Errorf("%s: no NSX-T L2 VPN Tunnel with name '%s' found", govcd.ErrorEntityNotFound, name)
+	}
+
+	if len(foundTunnels) > 1 {
+		return nil, fmt.Errorf("expected exactly one NSX-T L2 VPN Tunnel with name '%s'. Got %d", name, len(foundTunnels))
+	}
+
+	// Tunnel list does not contain all details, therefore retrieving it by ID
+	return getNsxtL2VpnTunnelById(client, edgeGatewayId, foundTunnels[0].ID)
+}
+
+func deleteNsxtL2VpnTunnel(client *govcd.Client, edgeGatewayId, id string) error {
+	if id == "" {
+		return fmt.Errorf("empty NSX-T L2 VPN Tunnel ID")
+	}
+
+	urlRef, err := openApiBuildEndpointWithVersion(client, nsxtL2VpnTunnelMinApiVersion,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointEdgeGateways, edgeGatewayId, "/l2vpn/tunnels/", id)
+	if err != nil {
+		return err
+	}
+
+	return client.OpenApiDeleteItem(nsxtL2VpnTunnelMinApiVersion, urlRef, nil, nil)
+}
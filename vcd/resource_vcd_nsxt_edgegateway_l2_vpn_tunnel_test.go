@@ -0,0 +1,128 @@
+//go:build network || nsxt || ALL || functional
+// +build network nsxt ALL functional
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVcdNsxtEdgeGatewayL2VpnTunnel(t *testing.T) {
+	preTestChecks(t)
+
+	vcdClient := createTemporaryVCDConnection(false)
+	if vcdClient.Client.APIVCDMaxVersionIs("< 37.0") {
+		t.Skipf("This test tests VCD 10.4.0+ (API V37.0+) features. Skipping.")
+	}
+
+	// String map to fill the template
+	var params = StringMap{
+		"Org":        testConfig.VCD.Org,
+		"NsxtVdc":    testConfig.Nsxt.Vdc,
+		"EdgeGw":     testConfig.Nsxt.EdgeGateway,
+		"TunnelName": t.Name(),
+		"Enabled":    "true",
+		"Tags":       "network nsxt",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText1 := templateFill(testAccVcdNsxtEdgeGatewayL2VpnTunnelStep1, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "-step2"
+	params["Enabled"] = "false"
+	configText2 := templateFill(testAccVcdNsxtEdgeGatewayL2VpnTunnelStep2DS, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// The stretched network has no 'tunnel_id', so the one assigned by VCD must not
+				// show up as a diff in the plan after apply
+				Config: configText1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcd_nsxt_edgegateway_l2_vpn_tunnel.server", "id"),
+					resource.TestCheckResourceAttr("vcd_nsxt_edgegateway_l2_vpn_tunnel.server", "session_mode", "SERVER"),
+					resource.TestCheckResourceAttr("vcd_nsxt_edgegateway_l2_vpn_tunnel.server", "enabled", "true"),
+					resource.TestCheckResourceAttr("vcd_nsxt_edgegateway_l2_vpn_tunnel.server", "connector_initiation_mode", "ON_DEMAND"),
+					resource.TestCheckResourceAttr("vcd_nsxt_edgegateway_l2_vpn_tunnel.server", "stretched_network.#", "1"),
+					resource.TestCheckResourceAttrSet("vcd_nsxt_edgegateway_l2_vpn_tunnel.server", "peer_code"),
+					resource.TestCheckResourceAttrPair("vcd_nsxt_edgegateway_l2_vpn_tunnel.server", "local_endpoint_ip", "data.vcd_nsxt_edgegateway.existing", "primary_ip"),
+				),
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_nsxt_edgegateway_l2_vpn_tunnel.server", "enabled", "false"),
+					resourceFieldsEqual("vcd_nsxt_edgegateway_l2_vpn_tunnel.server", "data.vcd_nsxt_edgegateway_l2_vpn_tunnel.server", nil),
+				),
+			},
+			{
+				ResourceName:            "vcd_nsxt_edgegateway_l2_vpn_tunnel.server",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       importStateIdOrgNsxtVdcObject(testConfig, testConfig.Nsxt.EdgeGateway+ImportSeparator+t.Name()),
+				ImportStateVerifyIgnore: []string{"pre_shared_key"},
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdNsxtEdgeGatewayL2VpnTunnelPrereqs = `
+data "vcd_nsxt_edgegateway" "existing" {
+  org  = "{{.Org}}"
+  vdc  = "{{.NsxtVdc}}"
+  name = "{{.EdgeGw}}"
+}
+
+resource "vcd_network_routed_v2" "stretched" {
+  org  = "{{.Org}}"
+  vdc  = "{{.NsxtVdc}}"
+  name = "{{.TunnelName}}-net"
+
+  edge_gateway_id = data.vcd_nsxt_edgegateway.existing.id
+
+  gateway       = "10.10.102.1"
+  prefix_length = 24
+}
+`
+
+const testAccVcdNsxtEdgeGatewayL2VpnTunnelStep1 = testAccVcdNsxtEdgeGatewayL2VpnTunnelPrereqs + `
+resource "vcd_nsxt_edgegateway_l2_vpn_tunnel" "server" {
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.existing.id
+
+  name                      = "{{.TunnelName}}"
+  description               = "L2 VPN server session"
+  session_mode              = "SERVER"
+  enabled                   = {{.Enabled}}
+  connector_initiation_mode = "ON_DEMAND"
+
+  local_endpoint_ip  = data.vcd_nsxt_edgegateway.existing.primary_ip
+  remote_endpoint_ip = "1.2.3.4"
+  tunnel_interface   = "192.168.0.1/24"
+  pre_shared_key     = "secret-key"
+
+  stretched_network {
+    network_id = vcd_network_routed_v2.stretched.id
+  }
+}
+`
+
+const testAccVcdNsxtEdgeGatewayL2VpnTunnelStep2DS = testAccVcdNsxtEdgeGatewayL2VpnTunnelStep1 + `
+# skip-binary-test: Data Source test
+data "vcd_nsxt_edgegateway_l2_vpn_tunnel" "server" {
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.existing.id
+  name            = vcd_nsxt_edgegateway_l2_vpn_tunnel.server.name
+}
+`
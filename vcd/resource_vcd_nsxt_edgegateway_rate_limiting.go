@@ -0,0 +1,205 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// nsxtEdgeGatewayQosMinApiVersion is the first API version which supports Gateway QoS profiles
+// (VCD 10.3.2)
+const nsxtEdgeGatewayQosMinApiVersion = "36.2"
+
+func resourceVcdNsxtEdgegatewayRateLimiting() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdNsxtEdgegatewayRateLimitingCreateUpdate,
+		ReadContext:   resourceVcdNsxtEdgegatewayRateLimitingRead,
+		UpdateContext: resourceVcdNsxtEdgegatewayRateLimitingCreateUpdate,
+		DeleteContext: resourceVcdNsxtEdgegatewayRateLimitingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdNsxtEdgegatewayRateLimitingImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"edge_gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "NSX-T Edge Gateway ID for Rate limiting (QoS) configuration",
+			},
+			"ingress_profile_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Ingress profile ID for Rate limiting (QoS) configuration. Empty means 'unlimited'",
+			},
+			"egress_profile_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Egress profile ID for Rate limiting (QoS) configuration. Empty means 'unlimited'",
+			},
+		},
+	}
+}
+
+// nsxtEdgeGatewayQos binds Gateway QoS profiles to an NSX-T Edge Gateway. A nil reference means
+// that traffic in that direction is not limited.
+type nsxtEdgeGatewayQos struct {
+	IngressProfile *types.OpenApiReference `json:"ingressProfile"`
+	EgressProfile  *types.OpenApiReference `json:"egressProfile"`
+}
+
+func resourceVcdNsxtEdgegatewayRateLimitingCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	unlock, nsxtEdgeGateway, err := lockParentVdcGroupOrEdgeGateway(vcdClient, d, "rate limiting create/update")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	qosConfig := &nsxtEdgeGatewayQos{}
+	if ingressProfileId := d.Get("ingress_profile_id").(string); ingressProfileId != "" {
+		qosConfig.IngressProfile = &types.OpenApiReference{ID: ingressProfileId}
+	}
+	if egressProfileId := d.Get("egress_profile_id").(string); egressProfileId != "" {
+		qosConfig.EgressProfile = &types.OpenApiReference{ID: egressProfileId}
+	}
+
+	err = updateNsxtEdgeGatewayQos(&vcdClient.Client, nsxtEdgeGateway.EdgeGateway.ID, qosConfig)
+	if err != nil {
+		return diag.Errorf("[rate limiting create/update] error updating QoS configuration for NSX-T Edge Gateway '%s': %s",
+			nsxtEdgeGateway.EdgeGateway.Name, err)
+	}
+
+	d.SetId(nsxtEdgeGateway.EdgeGateway.ID)
+
+	return resourceVcdNsxtEdgegatewayRateLimitingRead(ctx, d, meta)
+}
+
+func resourceVcdNsxtEdgegatewayRateLimitingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	orgName, err := vcdClient.GetOrgNameFromResource(d)
+	if err != nil {
+		return diag.Errorf("[rate limiting read] error when getting Org name: %s", err)
+	}
+
+	nsxtEdgeGateway, err := vcdClient.GetNsxtEdgeGatewayById(orgName, d.Id())
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[rate limiting read] error retrieving NSX-T Edge Gateway: %s", err)
+	}
+
+	qosConfig, err := getNsxtEdgeGatewayQos(&vcdClient.Client, nsxtEdgeGateway.EdgeGateway.ID)
+	if err != nil {
+		return diag.Errorf("[rate limiting read] error retrieving QoS configuration for NSX-T Edge Gateway '%s': %s",
+			nsxtEdgeGateway.EdgeGateway.Name, err)
+	}
+
+	dSet(d, "edge_gateway_id", nsxtEdgeGateway.EdgeGateway.ID)
+
+	ingressProfileId := ""
+	if qosConfig.IngressProfile != nil {
+		ingressProfileId = qosConfig.IngressProfile.ID
+	}
+	dSet(d, "ingress_profile_id", ingressProfileId)
+
+	egressProfileId := ""
+	if qosConfig.EgressProfile != nil {
+		egressProfileId = qosConfig.EgressProfile.ID
+	}
+	dSet(d, "egress_profile_id", egressProfileId)
+
+	return nil
+}
+
+func resourceVcdNsxtEdgegatewayRateLimitingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	unlock, nsxtEdgeGateway, err := lockParentVdcGroupOrEdgeGateway(vcdClient, d, "rate limiting delete")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	// QoS configuration cannot be removed. Sending empty profiles sets traffic to 'unlimited'
+	err = updateNsxtEdgeGatewayQos(&vcdClient.Client, nsxtEdgeGateway.EdgeGateway.ID, &nsxtEdgeGatewayQos{})
+	if err != nil {
+		return diag.Errorf("[rate limiting delete] error removing QoS configuration for NSX-T Edge Gateway '%s': %s",
+			nsxtEdgeGateway.EdgeGateway.Name, err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceVcdNsxtEdgegatewayRateLimitingImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Printf("[TRACE] NSX-T Edge Gateway Rate Limiting import initiated")
+
+	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	if len(resourceURI) != 3 {
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name")
+	}
+	orgName, vdcOrVdcGroupName, edgeName := resourceURI[0], resourceURI[1], resourceURI[2]
+
+	vcdClient := meta.(*VCDClient)
+	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(vcdClient, orgName, vdcOrVdcGroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	edge, err := vdcOrVdcGroup.GetNsxtEdgeGatewayByName(edgeName)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve NSX-T Edge Gateway '%s': %s", edgeName, err)
+	}
+
+	dSet(d, "org", orgName)
+	dSet(d, "edge_gateway_id", edge.EdgeGateway.ID)
+	d.SetId(edge.EdgeGateway.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func getNsxtEdgeGatewayQos(client *govcd.Client, edgeGatewayId string) (*nsxtEdgeGatewayQos, error) {
+	urlRef, err := openApiBuildEndpointWithVersion(client, nsxtEdgeGatewayQosMinApiVersion,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointEdgeGateways, edgeGatewayId, "/qos")
+	if err != nil {
+		return nil, err
+	}
+
+	qosConfig := &nsxtEdgeGatewayQos{}
+	err = client.OpenApiGetItem(nsxtEdgeGatewayQosMinApiVersion, urlRef, nil, qosConfig, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return qosConfig, nil
+}
+
+func updateNsxtEdgeGatewayQos(client *govcd.Client, edgeGatewayId string, qosConfig *nsxtEdgeGatewayQos) error {
+	urlRef, err := openApiBuildEndpointWithVersion(client, nsxtEdgeGatewayQosMinApiVersion,
+		types.OpenApiPathVersion1_0_0, types.OpenApiEndpointEdgeGateways, edgeGatewayId, "/qos")
+	if err != nil {
+		return err
+	}
+
+	updatedQosConfig := &nsxtEdgeGatewayQos{}
+	return client.OpenApiPutItem(nsxtEdgeGatewayQosMinApiVersion, urlRef, nil, qosConfig, updatedQosConfig, nil)
+}
@@ -0,0 +1,118 @@
+//go:build network || nsxt || ALL || functional
+// +build network nsxt ALL functional
+
+package vcd
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVcdNsxtEdgeGatewayRateLimiting(t *testing.T) {
+	preTestChecks(t)
+	if !usingSysAdmin() {
+		t.Skip(t.Name() + " requires system admin privileges to lookup Gateway QoS Profiles")
+		return
+	}
+
+	vcdClient := createTemporaryVCDConnection(false)
+	if vcdClient.Client.APIVCDMaxVersionIs("< 36.2") {
+		t.Skipf("This test tests VCD 10.3.2+ (API V36.2+) features. Skipping.")
+	}
+
+	if testConfig.Nsxt.GatewayQosProfile == "" {
+		t.Skip("Missing NSX-T Gateway QoS Profile in test configuration")
+	}
+
+	// String map to fill the template
+	var params = StringMap{
+		"Org":               testConfig.VCD.Org,
+		"NsxtVdc":           testConfig.Nsxt.Vdc,
+		"EdgeGw":            testConfig.Nsxt.EdgeGateway,
+		"NsxtManager":       testConfig.Nsxt.Manager,
+		"GatewayQosProfile": testConfig.Nsxt.GatewayQosProfile,
+		"Tags":              "network nsxt",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText1 := templateFill(testAccVcdNsxtEdgeGatewayRateLimitingStep1, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "-step2"
+	configText2 := templateFill(testAccVcdNsxtEdgeGatewayRateLimitingStep2, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("vcd_nsxt_edgegateway_rate_limiting.testing", "id", regexp.MustCompile(`^urn:vcloud:gateway:.*$`)),
+					resource.TestCheckResourceAttrPair("vcd_nsxt_edgegateway_rate_limiting.testing", "ingress_profile_id", "data.vcd_nsxt_edgegateway_qos_profile.qos-1", "id"),
+					resource.TestCheckResourceAttrPair("vcd_nsxt_edgegateway_rate_limiting.testing", "egress_profile_id", "data.vcd_nsxt_edgegateway_qos_profile.qos-1", "id"),
+					resource.TestCheckResourceAttrSet("data.vcd_nsxt_edgegateway_qos_profile.qos-1", "committed_bandwidth"),
+					resource.TestCheckResourceAttrSet("data.vcd_nsxt_edgegateway_qos_profile.qos-1", "excess_action"),
+				),
+			},
+			{
+				ResourceName:      "vcd_nsxt_edgegateway_rate_limiting.testing",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdOrgNsxtVdcObject(testConfig, testConfig.Nsxt.EdgeGateway),
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("vcd_nsxt_edgegateway_rate_limiting.testing", "id", regexp.MustCompile(`^urn:vcloud:gateway:.*$`)),
+					resource.TestCheckResourceAttr("vcd_nsxt_edgegateway_rate_limiting.testing", "ingress_profile_id", ""),
+					resource.TestCheckResourceAttrPair("vcd_nsxt_edgegateway_rate_limiting.testing", "egress_profile_id", "data.vcd_nsxt_edgegateway_qos_profile.qos-1", "id"),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdNsxtEdgeGatewayRateLimitingPrereqs = `
+data "vcd_nsxt_manager" "main" {
+  name = "{{.NsxtManager}}"
+}
+
+data "vcd_nsxt_edgegateway_qos_profile" "qos-1" {
+  nsxt_manager_id = data.vcd_nsxt_manager.main.id
+  name            = "{{.GatewayQosProfile}}"
+}
+
+data "vcd_nsxt_edgegateway" "testing" {
+  org  = "{{.Org}}"
+  vdc  = "{{.NsxtVdc}}"
+  name = "{{.EdgeGw}}"
+}
+`
+
+const testAccVcdNsxtEdgeGatewayRateLimitingStep1 = testAccVcdNsxtEdgeGatewayRateLimitingPrereqs + `
+resource "vcd_nsxt_edgegateway_rate_limiting" "testing" {
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.testing.id
+
+  ingress_profile_id = data.vcd_nsxt_edgegateway_qos_profile.qos-1.id
+  egress_profile_id  = data.vcd_nsxt_edgegateway_qos_profile.qos-1.id
+}
+`
+
+const testAccVcdNsxtEdgeGatewayRateLimitingStep2 = testAccVcdNsxtEdgeGatewayRateLimitingPrereqs + `
+resource "vcd_nsxt_edgegateway_rate_limiting" "testing" {
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.testing.id
+
+  egress_profile_id = data.vcd_nsxt_edgegateway_qos_profile.qos-1.id
+}
+`
@@ -863,3 +863,108 @@ data "vcd_nsxt_edgegateway" "nsxt-edge" {
   name     = vcd_nsxt_edgegateway.nsxt-edge.name
 }
 `
+
+// TestAccVcdNsxtEdgeGatewayIpCount tests out auto-allocation of IP addresses using
+// 'subnet_with_ip_count' instead of explicit 'allocated_ips' ranges. An IP Set referencing the
+// allocated ranges checks that it is planned against the new ranges when the IP count changes
+func TestAccVcdNsxtEdgeGatewayIpCount(t *testing.T) {
+	preTestChecks(t)
+	if !usingSysAdmin() {
+		t.Skip(t.Name() + " requires system admin privileges")
+		return
+	}
+
+	skipNoConfiguration(t, StringMap{"Nsxt.ExternalNetwork": testConfig.Nsxt.ExternalNetwork})
+
+	var params = StringMap{
+		"Org":                testConfig.VCD.Org,
+		"NsxtVdc":            testConfig.Nsxt.Vdc,
+		"NsxtEdgeGatewayVcd": "nsxt-edge-ip-count",
+		"ExternalNetwork":    testConfig.Nsxt.ExternalNetwork,
+		"IpCount":            "2",
+		"Tags":               "gateway nsxt",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText := templateFill(testAccNsxtEdgeGatewayIpCount, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText)
+
+	params["FuncName"] = t.Name() + "step1"
+	params["IpCount"] = "3"
+	configText1 := templateFill(testAccNsxtEdgeGatewayIpCount, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText1)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_nsxt_edgegateway.nsxt-edge"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdNsxtEdgeGatewayDestroy(params["NsxtEdgeGatewayVcd"].(string)),
+		Steps: []resource.TestStep{
+			{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", params["NsxtEdgeGatewayVcd"].(string)),
+					resource.TestCheckResourceAttr(resourceName, "subnet_with_ip_count.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "subnet_with_ip_count.*", map[string]string{
+						"allocated_ip_count": "2",
+					}),
+					resource.TestCheckResourceAttr(resourceName, "subnet.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "primary_ip"),
+					resource.TestCheckResourceAttrPair("vcd_nsxt_ip_set.allocated", "ip_addresses.#", resourceName, "subnet.0.allocated_ips.#"),
+				),
+			},
+			{
+				Config: configText1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "subnet_with_ip_count.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "subnet_with_ip_count.*", map[string]string{
+						"allocated_ip_count": "3",
+					}),
+					resource.TestCheckResourceAttr(resourceName, "subnet.#", "1"),
+					resource.TestCheckResourceAttrPair("vcd_nsxt_ip_set.allocated", "ip_addresses.#", resourceName, "subnet.0.allocated_ips.#"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdOrgNsxtVdcObject(testConfig, params["NsxtEdgeGatewayVcd"].(string)),
+				// Imported resource always uses 'subnet' with explicit 'allocated_ips'
+				ImportStateVerifyIgnore: []string{"subnet_with_ip_count"},
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccNsxtEdgeGatewayIpCount = testAccNsxtEdgeGatewayDataSources + `
+resource "vcd_nsxt_edgegateway" "nsxt-edge" {
+  org  = "{{.Org}}"
+  vdc  = "{{.NsxtVdc}}"
+  name = "{{.NsxtEdgeGatewayVcd}}"
+
+  external_network_id = data.vcd_external_network_v2.existing-extnet.id
+
+  subnet_with_ip_count {
+    gateway            = tolist(data.vcd_external_network_v2.existing-extnet.ip_scope)[0].gateway
+    prefix_length      = tolist(data.vcd_external_network_v2.existing-extnet.ip_scope)[0].prefix_length
+    allocated_ip_count = {{.IpCount}}
+  }
+}
+
+resource "vcd_nsxt_ip_set" "allocated" {
+  org             = "{{.Org}}"
+  edge_gateway_id = vcd_nsxt_edgegateway.nsxt-edge.id
+
+  name         = "allocated-ips"
+  ip_addresses = flatten([
+    for subnet in vcd_nsxt_edgegateway.nsxt-edge.subnet : [
+      for ip_range in subnet.allocated_ips : "${ip_range.start_address}-${ip_range.end_address}"
+    ]
+  ])
+}
+`
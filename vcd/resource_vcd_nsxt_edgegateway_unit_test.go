@@ -0,0 +1,48 @@
+//go:build unit || ALL
+// +build unit ALL
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func TestCountIpsInRanges(t *testing.T) {
+	tests := []struct {
+		name        string
+		ipRanges    []types.OpenApiIPRangeValues
+		expected    int
+		expectError bool
+	}{
+		{name: "empty", expected: 0},
+		{name: "single IP", ipRanges: []types.OpenApiIPRangeValues{{StartAddress: "10.0.0.1", EndAddress: "10.0.0.1"}}, expected: 1},
+		{name: "no end address", ipRanges: []types.OpenApiIPRangeValues{{StartAddress: "10.0.0.1"}}, expected: 1},
+		{name: "multiple ranges", ipRanges: []types.OpenApiIPRangeValues{
+			{StartAddress: "10.0.0.1", EndAddress: "10.0.0.10"},
+			{StartAddress: "10.0.1.250", EndAddress: "10.0.2.4"},
+		}, expected: 21},
+		{name: "IPv6", ipRanges: []types.OpenApiIPRangeValues{{StartAddress: "2001:db8::fffe", EndAddress: "2001:db8::1:1"}}, expected: 4},
+		{name: "IPv6 too large", ipRanges: []types.OpenApiIPRangeValues{{StartAddress: "2001:db8::", EndAddress: "2001:db8::ffff:ffff:ffff:ffff"}}, expectError: true},
+		{name: "invalid", ipRanges: []types.OpenApiIPRangeValues{{StartAddress: "10.0.0.300", EndAddress: "10.0.0.1"}}, expectError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			count, err := countIpsInRanges(test.ipRanges)
+			if test.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if count != test.expected {
+				t.Errorf("expected %d IPs, got %d", test.expected, count)
+			}
+		})
+	}
+}
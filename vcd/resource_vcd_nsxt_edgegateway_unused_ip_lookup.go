@@ -0,0 +1,367 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// nsxtEdgeGatewayUsedIpAddressesMinApiVersion is the first API version which reports used IP
+// addresses of NSX-T Edge Gateway (VCD 10.2)
+const nsxtEdgeGatewayUsedIpAddressesMinApiVersion = "35.0"
+
+const nsxtEdgeGatewayUsedIpAddressesEndpoint = "edgeGateways/%s/usedIpAddresses"
+
+func resourceVcdNsxtEdgegatewayUnusedIpLookup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdNsxtEdgegatewayUnusedIpLookupCreate,
+		ReadContext:   resourceVcdNsxtEdgegatewayUnusedIpLookupRead,
+		DeleteContext: resourceVcdNsxtEdgegatewayUnusedIpLookupDelete,
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"edge_gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "NSX-T Edge Gateway ID from which IP addresses should be picked",
+			},
+			"ip_count": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Number of unused IP addresses to pick. They are not reserved in VCD",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"ip_addresses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Unused IP addresses picked from the Edge Gateway",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// nsxtEdgeGatewayUsedIpAddress defines a single IP address of NSX-T Edge Gateway which is used by
+// NAT rules, Load Balancer Virtual Services, IPSec VPN tunnels or other services
+type nsxtEdgeGatewayUsedIpAddress struct {
+	IpAddress  string                  `json:"ipAddress"`
+	Category   string                  `json:"category"`
+	EntityRef  *types.OpenApiReference `json:"entityRef,omitempty"`
+	NetworkRef *types.OpenApiReference `json:"networkRef,omitempty"`
+}
+
+// nsxtEdgeGatewayHandedOutIps keeps IP addresses handed out by 'vcd_nsxt_edgegateway_unused_ip_lookup'
+// resources during the lifetime of the provider process. It is NOT a reservation: VCD has no API to
+// reserve Edge Gateway IP addresses and reports them as used only after an entity (e.g. NAT rule)
+// consumes them. The map only prevents multiple resources of the same Terraform run from receiving
+// the same IP address. Handed out IP addresses of resources in state are added during refresh.
+var nsxtEdgeGatewayHandedOutIps = struct {
+	sync.Mutex
+	ipAddresses map[string]map[string]bool
+}{ipAddresses: make(map[string]map[string]bool)}
+
+func rememberNsxtEdgeGatewayHandedOutIps(edgeGatewayId string, ipAddresses []string) {
+	nsxtEdgeGatewayHandedOutIps.Lock()
+	defer nsxtEdgeGatewayHandedOutIps.Unlock()
+
+	if nsxtEdgeGatewayHandedOutIps.ipAddresses[edgeGatewayId] == nil {
+		nsxtEdgeGatewayHandedOutIps.ipAddresses[edgeGatewayId] = make(map[string]bool)
+	}
+	for _, ipAddress := range ipAddresses {
+		nsxtEdgeGatewayHandedOutIps.ipAddresses[edgeGatewayId][ipAddress] = true
+	}
+}
+
+func forgetNsxtEdgeGatewayHandedOutIps(edgeGatewayId string, ipAddresses []string) {
+	nsxtEdgeGatewayHandedOutIps.Lock()
+	defer nsxtEdgeGatewayHandedOutIps.Unlock()
+
+	for _, ipAddress := range ipAddresses {
+		delete(nsxtEdgeGatewayHandedOutIps.ipAddresses[edgeGatewayId], ipAddress)
+	}
+}
+
+// getNsxtEdgeGatewayHandedOutIps returns a copy of IP addresses handed out for a given Edge Gateway
+func getNsxtEdgeGatewayHandedOutIps(edgeGatewayId string) map[string]bool {
+	nsxtEdgeGatewayHandedOutIps.Lock()
+	defer nsxtEdgeGatewayHandedOutIps.Unlock()
+
+	handedOutIps := make(map[string]bool, len(nsxtEdgeGatewayHandedOutIps.ipAddresses[edgeGatewayId]))
+	for ipAddress := range nsxtEdgeGatewayHandedOutIps.ipAddresses[edgeGatewayId] {
+		handedOutIps[ipAddress] = true
+	}
+	return handedOutIps
+}
+
+func resourceVcdNsxtEdgegatewayUnusedIpLookupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	unlock, nsxtEdgeGateway, err := lockParentVdcGroupOrEdgeGateway(vcdClient, d, "unused ip lookup create")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	ipCount := d.Get("ip_count").(int)
+	edgeGatewayId := nsxtEdgeGateway.EdgeGateway.ID
+
+	usedIpAddresses, err := getNsxtEdgeGatewayUsedIpAddresses(&vcdClient.Client, edgeGatewayId)
+	if err != nil {
+		return diag.Errorf("[unused ip lookup create] error retrieving used IP addresses of NSX-T Edge Gateway '%s': %s",
+			nsxtEdgeGateway.EdgeGateway.Name, err)
+	}
+
+	unusedIpAddresses, err := getNsxtEdgeGatewayUnusedIpAddresses(nsxtEdgeGateway.EdgeGateway, usedIpAddresses,
+		getNsxtEdgeGatewayHandedOutIps(edgeGatewayId), ipCount)
+	if err != nil {
+		return diag.Errorf("[unused ip lookup create] error finding unused IP addresses of NSX-T Edge Gateway '%s': %s",
+			nsxtEdgeGateway.EdgeGateway.Name, err)
+	}
+
+	if len(unusedIpAddresses) < ipCount {
+		return diag.Errorf("[unused ip lookup create] NSX-T Edge Gateway '%s' has only %d unused IP addresses, but %d were requested",
+			nsxtEdgeGateway.EdgeGateway.Name, len(unusedIpAddresses), ipCount)
+	}
+
+	rememberNsxtEdgeGatewayHandedOutIps(edgeGatewayId, unusedIpAddresses)
+
+	err = d.Set("ip_addresses", unusedIpAddresses)
+	if err != nil {
+		return diag.Errorf("[unused ip lookup create] error storing 'ip_addresses': %s", err)
+	}
+	dSet(d, "edge_gateway_id", edgeGatewayId)
+	d.SetId(fmt.Sprintf("%s.%d", edgeGatewayId, hashcodeString(strings.Join(unusedIpAddresses, ","))))
+
+	return resourceVcdNsxtEdgegatewayUnusedIpLookupRead(ctx, d, meta)
+}
+
+func resourceVcdNsxtEdgegatewayUnusedIpLookupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	orgName, err := vcdClient.GetOrgNameFromResource(d)
+	if err != nil {
+		return diag.Errorf("[unused ip lookup read] error when getting Org name: %s", err)
+	}
+
+	nsxtEdgeGateway, err := vcdClient.GetNsxtEdgeGatewayById(orgName, d.Get("edge_gateway_id").(string))
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[unused ip lookup read] error retrieving NSX-T Edge Gateway: %s", err)
+	}
+
+	ipAddresses := convertToStringSlice(d.Get("ip_addresses").([]interface{}))
+
+	// IP addresses that are no longer part of Edge Gateway allocations cannot be used. The resource
+	// is removed from state so that new IP addresses are picked.
+	for _, ipAddress := range ipAddresses {
+		if !isIpAllocatedToNsxtEdgeGateway(nsxtEdgeGateway.EdgeGateway, ipAddress) {
+			forgetNsxtEdgeGatewayHandedOutIps(nsxtEdgeGateway.EdgeGateway.ID, ipAddresses)
+			d.SetId("")
+			return nil
+		}
+	}
+
+	rememberNsxtEdgeGatewayHandedOutIps(nsxtEdgeGateway.EdgeGateway.ID, ipAddresses)
+
+	return nil
+}
+
+func resourceVcdNsxtEdgegatewayUnusedIpLookupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// IP addresses are not reserved in VCD therefore it is enough to forget them
+	ipAddresses := convertToStringSlice(d.Get("ip_addresses").([]interface{}))
+	forgetNsxtEdgeGatewayHandedOutIps(d.Get("edge_gateway_id").(string), ipAddresses)
+
+	d.SetId("")
+
+	return nil
+}
+
+func getNsxtEdgeGatewayUsedIpAddresses(client *govcd.Client, edgeGatewayId string) ([]*nsxtEdgeGatewayUsedIpAddress, error) {
+	urlRef, err := openApiBuildEndpointWithVersion(client, nsxtEdgeGatewayUsedIpAddressesMinApiVersion,
+		types.OpenApiPathVersion1_0_0, fmt.Sprintf(nsxtEdgeGatewayUsedIpAddressesEndpoint, edgeGatewayId))
+	if err != nil {
+		return nil, err
+	}
+
+	usedIpAddresses := []*nsxtEdgeGatewayUsedIpAddress{{}}
+	err = client.OpenApiGetAllItems(nsxtEdgeGatewayUsedIpAddressesMinApiVersion, urlRef, nil, &usedIpAddresses, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return usedIpAddresses, nil
+}
+
+// getNsxtEdgeGatewayUnusedIpAddresses walks IP ranges allocated to NSX-T Edge Gateway and returns up
+// to 'limit' IP addresses which are neither used in VCD nor present in 'excludedIpAddresses'. A
+// limit of 0 returns all unused IP addresses and is refused for Edge Gateways with IPv6 ranges, as
+// walking them one address at a time never ends.
+func getNsxtEdgeGatewayUnusedIpAddresses(edgeGateway *types.OpenAPIEdgeGateway, usedIpAddresses []*nsxtEdgeGatewayUsedIpAddress, excludedIpAddresses map[string]bool, limit int) ([]string, error) {
+	if limit == 0 && hasNsxtEdgeGatewayIpv6Ranges(edgeGateway) {
+		return nil, fmt.Errorf("listing all unused IP addresses is not supported for Edge Gateways with IPv6 ranges, a limit must be set")
+	}
+
+	usedIpMap := make(map[string]bool)
+	for _, usedIpAddress := range usedIpAddresses {
+		usedIpMap[normalizeIpAddress(usedIpAddress.IpAddress)] = true
+	}
+
+	unusedIpAddresses := make([]string, 0)
+	for _, uplink := range edgeGateway.EdgeGatewayUplinks {
+		for _, subnet := range uplink.Subnets.Values {
+			// Primary IP is always used by Edge Gateway itself
+			if subnet.PrimaryIP != "" {
+				usedIpMap[normalizeIpAddress(subnet.PrimaryIP)] = true
+			}
+
+			if subnet.IPRanges == nil {
+				continue
+			}
+
+			for _, ipRange := range subnet.IPRanges.Values {
+				endAddress := ipRange.EndAddress
+				if endAddress == "" {
+					endAddress = ipRange.StartAddress
+				}
+				err := forEachIpInRange(ipRange.StartAddress, endAddress, func(ipAddress string) bool {
+					if !usedIpMap[ipAddress] && !excludedIpAddresses[ipAddress] {
+						unusedIpAddresses = append(unusedIpAddresses, ipAddress)
+					}
+					return limit == 0 || len(unusedIpAddresses) < limit
+				})
+				if err != nil {
+					return nil, err
+				}
+				if limit != 0 && len(unusedIpAddresses) >= limit {
+					return unusedIpAddresses, nil
+				}
+			}
+		}
+	}
+
+	return unusedIpAddresses, nil
+}
+
+// hasNsxtEdgeGatewayIpv6Ranges checks if any of the IP ranges allocated to NSX-T Edge Gateway is IPv6
+func hasNsxtEdgeGatewayIpv6Ranges(edgeGateway *types.OpenAPIEdgeGateway) bool {
+	for _, uplink := range edgeGateway.EdgeGatewayUplinks {
+		for _, subnet := range uplink.Subnets.Values {
+			if subnet.IPRanges == nil {
+				continue
+			}
+			for _, ipRange := range subnet.IPRanges.Values {
+				startIp := net.ParseIP(ipRange.StartAddress)
+				if startIp != nil && startIp.To4() == nil {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isIpAllocatedToNsxtEdgeGateway checks if IP address is within IP ranges allocated to NSX-T Edge
+// Gateway
+func isIpAllocatedToNsxtEdgeGateway(edgeGateway *types.OpenAPIEdgeGateway, ipAddress string) bool {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return false
+	}
+
+	for _, uplink := range edgeGateway.EdgeGatewayUplinks {
+		for _, subnet := range uplink.Subnets.Values {
+			if subnet.IPRanges == nil {
+				continue
+			}
+			for _, ipRange := range subnet.IPRanges.Values {
+				endAddress := ipRange.EndAddress
+				if endAddress == "" {
+					endAddress = ipRange.StartAddress
+				}
+				startIp, endIp := net.ParseIP(ipRange.StartAddress), net.ParseIP(endAddress)
+				if startIp == nil || endIp == nil {
+					continue
+				}
+				ipInt := ipToBigInt(ip)
+				if ipInt.Cmp(ipToBigInt(startIp)) >= 0 && ipInt.Cmp(ipToBigInt(endIp)) <= 0 {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// forEachIpInRange calls function f for each IP address between startAddress and endAddress
+// (inclusive) until f returns false. Both IPv4 and IPv6 ranges are supported.
+func forEachIpInRange(startAddress, endAddress string, f func(ipAddress string) bool) error {
+	startIp, endIp := net.ParseIP(startAddress), net.ParseIP(endAddress)
+	if startIp == nil || endIp == nil {
+		return fmt.Errorf("invalid IP range '%s-%s'", startAddress, endAddress)
+	}
+
+	isIpv4 := startIp.To4() != nil
+	if isIpv4 != (endIp.To4() != nil) {
+		return fmt.Errorf("IP range '%s-%s' mixes IPv4 and IPv6 addresses", startAddress, endAddress)
+	}
+
+	one := big.NewInt(1)
+	endInt := ipToBigInt(endIp)
+	for current := ipToBigInt(startIp); current.Cmp(endInt) <= 0; current.Add(current, one) {
+		if !f(bigIntToIp(current, isIpv4).String()) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func ipToBigInt(ip net.IP) *big.Int {
+	if ipv4 := ip.To4(); ipv4 != nil {
+		return new(big.Int).SetBytes(ipv4)
+	}
+	return new(big.Int).SetBytes(ip.To16())
+}
+
+func bigIntToIp(ipInt *big.Int, isIpv4 bool) net.IP {
+	size := net.IPv6len
+	if isIpv4 {
+		size = net.IPv4len
+	}
+	ipBytes := ipInt.Bytes()
+	ip := make(net.IP, size)
+	copy(ip[size-len(ipBytes):], ipBytes)
+	return ip
+}
+
+// normalizeIpAddress returns canonical form of IP address so that different IPv6 notations match
+func normalizeIpAddress(ipAddress string) string {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return ipAddress
+	}
+	return ip.String()
+}
@@ -0,0 +1,111 @@
+//go:build network || nsxt || ALL || functional
+// +build network nsxt ALL functional
+
+package vcd
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVcdNsxtEdgeGatewayUnusedIpLookup(t *testing.T) {
+	preTestChecks(t)
+
+	// String map to fill the template
+	var params = StringMap{
+		"Org":     testConfig.VCD.Org,
+		"NsxtVdc": testConfig.Nsxt.Vdc,
+		"EdgeGw":  testConfig.Nsxt.EdgeGateway,
+		"Tags":    "network nsxt",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText1 := templateFill(testAccVcdNsxtEdgeGatewayUnusedIpLookupStep1, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "-step2"
+	configText2 := templateFill(testAccVcdNsxtEdgeGatewayUnusedIpLookupStep2, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("vcd_nsxt_edgegateway_unused_ip_lookup.first", "id", regexp.MustCompile(`^urn:vcloud:gateway:.*$`)),
+					resource.TestCheckResourceAttr("vcd_nsxt_edgegateway_unused_ip_lookup.first", "ip_addresses.#", "2"),
+					resource.TestCheckResourceAttr("vcd_nsxt_edgegateway_unused_ip_lookup.second", "ip_addresses.#", "1"),
+					// Second allocation must not overlap with the first one
+					resourceFieldsNotEqual("vcd_nsxt_edgegateway_unused_ip_lookup.first", "ip_addresses.0", "vcd_nsxt_edgegateway_unused_ip_lookup.second", "ip_addresses.0"),
+					resourceFieldsNotEqual("vcd_nsxt_edgegateway_unused_ip_lookup.first", "ip_addresses.1", "vcd_nsxt_edgegateway_unused_ip_lookup.second", "ip_addresses.0"),
+					resource.TestCheckResourceAttrPair("vcd_nsxt_nat_rule.dnat", "external_address", "vcd_nsxt_edgegateway_unused_ip_lookup.first", "ip_addresses.0"),
+				),
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vcd_nsxt_edgegateway_ip_allocation.usage", "unused_ip_addresses.0"),
+					// IP addresses picked by lookup resources remain unused in VCD until they are consumed
+					resource.TestCheckResourceAttrPair("data.vcd_nsxt_edgegateway_ip_allocation.usage", "unused_ip_addresses.0", "vcd_nsxt_edgegateway_unused_ip_lookup.first", "ip_addresses.1"),
+					resource.TestCheckResourceAttrPair("data.vcd_nsxt_edgegateway_ip_allocation.usage", "unused_ip_addresses.1", "vcd_nsxt_edgegateway_unused_ip_lookup.second", "ip_addresses.0"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.vcd_nsxt_edgegateway_ip_allocation.usage", "used_ip.*", map[string]string{
+						"category":    "DNAT",
+						"entity_name": "test-dnat",
+					}),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdNsxtEdgeGatewayUnusedIpLookupStep1 = `
+data "vcd_nsxt_edgegateway" "existing" {
+  org  = "{{.Org}}"
+  vdc  = "{{.NsxtVdc}}"
+  name = "{{.EdgeGw}}"
+}
+
+resource "vcd_nsxt_edgegateway_unused_ip_lookup" "first" {
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.existing.id
+
+  ip_count = 2
+}
+
+resource "vcd_nsxt_edgegateway_unused_ip_lookup" "second" {
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.existing.id
+
+  ip_count = 1
+}
+
+resource "vcd_nsxt_nat_rule" "dnat" {
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.existing.id
+
+  name             = "test-dnat"
+  rule_type        = "DNAT"
+  external_address = vcd_nsxt_edgegateway_unused_ip_lookup.first.ip_addresses[0]
+  internal_address = "11.11.11.2"
+}
+`
+
+const testAccVcdNsxtEdgeGatewayUnusedIpLookupStep2 = testAccVcdNsxtEdgeGatewayUnusedIpLookupStep1 + `
+data "vcd_nsxt_edgegateway_ip_allocation" "usage" {
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.existing.id
+
+  unused_ip_limit = 5
+
+  depends_on = [vcd_nsxt_nat_rule.dnat]
+}
+`
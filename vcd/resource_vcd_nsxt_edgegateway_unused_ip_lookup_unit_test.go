@@ -0,0 +1,85 @@
+//go:build unit || ALL
+// +build unit ALL
+
+package vcd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func TestGetNsxtEdgeGatewayUnusedIpAddresses(t *testing.T) {
+	edgeGateway := &types.OpenAPIEdgeGateway{
+		ID: "urn:vcloud:gateway:unit-test",
+		EdgeGatewayUplinks: []types.EdgeGatewayUplinks{{
+			Subnets: types.OpenAPIEdgeGatewaySubnets{Values: []types.OpenAPIEdgeGatewaySubnetValue{
+				{
+					PrimaryIP: "10.0.0.10",
+					IPRanges: &types.OpenApiIPRanges{Values: []types.OpenApiIPRangeValues{
+						{StartAddress: "10.0.0.10", EndAddress: "10.0.0.14"},
+						{StartAddress: "10.0.0.20"},
+					}},
+				},
+				{
+					IPRanges: &types.OpenApiIPRanges{Values: []types.OpenApiIPRangeValues{
+						{StartAddress: "2001:db8::fffe", EndAddress: "2001:db8::1:1"},
+					}},
+				},
+			}},
+		}},
+	}
+	usedIpAddresses := []*nsxtEdgeGatewayUsedIpAddress{
+		{IpAddress: "10.0.0.12", Category: "SNAT"},
+		{IpAddress: "2001:db8:0:0:0:0:0:ffff", Category: "LOAD_BALANCER"},
+	}
+
+	tests := []struct {
+		name     string
+		limit    int
+		expected []string
+	}{
+		{name: "limit", limit: 2, expected: []string{"10.0.0.11", "10.0.0.13"}},
+		{name: "single IP range", limit: 4, expected: []string{"10.0.0.11", "10.0.0.13", "10.0.0.14", "10.0.0.20"}},
+		{name: "all ranges", limit: 10, expected: []string{"10.0.0.11", "10.0.0.13", "10.0.0.14", "10.0.0.20",
+			"2001:db8::fffe", "2001:db8::1:0", "2001:db8::1:1"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			unusedIpAddresses, err := getNsxtEdgeGatewayUnusedIpAddresses(edgeGateway, usedIpAddresses, nil, test.limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(unusedIpAddresses, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, unusedIpAddresses)
+			}
+		})
+	}
+
+	// Listing all unused IP addresses is refused when IPv6 ranges are present
+	_, err := getNsxtEdgeGatewayUnusedIpAddresses(edgeGateway, usedIpAddresses, nil, 0)
+	if err == nil {
+		t.Errorf("expected an error when listing all unused IP addresses of IPv6 ranges")
+	}
+
+	// IP addresses handed out to other resources must not be offered again
+	rememberNsxtEdgeGatewayHandedOutIps(edgeGateway.ID, []string{"10.0.0.11"})
+	defer forgetNsxtEdgeGatewayHandedOutIps(edgeGateway.ID, []string{"10.0.0.11"})
+	unusedIpAddresses, err := getNsxtEdgeGatewayUnusedIpAddresses(edgeGateway, usedIpAddresses,
+		getNsxtEdgeGatewayHandedOutIps(edgeGateway.ID), 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(unusedIpAddresses, []string{"10.0.0.13"}) {
+		t.Errorf("expected [10.0.0.13], got %v", unusedIpAddresses)
+	}
+
+	if !isIpAllocatedToNsxtEdgeGateway(edgeGateway, "2001:db8::1:0") {
+		t.Errorf("expected IP '2001:db8::1:0' to be allocated to Edge Gateway")
+	}
+	if isIpAllocatedToNsxtEdgeGateway(edgeGateway, "10.0.0.15") {
+		t.Errorf("expected IP '10.0.0.15' not to be allocated to Edge Gateway")
+	}
+}
@@ -0,0 +1,405 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func resourceVcdNsxtFirewallRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdNsxtFirewallRuleCreate,
+		ReadContext:   resourceVcdNsxtFirewallRuleRead,
+		UpdateContext: resourceVcdNsxtFirewallRuleUpdate,
+		DeleteContext: resourceVcdNsxtFirewallRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdNsxtFirewallRuleImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"edge_gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Edge Gateway ID in which Firewall Rule is located",
+			},
+			"position": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "bottom",
+				Description:  "Position of the rule in user defined rules. One of 'top', 'bottom', 'before', 'after'",
+				ValidateFunc: validation.StringInSlice([]string{"top", "bottom", "before", "after"}, false),
+			},
+			"position_rule_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Firewall Rule ID to place this rule 'before' or 'after'",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Firewall Rule name",
+			},
+			"direction": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Direction on which Firewall Rule applies (One of 'IN', 'OUT', 'IN_OUT')",
+				ValidateFunc: validation.StringInSlice([]string{"IN", "OUT", "IN_OUT"}, false),
+			},
+			"ip_protocol": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Firewall Rule Protocol (One of 'IPV4', 'IPV6', 'IPV4_IPV6')",
+				ValidateFunc: validation.StringInSlice([]string{"IPV4", "IPV6", "IPV4_IPV6"}, false),
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Defines if the rule should 'ALLOW' or 'DROP' matching traffic",
+				ValidateFunc: validation.StringInSlice([]string{"ALLOW", "DROP"}, false),
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Defined if Firewall Rule is active",
+			},
+			"logging": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Defines if matching traffic should be logged",
+			},
+			"source_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A set of Source Firewall Group IDs (IP Sets or Security Groups). Leaving it empty means 'Any'",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"destination_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A set of Destination Firewall Group IDs (IP Sets or Security Groups). Leaving it empty means 'Any'",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"app_port_profile_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A set of Application Port Profile IDs. Leaving it empty means 'Any'",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// resourceVcdNsxtFirewallRuleCreate inserts a single rule into user defined rules of an Edge
+// Gateway. The API only allows to set the whole ordered list of rules, therefore all rules are read,
+// the new one is inserted at requested position and the complete list is sent back while holding a
+// lock on parent Edge Gateway.
+func resourceVcdNsxtFirewallRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	vcdClient.lockParentEdgeGtw(d)
+	defer vcdClient.unLockParentEdgeGtw(d)
+
+	err := validateNsxtFirewallRulePosition(d)
+	if err != nil {
+		return diag.Errorf("[nsxt firewall rule create] %s", err)
+	}
+
+	nsxtEdge, firewall, err := getNsxtFirewallRuleParents(vcdClient, d)
+	if err != nil {
+		return diag.Errorf("[nsxt firewall rule create] %s", err)
+	}
+
+	newRule := getNsxtFirewallRuleType(d)
+	userDefinedRules, index, err := insertNsxtFirewallRule(firewall.NsxtFirewallRuleContainer.UserDefinedRules, newRule,
+		d.Get("position").(string), d.Get("position_rule_id").(string))
+	if err != nil {
+		return diag.Errorf("[nsxt firewall rule create] %s", err)
+	}
+
+	updatedFirewall, err := nsxtEdge.UpdateNsxtFirewall(&types.NsxtFirewallRuleContainer{UserDefinedRules: userDefinedRules})
+	if err != nil {
+		return diag.Errorf("[nsxt firewall rule create] error creating NSX-T Firewall Rule '%s': %s", newRule.Name, err)
+	}
+
+	updatedRules := updatedFirewall.NsxtFirewallRuleContainer.UserDefinedRules
+	if len(updatedRules) <= index || updatedRules[index].Name != newRule.Name {
+		return diag.Errorf("[nsxt firewall rule create] unable to find created NSX-T Firewall Rule '%s' at position %d", newRule.Name, index)
+	}
+
+	d.SetId(updatedRules[index].ID)
+
+	return resourceVcdNsxtFirewallRuleRead(ctx, d, meta)
+}
+
+func resourceVcdNsxtFirewallRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	vcdClient.lockParentEdgeGtw(d)
+	defer vcdClient.unLockParentEdgeGtw(d)
+
+	err := validateNsxtFirewallRulePosition(d)
+	if err != nil {
+		return diag.Errorf("[nsxt firewall rule update] %s", err)
+	}
+
+	nsxtEdge, firewall, err := getNsxtFirewallRuleParents(vcdClient, d)
+	if err != nil {
+		return diag.Errorf("[nsxt firewall rule update] %s", err)
+	}
+
+	updatedRule := getNsxtFirewallRuleType(d)
+	updatedRule.ID = d.Id()
+
+	userDefinedRules := firewall.NsxtFirewallRuleContainer.UserDefinedRules
+	existingIndex := findNsxtFirewallRuleIndex(userDefinedRules, d.Id())
+	if existingIndex == -1 {
+		return diag.Errorf("[nsxt firewall rule update] unable to find NSX-T Firewall Rule with ID '%s'", d.Id())
+	}
+	updatedRule.Version = userDefinedRules[existingIndex].Version
+
+	if d.HasChanges("position", "position_rule_id") {
+		// Rule must be removed from its current place and inserted at a new position
+		userDefinedRules = append(userDefinedRules[:existingIndex], userDefinedRules[existingIndex+1:]...)
+		userDefinedRules, _, err = insertNsxtFirewallRule(userDefinedRules, updatedRule,
+			d.Get("position").(string), d.Get("position_rule_id").(string))
+		if err != nil {
+			return diag.Errorf("[nsxt firewall rule update] %s", err)
+		}
+	} else {
+		userDefinedRules[existingIndex] = updatedRule
+	}
+
+	_, err = nsxtEdge.UpdateNsxtFirewall(&types.NsxtFirewallRuleContainer{UserDefinedRules: userDefinedRules})
+	if err != nil {
+		return diag.Errorf("[nsxt firewall rule update] error updating NSX-T Firewall Rule '%s': %s", updatedRule.Name, err)
+	}
+
+	return resourceVcdNsxtFirewallRuleRead(ctx, d, meta)
+}
+
+func resourceVcdNsxtFirewallRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	_, firewall, err := getNsxtFirewallRuleParents(vcdClient, d)
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[nsxt firewall rule read] %s", err)
+	}
+
+	userDefinedRules := firewall.NsxtFirewallRuleContainer.UserDefinedRules
+	ruleIndex := findNsxtFirewallRuleIndex(userDefinedRules, d.Id())
+	if ruleIndex == -1 {
+		log.Printf("[DEBUG] NSX-T Firewall Rule with ID '%s' not found. Removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	rule := userDefinedRules[ruleIndex]
+	dSet(d, "name", rule.Name)
+	dSet(d, "action", rule.Action)
+	dSet(d, "enabled", rule.Enabled)
+	dSet(d, "ip_protocol", rule.IpProtocol)
+	dSet(d, "direction", rule.Direction)
+	dSet(d, "logging", rule.Logging)
+
+	err = d.Set("source_ids", convertStringsToTypeSet(extractIdsFromOpenApiReferences(rule.SourceFirewallGroups)))
+	if err != nil {
+		return diag.Errorf("[nsxt firewall rule read] error setting 'source_ids': %s", err)
+	}
+	err = d.Set("destination_ids", convertStringsToTypeSet(extractIdsFromOpenApiReferences(rule.DestinationFirewallGroups)))
+	if err != nil {
+		return diag.Errorf("[nsxt firewall rule read] error setting 'destination_ids': %s", err)
+	}
+	err = d.Set("app_port_profile_ids", convertStringsToTypeSet(extractIdsFromOpenApiReferences(rule.ApplicationPortProfiles)))
+	if err != nil {
+		return diag.Errorf("[nsxt firewall rule read] error setting 'app_port_profile_ids': %s", err)
+	}
+
+	return nil
+}
+
+func resourceVcdNsxtFirewallRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	vcdClient.lockParentEdgeGtw(d)
+	defer vcdClient.unLockParentEdgeGtw(d)
+
+	_, firewall, err := getNsxtFirewallRuleParents(vcdClient, d)
+	if err != nil {
+		return diag.Errorf("[nsxt firewall rule delete] %s", err)
+	}
+
+	err = firewall.DeleteRuleById(d.Id())
+	if err != nil {
+		return diag.Errorf("[nsxt firewall rule delete] %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceVcdNsxtFirewallRuleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Printf("[TRACE] NSX-T Edge Gateway Firewall Rule import initiated")
+
+	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	if len(resourceURI) != 4 {
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name.firewall-rule-name")
+	}
+	orgName, vdcOrVdcGroupName, edgeName, ruleName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
+
+	vcdClient := meta.(*VCDClient)
+	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(vcdClient, orgName, vdcOrVdcGroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	edge, err := vdcOrVdcGroup.GetNsxtEdgeGatewayByName(edgeName)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve NSX-T Edge Gateway '%s': %s", edgeName, err)
+	}
+
+	firewall, err := edge.GetNsxtFirewall()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T Firewall Rules: %s", err)
+	}
+
+	// Rule names are not enforced to be unique therefore import must fail if more than one rule
+	// with the same name exists
+	var foundRules []*types.NsxtFirewallRule
+	for _, rule := range firewall.NsxtFirewallRuleContainer.UserDefinedRules {
+		if rule.Name == ruleName {
+			foundRules = append(foundRules, rule)
+		}
+	}
+
+	if len(foundRules) != 1 {
+		return nil, fmt.Errorf("expected exactly one NSX-T Firewall Rule with name '%s'. Got %d", ruleName, len(foundRules))
+	}
+
+	dSet(d, "org", orgName)
+	dSet(d, "edge_gateway_id", edge.EdgeGateway.ID)
+	dSet(d, "position", "bottom")
+	d.SetId(foundRules[0].ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// getNsxtFirewallRuleParents retrieves parent NSX-T Edge Gateway and all its Firewall Rules
+func getNsxtFirewallRuleParents(vcdClient *VCDClient, d *schema.ResourceData) (*govcd.NsxtEdgeGateway, *govcd.NsxtFirewall, error) {
+	orgName, err := vcdClient.GetOrgNameFromResource(d)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error when getting Org name: %s", err)
+	}
+
+	nsxtEdge, err := vcdClient.GetNsxtEdgeGatewayById(orgName, d.Get("edge_gateway_id").(string))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving NSX-T Edge Gateway: %s", err)
+	}
+
+	firewall, err := nsxtEdge.GetNsxtFirewall()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving NSX-T Firewall Rules: %s", err)
+	}
+
+	return nsxtEdge, firewall, nil
+}
+
+func validateNsxtFirewallRulePosition(d *schema.ResourceData) error {
+	position := d.Get("position").(string)
+	positionRuleId := d.Get("position_rule_id").(string)
+
+	if (position == "before" || position == "after") && positionRuleId == "" {
+		return fmt.Errorf("'position_rule_id' must be set when 'position' is '%s'", position)
+	}
+
+	if (position == "top" || position == "bottom") && positionRuleId != "" {
+		return fmt.Errorf("'position_rule_id' cannot be set when 'position' is '%s'", position)
+	}
+
+	return nil
+}
+
+// insertNsxtFirewallRule inserts a rule into the ordered list of rules at a given position and
+// returns the new list together with the index of inserted rule
+func insertNsxtFirewallRule(rules []*types.NsxtFirewallRule, rule *types.NsxtFirewallRule, position, positionRuleId string) ([]*types.NsxtFirewallRule, int, error) {
+	var index int
+	switch position {
+	case "top":
+		index = 0
+	case "bottom", "":
+		index = len(rules)
+	case "before", "after":
+		index = findNsxtFirewallRuleIndex(rules, positionRuleId)
+		if index == -1 {
+			return nil, -1, fmt.Errorf("unable to find NSX-T Firewall Rule with ID '%s' specified in 'position_rule_id'", positionRuleId)
+		}
+		if position == "after" {
+			index++
+		}
+	default:
+		return nil, -1, fmt.Errorf("unknown position '%s'", position)
+	}
+
+	result := make([]*types.NsxtFirewallRule, 0, len(rules)+1)
+	result = append(result, rules[:index]...)
+	result = append(result, rule)
+	result = append(result, rules[index:]...)
+
+	return result, index, nil
+}
+
+// findNsxtFirewallRuleIndex returns index of a rule with given ID or -1 if it is not found
+func findNsxtFirewallRuleIndex(rules []*types.NsxtFirewallRule, id string) int {
+	for index, rule := range rules {
+		if rule.ID == id {
+			return index
+		}
+	}
+	return -1
+}
+
+func getNsxtFirewallRuleType(d *schema.ResourceData) *types.NsxtFirewallRule {
+	rule := &types.NsxtFirewallRule{
+		Name:       d.Get("name").(string),
+		Action:     d.Get("action").(string),
+		Enabled:    d.Get("enabled").(bool),
+		IpProtocol: d.Get("ip_protocol").(string),
+		Logging:    d.Get("logging").(bool),
+		Direction:  d.Get("direction").(string),
+	}
+
+	sourceGroups := convertSchemaSetToSliceOfStrings(d.Get("source_ids").(*schema.Set))
+	rule.SourceFirewallGroups = convertSliceOfStringsToOpenApiReferenceIds(sourceGroups)
+
+	destinationGroups := convertSchemaSetToSliceOfStrings(d.Get("destination_ids").(*schema.Set))
+	rule.DestinationFirewallGroups = convertSliceOfStringsToOpenApiReferenceIds(destinationGroups)
+
+	appPortProfiles := convertSchemaSetToSliceOfStrings(d.Get("app_port_profile_ids").(*schema.Set))
+	rule.ApplicationPortProfiles = convertSliceOfStringsToOpenApiReferenceIds(appPortProfiles)
+
+	return rule
+}
@@ -0,0 +1,191 @@
+//go:build network || nsxt || ALL || functional
+// +build network nsxt ALL functional
+
+package vcd
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccVcdNsxtFirewallRule tests individual Firewall Rules and their ordering
+func TestAccVcdNsxtFirewallRule(t *testing.T) {
+	preTestChecks(t)
+
+	// String map to fill the template
+	var params = StringMap{
+		"Org":     testConfig.VCD.Org,
+		"NsxtVdc": testConfig.Nsxt.Vdc,
+		"EdgeGw":  testConfig.Nsxt.EdgeGateway,
+		"Tags":    "network nsxt",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText1 := templateFill(testAccVcdNsxtFirewallRule, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "-step2"
+	configText2 := templateFill(testAccVcdNsxtFirewallRuleStep2, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckNsxtFirewallRulesDestroy(testConfig.Nsxt.Vdc, testConfig.Nsxt.EdgeGateway),
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("vcd_nsxt_firewall_rule.bottom", "id", regexp.MustCompile(`^\S+$`)),
+					resource.TestCheckResourceAttr("vcd_nsxt_firewall_rule.bottom", "name", "bottom-rule"),
+					resource.TestCheckResourceAttr("vcd_nsxt_firewall_rule.bottom", "action", "DROP"),
+					resource.TestCheckResourceAttr("vcd_nsxt_firewall_rule.top", "name", "top-rule"),
+					resource.TestCheckResourceAttr("vcd_nsxt_firewall_rule.top", "source_ids.#", "0"),
+					resource.TestCheckResourceAttr("vcd_nsxt_firewall_rule.before-bottom", "name", "before-bottom-rule"),
+					testAccCheckNsxtFirewallRuleOrder(testConfig.Nsxt.Vdc, testConfig.Nsxt.EdgeGateway,
+						[]string{"top-rule", "before-bottom-rule", "bottom-rule"}),
+				),
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_nsxt_firewall_rule.top", "enabled", "false"),
+					resource.TestCheckResourceAttr("vcd_nsxt_firewall_rule.before-bottom", "direction", "IN_OUT"),
+					testAccCheckNsxtFirewallRuleOrder(testConfig.Nsxt.Vdc, testConfig.Nsxt.EdgeGateway,
+						[]string{"top-rule", "bottom-rule", "before-bottom-rule"}),
+				),
+			},
+			{
+				ResourceName:            "vcd_nsxt_firewall_rule.top",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       importStateIdOrgNsxtVdcObject(testConfig, testConfig.Nsxt.EdgeGateway+ImportSeparator+"top-rule"),
+				ImportStateVerifyIgnore: []string{"position", "position_rule_id"},
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdNsxtFirewallRule = testAccVcdNsxtFirewallPrereqs + `
+resource "vcd_nsxt_firewall_rule" "bottom" {
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.testing.id
+
+  name        = "bottom-rule"
+  direction   = "IN"
+  ip_protocol = "IPV4"
+  action      = "DROP"
+}
+
+resource "vcd_nsxt_firewall_rule" "top" {
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.testing.id
+  position        = "top"
+
+  name        = "top-rule"
+  direction   = "IN_OUT"
+  ip_protocol = "IPV4_IPV6"
+  action      = "ALLOW"
+
+  depends_on = [vcd_nsxt_firewall_rule.bottom]
+}
+
+resource "vcd_nsxt_firewall_rule" "before-bottom" {
+  org              = "{{.Org}}"
+  edge_gateway_id  = data.vcd_nsxt_edgegateway.testing.id
+  position         = "before"
+  position_rule_id = vcd_nsxt_firewall_rule.bottom.id
+
+  name        = "before-bottom-rule"
+  direction   = "OUT"
+  ip_protocol = "IPV6"
+  action      = "ALLOW"
+
+  depends_on = [vcd_nsxt_firewall_rule.top]
+}
+`
+
+const testAccVcdNsxtFirewallRuleStep2 = testAccVcdNsxtFirewallPrereqs + `
+resource "vcd_nsxt_firewall_rule" "bottom" {
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.testing.id
+
+  name        = "bottom-rule"
+  direction   = "IN"
+  ip_protocol = "IPV4"
+  action      = "DROP"
+}
+
+resource "vcd_nsxt_firewall_rule" "top" {
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.testing.id
+  position        = "top"
+
+  name        = "top-rule"
+  direction   = "IN_OUT"
+  ip_protocol = "IPV4_IPV6"
+  action      = "ALLOW"
+  enabled     = false
+
+  depends_on = [vcd_nsxt_firewall_rule.bottom]
+}
+
+resource "vcd_nsxt_firewall_rule" "before-bottom" {
+  org              = "{{.Org}}"
+  edge_gateway_id  = data.vcd_nsxt_edgegateway.testing.id
+  position         = "after"
+  position_rule_id = vcd_nsxt_firewall_rule.bottom.id
+
+  name        = "before-bottom-rule"
+  direction   = "IN_OUT"
+  ip_protocol = "IPV6"
+  action      = "ALLOW"
+
+  depends_on = [vcd_nsxt_firewall_rule.top]
+}
+`
+
+// testAccCheckNsxtFirewallRuleOrder checks that user defined Firewall Rules have the expected names
+// in the expected order
+func testAccCheckNsxtFirewallRuleOrder(vdcName, edgeGatewayName string, expectedNames []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*VCDClient)
+
+		_, vdc, err := conn.GetOrgAndVdc(testConfig.VCD.Org, vdcName)
+		if err != nil {
+			return fmt.Errorf(errorRetrievingVdcFromOrg, vdcName, testConfig.VCD.Org, err)
+		}
+
+		edge, err := vdc.GetNsxtEdgeGatewayByName(edgeGatewayName)
+		if err != nil {
+			return fmt.Errorf(errorUnableToFindEdgeGateway, edgeGatewayName)
+		}
+
+		fwRules, err := edge.GetNsxtFirewall()
+		if err != nil {
+			return fmt.Errorf("error retrieving NSX-T Firewall Rules for Edge Gateway '%s': %s", edgeGatewayName, err)
+		}
+
+		userDefinedRules := fwRules.NsxtFirewallRuleContainer.UserDefinedRules
+		if len(userDefinedRules) != len(expectedNames) {
+			return fmt.Errorf("expected %d firewall rules, got %d", len(expectedNames), len(userDefinedRules))
+		}
+
+		for index, rule := range userDefinedRules {
+			if rule.Name != expectedNames[index] {
+				return fmt.Errorf("expected rule '%s' at position %d, got '%s'", expectedNames[index], index, rule.Name)
+			}
+		}
+
+		return nil
+	}
+}
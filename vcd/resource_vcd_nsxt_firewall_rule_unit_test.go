@@ -0,0 +1,62 @@
+//go:build unit || ALL
+// +build unit ALL
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func TestInsertNsxtFirewallRule(t *testing.T) {
+	existingRules := func() []*types.NsxtFirewallRule {
+		return []*types.NsxtFirewallRule{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	}
+
+	tests := []struct {
+		name           string
+		position       string
+		positionRuleId string
+		expectedOrder  []string
+		expectedIndex  int
+		expectError    bool
+	}{
+		{name: "top", position: "top", expectedOrder: []string{"new", "1", "2", "3"}, expectedIndex: 0},
+		{name: "bottom", position: "bottom", expectedOrder: []string{"1", "2", "3", "new"}, expectedIndex: 3},
+		{name: "before first", position: "before", positionRuleId: "1", expectedOrder: []string{"new", "1", "2", "3"}, expectedIndex: 0},
+		{name: "before middle", position: "before", positionRuleId: "2", expectedOrder: []string{"1", "new", "2", "3"}, expectedIndex: 1},
+		{name: "after middle", position: "after", positionRuleId: "2", expectedOrder: []string{"1", "2", "new", "3"}, expectedIndex: 2},
+		{name: "after last", position: "after", positionRuleId: "3", expectedOrder: []string{"1", "2", "3", "new"}, expectedIndex: 3},
+		{name: "missing reference rule", position: "after", positionRuleId: "4", expectError: true},
+		{name: "unknown position", position: "middle", expectError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rules, index, err := insertNsxtFirewallRule(existingRules(), &types.NsxtFirewallRule{ID: "new"}, test.position, test.positionRuleId)
+			if test.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if index != test.expectedIndex {
+				t.Errorf("expected index %d, got %d", test.expectedIndex, index)
+			}
+
+			if len(rules) != len(test.expectedOrder) {
+				t.Fatalf("expected %d rules, got %d", len(test.expectedOrder), len(rules))
+			}
+			for i, rule := range rules {
+				if rule.ID != test.expectedOrder[i] {
+					t.Errorf("expected rule '%s' at position %d, got '%s'", test.expectedOrder[i], i, rule.ID)
+				}
+			}
+		})
+	}
+}
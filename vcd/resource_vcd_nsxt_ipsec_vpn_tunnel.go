@@ -65,11 +65,28 @@ func resourceVcdNsxtIpSecVpnTunnel() *schema.Resource {
 				Optional:    true,
 				Description: "Description IP Sec VPN Tunnel",
 			},
+			"authentication_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "PSK",
+				Description:  "Authentication mode - 'PSK' or 'CERTIFICATE' (VCD 10.4.0+). Default 'PSK'",
+				ValidateFunc: validation.StringInSlice([]string{"PSK", "CERTIFICATE"}, false),
+			},
 			"pre_shared_key": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "Pre-Shared Key (PSK)",
+				Description: "Pre-Shared Key (PSK). Required when 'authentication_mode' is 'PSK'",
+			},
+			"certificate_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Library certificate ID with a private key used to authenticate local endpoint. Required when 'authentication_mode' is 'CERTIFICATE'",
+			},
+			"ca_certificate_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Library CA certificate ID used to verify remote endpoint. Required when 'authentication_mode' is 'CERTIFICATE'",
 			},
 			"local_ip_address": {
 				Type:        schema.TypeString,
@@ -78,9 +95,9 @@ func resourceVcdNsxtIpSecVpnTunnel() *schema.Resource {
 			},
 			"local_networks": {
 				Type:        schema.TypeSet,
-				Required:    true,
+				Optional:    true,
 				MinItems:    1,
-				Description: "Set of local networks in CIDR format. At least one value is required",
+				Description: "Set of local networks in CIDR format. At least one value is required for 'POLICY_BASED' tunnels",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -98,6 +115,19 @@ func resourceVcdNsxtIpSecVpnTunnel() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "POLICY_BASED",
+				Description:  "Type of IPsec VPN Tunnel - 'POLICY_BASED' or 'ROUTE_BASED' (VCD 10.5.0+). Default 'POLICY_BASED'",
+				ValidateFunc: validation.StringInSlice([]string{"POLICY_BASED", "ROUTE_BASED"}, false),
+			},
+			"vti_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Virtual Tunnel Interface (VTI) address in CIDR format. Required for 'ROUTE_BASED' tunnels",
+			},
 			"logging": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -246,20 +276,34 @@ func resourceVcdNsxtIpSecVpnTunnelCreate(ctx context.Context, d *schema.Resource
 		return diag.Errorf("error retrieving Edge Gateway: %s", err)
 	}
 
-	ipSecVpnConfig, err := getNsxtIpSecVpnTunnelType(d)
+	ipSecVpnConfig, err := getNsxtIpSecVpnTunnelExtendedType(vcdClient, d)
 	if err != nil {
 		return diag.Errorf("error getting NSX-T IPsec VPN Tunnel configuration type: %s", err)
 	}
 
-	createdIpSecVpnConfig, err := nsxtEdge.CreateIpSecVpnTunnel(ipSecVpnConfig)
-	if err != nil {
-		return diag.Errorf("error creating NSX-T IPsec VPN Tunnel configuration: %s", err)
+	// Certificate authentication and route based tunnels are not supported by SDK yet
+	if ipSecVpnConfig.usesExtendedFeatures() {
+		createdId, err := createNsxtIpSecVpnTunnel(&vcdClient.Client, nsxtEdge.EdgeGateway.ID, ipSecVpnConfig)
+		if err != nil {
+			return diag.Errorf("error creating NSX-T IPsec VPN Tunnel configuration: %s", err)
+		}
+		d.SetId(createdId)
+	} else {
+		createdIpSecVpnConfig, err := nsxtEdge.CreateIpSecVpnTunnel(&ipSecVpnConfig.NsxtIpSecVpnTunnel)
+		if err != nil {
+			return diag.Errorf("error creating NSX-T IPsec VPN Tunnel configuration: %s", err)
+		}
+		d.SetId(createdIpSecVpnConfig.NsxtIpSecVpn.ID)
 	}
-	// IPSec VPN Tunnel is already created - storing ID
-	d.SetId(createdIpSecVpnConfig.NsxtIpSecVpn.ID)
+	// IPSec VPN Tunnel is already created - ID is stored
 
 	// Check if Tunnel Profile has custom settings and apply them
 	if _, isSet := d.GetOk("security_profile_customization"); isSet {
+		createdIpSecVpnConfig, err := nsxtEdge.GetIpSecVpnTunnelById(d.Id())
+		if err != nil {
+			return diag.Errorf("error retrieving created NSX-T IPsec VPN Tunnel configuration: %s", err)
+		}
+
 		tunnelProfileConfig, err := getNsxtIpSecVpnProfileTunnelConfigurationType(d)
 		if err != nil {
 			return diag.Errorf("error getting NSX-T IPsec VPN Tunnel Profile: %s", err)
@@ -290,10 +334,10 @@ func resourceVcdNsxtIpSecVpnTunnelUpdate(ctx context.Context, d *schema.Resource
 
 	existingIpSecVpnConfiguration, err := nsxtEdge.GetIpSecVpnTunnelById(d.Id())
 	if err != nil {
-		diag.Errorf("error retrieving existing NSX-T IPsec VPN Tunnel configuration: %s", err)
+		return diag.Errorf("error retrieving existing NSX-T IPsec VPN Tunnel configuration: %s", err)
 	}
 
-	ipSecVpnConfig, err := getNsxtIpSecVpnTunnelType(d)
+	ipSecVpnConfig, err := getNsxtIpSecVpnTunnelExtendedType(vcdClient, d)
 	if err != nil {
 		return diag.Errorf("error getting NSX-T IPsec VPN Tunnel configuration type: %s", err)
 	}
@@ -314,7 +358,14 @@ func resourceVcdNsxtIpSecVpnTunnelUpdate(ctx context.Context, d *schema.Resource
 
 	// At first update IPsec VPN tunnel configuration
 	// It will reset Security Profile to DEFAULT at the same shot if no customization exists in 'security_profile_customization'
-	updatedIpSecVpnConfiguration, err := existingIpSecVpnConfiguration.Update(ipSecVpnConfig)
+	// SDK updates the tunnel using API version 34.0 which has no 'authenticationMode'. VCD 10.4.0+
+	// tunnels are always updated directly so that switching from CERTIFICATE back to PSK is sent
+	updatedIpSecVpnConfiguration := existingIpSecVpnConfiguration
+	if vcdClient.Client.APIVCDMaxVersionIs(">= " + nsxtIpSecVpnTunnelCertificateMinApiVersion) {
+		err = updateNsxtIpSecVpnTunnel(&vcdClient.Client, edgeGatewayId, ipSecVpnConfig)
+	} else {
+		updatedIpSecVpnConfiguration, err = existingIpSecVpnConfiguration.Update(&ipSecVpnConfig.NsxtIpSecVpnTunnel)
+	}
 	if err != nil {
 		return diag.Errorf("error updating NSX-T IPsec VPN Tunnel configuration '%s': %s", ipSecVpnConfig.Name, err)
 	}
@@ -350,7 +401,7 @@ func resourceVcdNsxtIpSecVpnTunnelRead(ctx context.Context, d *schema.ResourceDa
 		return diag.Errorf("error retrieving Edge Gateway: %s", err)
 	}
 
-	ipSecVpnConfig, err := nsxtEdge.GetIpSecVpnTunnelById(d.Id())
+	ipSecVpnConfig, err := getNsxtIpSecVpnTunnelById(&vcdClient.Client, nsxtEdge.EdgeGateway.ID, d.Id())
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
 			d.SetId("")
@@ -358,14 +409,24 @@ func resourceVcdNsxtIpSecVpnTunnelRead(ctx context.Context, d *schema.ResourceDa
 		return diag.Errorf("error retrieving NSX-T IPsec VPN Tunnel configuration: %s", err)
 	}
 
+	return readNsxtIpSecVpnTunnel(&vcdClient.Client, d, nsxtEdge.EdgeGateway.ID, ipSecVpnConfig)
+}
+
+// readNsxtIpSecVpnTunnel stores IPsec VPN Tunnel configuration together with its Security
+// Customization and status in schema. Security Customization and status are retrieved directly as
+// well, because SDK methods for them require a tunnel retrieved by SDK.
+func readNsxtIpSecVpnTunnel(client *govcd.Client, d *schema.ResourceData, edgeGatewayId string, ipSecVpnConfig *nsxtIpSecVpnTunnel) diag.Diagnostics {
 	// Set general schema for configuration
-	err = setNsxtIpSecVpnTunnelData(d, ipSecVpnConfig.NsxtIpSecVpn)
+	err := setNsxtIpSecVpnTunnelData(d, &ipSecVpnConfig.NsxtIpSecVpnTunnel)
 	if err != nil {
 		return diag.Errorf("error storing NSX-T IPsec VPN Tunnel configuration to schema: %s", err)
 	}
+	setNsxtIpSecVpnTunnelExtendedData(d, ipSecVpnConfig)
 
 	// Tunnel Security Properties
-	tunnelConnectionProperties, err := ipSecVpnConfig.GetTunnelConnectionProperties()
+	tunnelConnectionProperties := &types.NsxtIpSecVpnTunnelSecurityProfile{}
+	err = openApiGetExtendedItem(client, nsxtIpSecVpnTunnelApiVersions, tunnelConnectionProperties, types.OpenApiPathVersion1_0_0,
+		fmt.Sprintf(types.OpenApiEndpointIpSecVpnTunnelConnectionProperties, edgeGatewayId, ipSecVpnConfig.ID))
 	if err != nil {
 		return diag.Errorf("error reading NSX-T IPsec VPN Tunnel Security Customization: %s", err)
 	}
@@ -376,7 +437,9 @@ func resourceVcdNsxtIpSecVpnTunnelRead(ctx context.Context, d *schema.ResourceDa
 	}
 
 	// Read tunnel status data from separate endpoint
-	tunnelStatus, err := ipSecVpnConfig.GetStatus()
+	tunnelStatus := &types.NsxtIpSecVpnTunnelStatus{}
+	err = openApiGetExtendedItem(client, nsxtIpSecVpnTunnelApiVersions, tunnelStatus, types.OpenApiPathVersion1_0_0,
+		fmt.Sprintf(types.OpenApiEndpointIpSecVpnTunnelStatus, edgeGatewayId, ipSecVpnConfig.ID))
 	if err != nil {
 		return diag.Errorf("error reading NSX-T IPsec VPN Tunnel status: %s", err)
 	}
@@ -487,6 +550,224 @@ func getNsxtIpSecVpnTunnelType(d *schema.ResourceData) (*types.NsxtIpSecVpnTunne
 	return ipSecVpnConfig, nil
 }
 
+// nsxtIpSecVpnTunnelMinApiVersion is the first API version which supports IPsec VPN Tunnels (VCD
+// 10.1.0)
+const nsxtIpSecVpnTunnelMinApiVersion = "34.0"
+
+// nsxtIpSecVpnTunnelCertificateMinApiVersion is the first API version which supports certificate
+// authentication for IPsec VPN Tunnels (VCD 10.4.0)
+const nsxtIpSecVpnTunnelCertificateMinApiVersion = "37.0"
+
+// nsxtIpSecVpnTunnelRouteBasedMinApiVersion is the first API version which supports route based
+// IPsec VPN Tunnels (VCD 10.5.0)
+const nsxtIpSecVpnTunnelRouteBasedMinApiVersion = "38.0"
+
+// nsxtIpSecVpnTunnelApiVersions lists API versions used for nsxtIpSecVpnTunnel type
+var nsxtIpSecVpnTunnelApiVersions = []string{
+	nsxtIpSecVpnTunnelMinApiVersion,
+	nsxtIpSecVpnTunnelCertificateMinApiVersion,
+	nsxtIpSecVpnTunnelRouteBasedMinApiVersion,
+}
+
+// nsxtIpSecVpnTunnel extends types.NsxtIpSecVpnTunnel with certificate authentication and route
+// based tunnel fields
+type nsxtIpSecVpnTunnel struct {
+	types.NsxtIpSecVpnTunnel
+	// CertificateRef is a library certificate with private key for CERTIFICATE authentication mode
+	CertificateRef *types.OpenApiReference `json:"certificateRef,omitempty"`
+	// CaCertificateRef is a library CA certificate to verify remote endpoint in CERTIFICATE
+	// authentication mode
+	CaCertificateRef *types.OpenApiReference `json:"caCertificateRef,omitempty"`
+	// Type is POLICY_BASED or ROUTE_BASED. Empty value means POLICY_BASED
+	Type string `json:"type,omitempty"`
+	// VtiAddress is Virtual Tunnel Interface address in CIDR format for ROUTE_BASED tunnels
+	VtiAddress string `json:"vtiAddress,omitempty"`
+}
+
+// usesExtendedFeatures returns true if the tunnel cannot be handled by SDK types
+func (tunnel *nsxtIpSecVpnTunnel) usesExtendedFeatures() bool {
+	return tunnel.AuthenticationMode == "CERTIFICATE" || tunnel.Type == "ROUTE_BASED"
+}
+
+func getNsxtIpSecVpnTunnelExtendedType(vcdClient *VCDClient, d *schema.ResourceData) (*nsxtIpSecVpnTunnel, error) {
+	baseConfig, err := getNsxtIpSecVpnTunnelType(d)
+	if err != nil {
+		return nil, err
+	}
+
+	ipSecVpnConfig := &nsxtIpSecVpnTunnel{NsxtIpSecVpnTunnel: *baseConfig}
+
+	authenticationMode := d.Get("authentication_mode").(string)
+	certificateId := d.Get("certificate_id").(string)
+	caCertificateId := d.Get("ca_certificate_id").(string)
+	switch authenticationMode {
+	case "PSK":
+		if ipSecVpnConfig.PreSharedKey == "" {
+			return nil, fmt.Errorf("'pre_shared_key' is required when 'authentication_mode' is 'PSK'")
+		}
+		if certificateId != "" || caCertificateId != "" {
+			return nil, fmt.Errorf("'certificate_id' and 'ca_certificate_id' can only be set when 'authentication_mode' is 'CERTIFICATE'")
+		}
+		// Authentication mode is only sent for VCD versions which support more than PSK
+		if vcdClient.Client.APIVCDMaxVersionIs(">= " + nsxtIpSecVpnTunnelCertificateMinApiVersion) {
+			ipSecVpnConfig.AuthenticationMode = authenticationMode
+		}
+	case "CERTIFICATE":
+		if vcdClient.Client.APIVCDMaxVersionIs("< " + nsxtIpSecVpnTunnelCertificateMinApiVersion) {
+			return nil, fmt.Errorf("'authentication_mode' CERTIFICATE requires VCD 10.4.0+")
+		}
+		if certificateId == "" || caCertificateId == "" {
+			return nil, fmt.Errorf("'certificate_id' and 'ca_certificate_id' are required when 'authentication_mode' is 'CERTIFICATE'")
+		}
+		if ipSecVpnConfig.PreSharedKey != "" {
+			return nil, fmt.Errorf("'pre_shared_key' cannot be set when 'authentication_mode' is 'CERTIFICATE'")
+		}
+		ipSecVpnConfig.AuthenticationMode = authenticationMode
+		ipSecVpnConfig.CertificateRef = &types.OpenApiReference{ID: certificateId}
+		ipSecVpnConfig.CaCertificateRef = &types.OpenApiReference{ID: caCertificateId}
+	}
+
+	vtiAddress := d.Get("vti_address").(string)
+	switch d.Get("type").(string) {
+	case "POLICY_BASED":
+		if len(ipSecVpnConfig.LocalEndpoint.LocalNetworks) == 0 {
+			return nil, fmt.Errorf("at least one 'local_networks' value is required for 'POLICY_BASED' tunnels")
+		}
+		if vtiAddress != "" {
+			return nil, fmt.Errorf("'vti_address' can only be set for 'ROUTE_BASED' tunnels")
+		}
+	case "ROUTE_BASED":
+		if vcdClient.Client.APIVCDMaxVersionIs("< " + nsxtIpSecVpnTunnelRouteBasedMinApiVersion) {
+			return nil, fmt.Errorf("'type' ROUTE_BASED requires VCD 10.5.0+")
+		}
+		if vtiAddress == "" {
+			return nil, fmt.Errorf("'vti_address' is required for 'ROUTE_BASED' tunnels")
+		}
+		if len(ipSecVpnConfig.LocalEndpoint.LocalNetworks) > 0 || len(ipSecVpnConfig.RemoteEndpoint.RemoteNetworks) > 0 {
+			return nil, fmt.Errorf("'local_networks' and 'remote_networks' cannot be set for 'ROUTE_BASED' tunnels")
+		}
+		ipSecVpnConfig.Type = "ROUTE_BASED"
+		ipSecVpnConfig.VtiAddress = vtiAddress
+	}
+
+	return ipSecVpnConfig, nil
+}
+
+// setNsxtIpSecVpnTunnelExtendedData stores authentication and tunnel type fields. Older VCD
+// versions do not return them as they only support 'PSK' authentication and 'POLICY_BASED' tunnels.
+func setNsxtIpSecVpnTunnelExtendedData(d *schema.ResourceData, ipSecVpnConfig *nsxtIpSecVpnTunnel) {
+	authenticationMode := ipSecVpnConfig.AuthenticationMode
+	if authenticationMode == "" {
+		authenticationMode = "PSK"
+	}
+	dSet(d, "authentication_mode", authenticationMode)
+
+	certificateId, caCertificateId := "", ""
+	if ipSecVpnConfig.CertificateRef != nil {
+		certificateId = ipSecVpnConfig.CertificateRef.ID
+	}
+	if ipSecVpnConfig.CaCertificateRef != nil {
+		caCertificateId = ipSecVpnConfig.CaCertificateRef.ID
+	}
+	dSet(d, "certificate_id", certificateId)
+	dSet(d, "ca_certificate_id", caCertificateId)
+
+	tunnelType := ipSecVpnConfig.Type
+	if tunnelType == "" {
+		tunnelType = "POLICY_BASED"
+	}
+	dSet(d, "type", tunnelType)
+	dSet(d, "vti_address", ipSecVpnConfig.VtiAddress)
+}
+
+func getNsxtIpSecVpnTunnelById(client *govcd.Client, edgeGatewayId, id string) (*nsxtIpSecVpnTunnel, error) {
+	ipSecVpnConfig := &nsxtIpSecVpnTunnel{}
+	err := openApiGetExtendedItem(client, nsxtIpSecVpnTunnelApiVersions, ipSecVpnConfig,
+		types.OpenApiPathVersion1_0_0, fmt.Sprintf(types.OpenApiEndpointIpSecVpnTunnel, edgeGatewayId), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ipSecVpnConfig, nil
+}
+
+// getNsxtIpSecVpnTunnelByName looks up IPsec VPN Tunnel by name and retrieves it by ID, because
+// only the latter includes Pre-Shared Key
+func getNsxtIpSecVpnTunnelByName(client *govcd.Client, edgeGatewayId, name string) (*nsxtIpSecVpnTunnel, error) {
+	allTunnels, err := getAllNsxtIpSecVpnTunnels(client, edgeGatewayId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving all NSX-T IPsec VPN Tunnel configurations: %s", err)
+	}
+
+	var foundTunnels []*nsxtIpSecVpnTunnel
+	for _, tunnel := range allTunnels {
+		if tunnel.Name == name {
+			foundTunnels = append(foundTunnels, tunnel)
+		}
+	}
+
+	if len(foundTunnels) > 1 {
+		return nil, fmt.Errorf("found %d NSX-T IPsec VPN Tunnel configurations with name '%s'. Expected 1",
+			len(foundTunnels), name)
+	}
+
+	if len(foundTunnels) == 0 {
+		return nil, govcd.ErrorEntityNotFound
+	}
+
+	return getNsxtIpSecVpnTunnelById(client, edgeGatewayId, foundTunnels[0].ID)
+}
+
+func getAllNsxtIpSecVpnTunnels(client *govcd.Client, edgeGatewayId string) ([]*nsxtIpSecVpnTunnel, error) {
+	allTunnels := []*nsxtIpSecVpnTunnel{{}}
+	err := openApiGetAllExtendedItems(client, nsxtIpSecVpnTunnelApiVersions, nil, &allTunnels,
+		types.OpenApiPathVersion1_0_0, fmt.Sprintf(types.OpenApiEndpointIpSecVpnTunnel, edgeGatewayId))
+	if err != nil {
+		return nil, err
+	}
+
+	return allTunnels, nil
+}
+
+// createNsxtIpSecVpnTunnel creates IPsec VPN Tunnel and returns its ID. The API does not return
+// created tunnel, therefore the only tunnel which did not exist before creation is looked up. It
+// is safe because the parent Edge Gateway is locked.
+func createNsxtIpSecVpnTunnel(client *govcd.Client, edgeGatewayId string, ipSecVpnConfig *nsxtIpSecVpnTunnel) (string, error) {
+	existingTunnels, err := getAllNsxtIpSecVpnTunnels(client, edgeGatewayId)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving existing NSX-T IPsec VPN Tunnels: %s", err)
+	}
+	existingIds := make(map[string]bool, len(existingTunnels))
+	for _, tunnel := range existingTunnels {
+		existingIds[tunnel.ID] = true
+	}
+
+	err = openApiPostExtendedItemAsync(client, nsxtIpSecVpnTunnelApiVersions, ipSecVpnConfig,
+		types.OpenApiPathVersion1_0_0, fmt.Sprintf(types.OpenApiEndpointIpSecVpnTunnel, edgeGatewayId))
+	if err != nil {
+		return "", err
+	}
+
+	allTunnels, err := getAllNsxtIpSecVpnTunnels(client, edgeGatewayId)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving NSX-T IPsec VPN Tunnels after creation: %s", err)
+	}
+
+	for _, tunnel := range allTunnels {
+		if !existingIds[tunnel.ID] && tunnel.Name == ipSecVpnConfig.Name {
+			return tunnel.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("error finding NSX-T IPsec VPN Tunnel '%s' after creation: %s", ipSecVpnConfig.Name, govcd.ErrorEntityNotFound)
+}
+
+func updateNsxtIpSecVpnTunnel(client *govcd.Client, edgeGatewayId string, ipSecVpnConfig *nsxtIpSecVpnTunnel) error {
+	updatedConfig := &nsxtIpSecVpnTunnel{}
+	return openApiPutExtendedItem(client, nsxtIpSecVpnTunnelApiVersions, ipSecVpnConfig, updatedConfig,
+		types.OpenApiPathVersion1_0_0, fmt.Sprintf(types.OpenApiEndpointIpSecVpnTunnel, edgeGatewayId), ipSecVpnConfig.ID)
+}
+
 func setNsxtIpSecVpnTunnelData(d *schema.ResourceData, ipSecVpnConfig *types.NsxtIpSecVpnTunnel) error {
 	dSet(d, "name", ipSecVpnConfig.Name)
 	dSet(d, "description", ipSecVpnConfig.Description)
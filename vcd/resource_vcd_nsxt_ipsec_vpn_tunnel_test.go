@@ -653,3 +653,183 @@ func testAccCheckNsxtIpSecVpnTunnelDestroy(ipSecVpnTunnelIdentifier string) reso
 		return nil
 	}
 }
+
+// TestAccVcdNsxtIpSecVpnTunnelCertificateAuth tests IPsec VPN Tunnel with CERTIFICATE authentication
+// mode using library certificates (VCD 10.4.0+) and switching it to PSK and back
+func TestAccVcdNsxtIpSecVpnTunnelCertificateAuth(t *testing.T) {
+	preTestChecks(t)
+
+	vcdClient := createTemporaryVCDConnection(false)
+	if vcdClient.Client.APIVCDMaxVersionIs("< 37.0") {
+		t.Skipf("This test tests VCD 10.4.0+ (API V37.0+) features. Skipping.")
+	}
+
+	if testConfig.Certificates.Certificate1Path == "" || testConfig.Certificates.Certificate2Path == "" ||
+		testConfig.Certificates.Certificate1PrivateKeyPath == "" || testConfig.Certificates.Certificate1Pass == "" {
+		t.Skip("Variables Certificates.Certificate1Path, Certificates.Certificate2Path, " +
+			"Certificates.Certificate1PrivateKeyPath, Certificates.Certificate1Pass must be set")
+	}
+
+	// String map to fill the template
+	var params = StringMap{
+		"Org":              testConfig.VCD.Org,
+		"NsxtVdc":          testConfig.Nsxt.Vdc,
+		"EdgeGw":           testConfig.Nsxt.EdgeGateway,
+		"NetworkName":      t.Name(),
+		"Alias":            t.Name(),
+		"Certificate1Path": testConfig.Certificates.Certificate1Path,
+		"Certificate2Path": testConfig.Certificates.Certificate2Path,
+		"CertPrivateKey1":  testConfig.Certificates.Certificate1PrivateKeyPath,
+		"CertPassPhrase1":  testConfig.Certificates.Certificate1Pass,
+		"ResourceName":     "test-tunnel-cert",
+		"Tags":             "network nsxt",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText1 := templateFill(testAccNsxtIpSecVpnTunnelCertificate, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "-step2"
+	configText2 := templateFill(testAccNsxtIpSecVpnTunnelCertificateDS, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	params["FuncName"] = t.Name() + "-step3"
+	configText3 := templateFill(testAccNsxtIpSecVpnTunnelCertificateToPsk, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 3: %s", configText3)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	ignoreDataSourceFields := []string{"status", "ike_service_status", "ike_fail_reason"}
+
+	checkCertificateAuth := resource.ComposeAggregateTestCheckFunc(
+		resource.TestCheckResourceAttrSet("vcd_nsxt_ipsec_vpn_tunnel.tunnel1", "id"),
+		resource.TestCheckResourceAttr("vcd_nsxt_ipsec_vpn_tunnel.tunnel1", "authentication_mode", "CERTIFICATE"),
+		resource.TestCheckResourceAttr("vcd_nsxt_ipsec_vpn_tunnel.tunnel1", "type", "POLICY_BASED"),
+		resource.TestCheckResourceAttr("vcd_nsxt_ipsec_vpn_tunnel.tunnel1", "pre_shared_key", ""),
+		resource.TestCheckResourceAttrPair("vcd_nsxt_ipsec_vpn_tunnel.tunnel1", "certificate_id", "vcd_library_certificate.cert", "id"),
+		resource.TestCheckResourceAttrPair("vcd_nsxt_ipsec_vpn_tunnel.tunnel1", "ca_certificate_id", "vcd_library_certificate.ca-cert", "id"),
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckNsxtIpSecVpnTunnelDestroy("test-tunnel-cert"),
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check:  checkCertificateAuth,
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resourceFieldsEqual("vcd_nsxt_ipsec_vpn_tunnel.tunnel1", "data.vcd_nsxt_ipsec_vpn_tunnel.tunnel1", ignoreDataSourceFields),
+				),
+			},
+			// Switch to PSK authentication. The plan must be empty after apply, which means that
+			// certificates were removed in VCD
+			{
+				Config: configText3,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_nsxt_ipsec_vpn_tunnel.tunnel1", "authentication_mode", "PSK"),
+					resource.TestCheckResourceAttr("vcd_nsxt_ipsec_vpn_tunnel.tunnel1", "pre_shared_key", "test-psk-key"),
+					resource.TestCheckResourceAttr("vcd_nsxt_ipsec_vpn_tunnel.tunnel1", "certificate_id", ""),
+					resource.TestCheckResourceAttr("vcd_nsxt_ipsec_vpn_tunnel.tunnel1", "ca_certificate_id", ""),
+				),
+			},
+			// Switch back to CERTIFICATE authentication
+			{
+				Config: configText1,
+				Check:  checkCertificateAuth,
+			},
+			{
+				ResourceName:            "vcd_nsxt_ipsec_vpn_tunnel.tunnel1",
+				ImportState:             true,
+				ImportStateId:           testConfig.VCD.Org + ImportSeparator + testConfig.Nsxt.Vdc + ImportSeparator + testConfig.Nsxt.EdgeGateway + ImportSeparator + "test-tunnel-cert",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"vdc"},
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccNsxtIpSecVpnTunnelCertificate = testAccNsxtIpSetPrereqs + `
+resource "vcd_library_certificate" "cert" {
+  org                    = "{{.Org}}"
+  alias                  = "{{.Alias}}-cert"
+  certificate            = file("{{.Certificate1Path}}")
+  private_key            = file("{{.CertPrivateKey1}}")
+  private_key_passphrase = "{{.CertPassPhrase1}}"
+}
+
+resource "vcd_library_certificate" "ca-cert" {
+  org         = "{{.Org}}"
+  alias       = "{{.Alias}}-ca-cert"
+  certificate = file("{{.Certificate2Path}}")
+}
+
+resource "vcd_nsxt_ipsec_vpn_tunnel" "tunnel1" {
+  org = "{{.Org}}"
+
+  edge_gateway_id = data.vcd_nsxt_edgegateway.existing_gw.id
+
+  name = "test-tunnel-cert"
+
+  authentication_mode = "CERTIFICATE"
+  certificate_id      = vcd_library_certificate.cert.id
+  ca_certificate_id   = vcd_library_certificate.ca-cert.id
+
+  # Primary IP address of Edge Gateway
+  local_ip_address  = tolist(data.vcd_nsxt_edgegateway.existing_gw.subnet)[0].primary_ip
+  local_networks    = ["10.10.10.0/24"]
+  # That is a fake remote IP address
+  remote_ip_address = "1.2.3.4"
+  remote_networks   = ["192.168.1.0/24"]
+}
+`
+
+const testAccNsxtIpSecVpnTunnelCertificateToPsk = testAccNsxtIpSetPrereqs + `
+resource "vcd_library_certificate" "cert" {
+  org                    = "{{.Org}}"
+  alias                  = "{{.Alias}}-cert"
+  certificate            = file("{{.Certificate1Path}}")
+  private_key            = file("{{.CertPrivateKey1}}")
+  private_key_passphrase = "{{.CertPassPhrase1}}"
+}
+
+resource "vcd_library_certificate" "ca-cert" {
+  org         = "{{.Org}}"
+  alias       = "{{.Alias}}-ca-cert"
+  certificate = file("{{.Certificate2Path}}")
+}
+
+resource "vcd_nsxt_ipsec_vpn_tunnel" "tunnel1" {
+  org = "{{.Org}}"
+
+  edge_gateway_id = data.vcd_nsxt_edgegateway.existing_gw.id
+
+  name = "test-tunnel-cert"
+
+  authentication_mode = "PSK"
+  pre_shared_key      = "test-psk-key"
+
+  # Primary IP address of Edge Gateway
+  local_ip_address  = tolist(data.vcd_nsxt_edgegateway.existing_gw.subnet)[0].primary_ip
+  local_networks    = ["10.10.10.0/24"]
+  # That is a fake remote IP address
+  remote_ip_address = "1.2.3.4"
+  remote_networks   = ["192.168.1.0/24"]
+}
+`
+
+const testAccNsxtIpSecVpnTunnelCertificateDS = testAccNsxtIpSecVpnTunnelCertificate + `
+# skip-binary-test: Data Source test
+data "vcd_nsxt_ipsec_vpn_tunnel" "tunnel1" {
+  org = "{{.Org}}"
+
+  edge_gateway_id = data.vcd_nsxt_edgegateway.existing_gw.id
+  name            = "{{.ResourceName}}"
+}
+`
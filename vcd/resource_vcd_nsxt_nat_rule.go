@@ -25,6 +25,7 @@ func resourceVcdNsxtNatRule() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdNsxtNatRuleImport,
 		},
+		CustomizeDiff: resourceVcdNsxtNatRuleCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"org": {
@@ -114,10 +115,64 @@ func resourceVcdNsxtNatRule() *schema.Resource {
 				Computed:    true,
 				Description: "VCD 10.2.2+ If an address has multiple NAT rules, the rule with the highest priority is applied. A lower value means a higher precedence for this rule.",
 			},
+			"applied_to": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "VCD 10.3+ Org VDC network ID to which the rule applies. Empty value applies the rule to all networks",
+			},
 		},
 	}
 }
 
+// nsxtNatRuleAppliedToMinApiVersion is the first API version which supports 'appliedTo' field in
+// NAT rules (VCD 10.3.0)
+const nsxtNatRuleAppliedToMinApiVersion = "36.0"
+
+// nsxtNatRuleApiVersions lists API versions used for nsxtExtendedNatRule type. VCD 10.2.2 (API
+// 35.2) introduced 'firewallMatch' and 'priority' fields.
+var nsxtNatRuleApiVersions = []string{"34.0", "35.2", nsxtNatRuleAppliedToMinApiVersion}
+
+// nsxtExtendedNatRule extends types.NsxtNatRule with 'appliedTo' field
+type nsxtExtendedNatRule struct {
+	types.NsxtNatRule
+	// AppliedTo scopes the rule to a particular Org VDC network. Nil value applies the rule to all
+	// networks
+	AppliedTo *types.OpenApiReference `json:"appliedTo,omitempty"`
+}
+
+// resourceVcdNsxtNatRuleCustomizeDiff fails plan when a field is not supported by target VCD so
+// that the error is not postponed until apply (API errors are opaque in such cases)
+func resourceVcdNsxtNatRuleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	_, firewallMatchOk := d.GetOk("firewall_match")
+	_, priorityOk := d.GetOk("priority")
+	_, appliedToOk := d.GetOk("applied_to")
+	// Value referencing a resource which is not created yet is unknown during plan
+	appliedToOk = appliedToOk || !d.NewValueKnown("applied_to")
+
+	return validateNsxtNatRuleVersion(vcdClient, d.Get("rule_type").(string), firewallMatchOk, priorityOk, appliedToOk)
+}
+
+// validateNsxtNatRuleVersion checks if rule type and optional fields are supported by VCD
+func validateNsxtNatRuleVersion(client *VCDClient, ruleType string, firewallMatchSet, prioritySet, appliedToSet bool) error {
+	// REFLEXIVE rule_type is only supported in VCD 10.3+
+	if ruleType == types.NsxtNatRuleTypeReflexive && client.Client.APIVCDMaxVersionIs("< 36.0") {
+		return fmt.Errorf("rule_type 'REFLEXIVE' can only be used for VCD 10.3+")
+	}
+
+	// Only supported in VCD 10.2.2+ (API V35.2)
+	if (firewallMatchSet || prioritySet) && client.Client.APIVCDMaxVersionIs("< 35.2") {
+		return fmt.Errorf("firewall_match and priority fields can only be set for VCD 10.2.2+")
+	}
+
+	if appliedToSet && client.Client.APIVCDMaxVersionIs("< "+nsxtNatRuleAppliedToMinApiVersion) {
+		return fmt.Errorf("applied_to field can only be set for VCD 10.3+")
+	}
+
+	return nil
+}
+
 func resourceVcdNsxtNatRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
@@ -136,6 +191,17 @@ func resourceVcdNsxtNatRuleCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.Errorf("error getting NSX-T NAT rule type: %s", err)
 	}
 
+	// SDK does not know 'appliedTo' field therefore such rules are created directly
+	if appliedTo := d.Get("applied_to").(string); appliedTo != "" {
+		ruleId, err := createNsxtNatRule(&vcdClient.Client, nsxtEdge.EdgeGateway.ID,
+			&nsxtExtendedNatRule{NsxtNatRule: *nsxtNatRule, AppliedTo: &types.OpenApiReference{ID: appliedTo}})
+		if err != nil {
+			return diag.Errorf("error creating NSX-T NAT rule: %s", err)
+		}
+		d.SetId(ruleId)
+		return resourceVcdNsxtNatRuleRead(ctx, d, meta)
+	}
+
 	rule, err := nsxtEdge.CreateNatRule(nsxtNatRule)
 	if err != nil {
 
@@ -171,6 +237,20 @@ func resourceVcdNsxtNatRuleUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	// Inject ID for update
 	nsxtNatRule.ID = existingRule.NsxtNatRule.ID
+
+	// Updating a rule using SDK types would remove 'appliedTo' field in VCD versions that support it
+	if vcdClient.Client.APIVCDMaxVersionIs(">= " + nsxtNatRuleAppliedToMinApiVersion) {
+		extendedRule := &nsxtExtendedNatRule{NsxtNatRule: *nsxtNatRule}
+		if appliedTo := d.Get("applied_to").(string); appliedTo != "" {
+			extendedRule.AppliedTo = &types.OpenApiReference{ID: appliedTo}
+		}
+		err = updateNsxtNatRule(&vcdClient.Client, nsxtEdge.EdgeGateway.ID, extendedRule)
+		if err != nil {
+			return diag.Errorf("error updating NSX-T NAT rule: %s", err)
+		}
+		return resourceVcdNsxtNatRuleRead(ctx, d, meta)
+	}
+
 	_, err = existingRule.Update(nsxtNatRule)
 	if err != nil {
 		return diag.Errorf("error updating NSX-T NAT rule: %s", err)
@@ -190,7 +270,7 @@ func resourceVcdNsxtNatRuleRead(ctx context.Context, d *schema.ResourceData, met
 		return diag.Errorf("error retrieving Edge Gateway: %s", err)
 	}
 
-	existingRule, err := nsxtEdge.GetNatRuleById(d.Id())
+	existingRule, err := getNsxtNatRuleById(&vcdClient.Client, nsxtEdge.EdgeGateway.ID, d.Id())
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
 			d.SetId("")
@@ -198,10 +278,11 @@ func resourceVcdNsxtNatRuleRead(ctx context.Context, d *schema.ResourceData, met
 		return diag.Errorf("unable to find NSX-T NAT rule: %s", err)
 	}
 
-	err = setNsxtNatRuleData(existingRule.NsxtNatRule, d, vcdClient)
+	err = setNsxtNatRuleData(&existingRule.NsxtNatRule, d, vcdClient)
 	if err != nil {
 		return diag.Errorf("error storing NSX-T NAT rule in statefile: %s", err)
 	}
+	setNsxtNatRuleAppliedTo(d, existingRule)
 
 	return nil
 }
@@ -280,18 +361,14 @@ func resourceVcdNsxtNatRuleImport(ctx context.Context, d *schema.ResourceData, m
 }
 
 func getNsxtNatType(d *schema.ResourceData, client *VCDClient) (*types.NsxtNatRule, error) {
-	// REFLEXIVE rule_type is only supported in VCD 10.3+
-	if d.Get("rule_type").(string) == types.NsxtNatRuleTypeReflexive && client.Client.APIVCDMaxVersionIs("< 36.0") {
-		return nil, fmt.Errorf("rule_type 'REFLEXIVE' can only be used for VCD 10.3+")
-	}
-
 	firewallMatch, firewallMatchOk := d.GetOk("firewall_match")
 	priority, priorityOk := d.GetOk("priority")
+	_, appliedToOk := d.GetOk("applied_to")
 
-	// Only supported in VCD 10.2.2+ (API V35.2) and throw immediate error if used with older versions as API error is
-	// opaque
-	if (firewallMatchOk || priorityOk) && client.Client.APIVCDMaxVersionIs("< 35.2") {
-		return nil, fmt.Errorf("firewall_match and priority fields can only be set for VCD 10.2.2+")
+	// Throw immediate error if fields are used with older versions as API error is opaque
+	err := validateNsxtNatRuleVersion(client, d.Get("rule_type").(string), firewallMatchOk, priorityOk, appliedToOk)
+	if err != nil {
+		return nil, err
 	}
 
 	nsxtNatRule := &types.NsxtNatRule{
@@ -355,6 +432,102 @@ func setNsxtNatRuleData(rule *types.NsxtNatRule, d *schema.ResourceData, client
 	return nil
 }
 
+// setNsxtNatRuleAppliedTo stores 'applied_to' field. VCD versions before 10.3 do not return it.
+func setNsxtNatRuleAppliedTo(d *schema.ResourceData, rule *nsxtExtendedNatRule) {
+	appliedTo := ""
+	if rule.AppliedTo != nil {
+		appliedTo = rule.AppliedTo.ID
+	}
+	dSet(d, "applied_to", appliedTo)
+}
+
+func getNsxtNatRuleById(client *govcd.Client, edgeGatewayId, id string) (*nsxtExtendedNatRule, error) {
+	rule := &nsxtExtendedNatRule{}
+	err := openApiGetExtendedItem(client, nsxtNatRuleApiVersions, rule,
+		types.OpenApiPathVersion1_0_0, fmt.Sprintf(types.OpenApiEndpointNsxtNatRules, edgeGatewayId), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// getNsxtNatRuleByName retrieves all NAT rules and looks up the one with given name, because the
+// endpoint does not support filtering
+func getNsxtNatRuleByName(client *govcd.Client, edgeGatewayId, name string) (*nsxtExtendedNatRule, error) {
+	allRules, err := getAllNsxtNatRules(client, edgeGatewayId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving all NSX-T NAT rules: %s", err)
+	}
+
+	var foundRules []*nsxtExtendedNatRule
+	for _, rule := range allRules {
+		if rule.Name == name {
+			foundRules = append(foundRules, rule)
+		}
+	}
+
+	if len(foundRules) > 1 {
+		return nil, fmt.Errorf("found %d NSX-T NAT rules with name '%s'. Expected 1", len(foundRules), name)
+	}
+
+	if len(foundRules) == 0 {
+		return nil, govcd.ErrorEntityNotFound
+	}
+
+	return foundRules[0], nil
+}
+
+func getAllNsxtNatRules(client *govcd.Client, edgeGatewayId string) ([]*nsxtExtendedNatRule, error) {
+	allRules := []*nsxtExtendedNatRule{{}}
+	err := openApiGetAllExtendedItems(client, nsxtNatRuleApiVersions, nil, &allRules,
+		types.OpenApiPathVersion1_0_0, fmt.Sprintf(types.OpenApiEndpointNsxtNatRules, edgeGatewayId))
+	if err != nil {
+		return nil, err
+	}
+
+	return allRules, nil
+}
+
+// createNsxtNatRule creates NAT rule and returns its ID. The API does not return created rule,
+// therefore the only rule which did not exist before creation is looked up. It is safe because
+// the parent Edge Gateway is locked.
+func createNsxtNatRule(client *govcd.Client, edgeGatewayId string, rule *nsxtExtendedNatRule) (string, error) {
+	existingRules, err := getAllNsxtNatRules(client, edgeGatewayId)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving existing NSX-T NAT rules: %s", err)
+	}
+	existingIds := make(map[string]bool, len(existingRules))
+	for _, existingRule := range existingRules {
+		existingIds[existingRule.ID] = true
+	}
+
+	err = openApiPostExtendedItemAsync(client, nsxtNatRuleApiVersions, rule,
+		types.OpenApiPathVersion1_0_0, fmt.Sprintf(types.OpenApiEndpointNsxtNatRules, edgeGatewayId))
+	if err != nil {
+		return "", err
+	}
+
+	allRules, err := getAllNsxtNatRules(client, edgeGatewayId)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving NSX-T NAT rules after creation: %s", err)
+	}
+
+	for _, singleRule := range allRules {
+		if !existingIds[singleRule.ID] && singleRule.Name == rule.Name {
+			return singleRule.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("error finding NSX-T NAT rule '%s' after creation: %s", rule.Name, govcd.ErrorEntityNotFound)
+}
+
+func updateNsxtNatRule(client *govcd.Client, edgeGatewayId string, rule *nsxtExtendedNatRule) error {
+	updatedRule := &nsxtExtendedNatRule{}
+	return openApiPutExtendedItem(client, nsxtNatRuleApiVersions, rule, updatedRule,
+		types.OpenApiPathVersion1_0_0, fmt.Sprintf(types.OpenApiEndpointNsxtNatRules, edgeGatewayId), rule.ID)
+}
+
 // getNatRulesList is a helper for import. NAT rules don't enforce name uniqueness therefore it may be that user
 // specifies a rule with the same name. In that case NAT rule details and their IDs are listed and the one will be able
 // to import by using ID.
@@ -746,6 +746,105 @@ resource "vcd_nsxt_nat_rule" "reflexive" {
 }
 `
 
+func TestAccVcdNsxtNatRuleAppliedTo(t *testing.T) {
+	preTestChecks(t)
+	if noTestCredentials() {
+		t.Skip("Skipping test run as no credentials are provided and this test needs to lookup VCD version")
+		return
+	}
+
+	client := createTemporaryVCDConnection(false)
+	if client.Client.APIVCDMaxVersionIs("< 36.0") {
+		t.Skipf("This test tests VCD 10.3.0+ (API V36.0+) features. Skipping.")
+	}
+
+	// String map to fill the template
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"NsxtVdc":     testConfig.Nsxt.Vdc,
+		"EdgeGw":      testConfig.Nsxt.EdgeGateway,
+		"NetworkName": t.Name(),
+		"Tags":        "network nsxt",
+		"AppliedTo":   "vcd_network_routed_v2.nat-applied-to.id",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText1 := templateFill(testAccNsxtNatRuleAppliedTo, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "-step2"
+	params["AppliedTo"] = `""`
+	configText2 := templateFill(testAccNsxtNatRuleAppliedTo, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckNsxtNatRuleDestroy("test-snat-applied-to"),
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcd_nsxt_nat_rule.snat", "id"),
+					resource.TestCheckResourceAttr("vcd_nsxt_nat_rule.snat", "rule_type", "SNAT"),
+					resource.TestCheckResourceAttrPair("vcd_nsxt_nat_rule.snat", "applied_to", "vcd_network_routed_v2.nat-applied-to", "id"),
+				),
+			},
+			{
+				ResourceName:            "vcd_nsxt_nat_rule.snat",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       importStateIdNsxtEdgeGatewayObject(testConfig, testConfig.Nsxt.EdgeGateway, "test-snat-applied-to"),
+				ImportStateVerifyIgnore: []string{"vdc"},
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcd_nsxt_nat_rule.snat", "id"),
+					resource.TestCheckResourceAttr("vcd_nsxt_nat_rule.snat", "applied_to", ""),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccNsxtNatRuleAppliedTo = testAccNsxtSecurityGroupPrereqsEmpty + `
+resource "vcd_network_routed_v2" "nat-applied-to" {
+  org  = "{{.Org}}"
+  name = "{{.NetworkName}}"
+
+  edge_gateway_id = data.vcd_nsxt_edgegateway.existing.id
+
+  gateway       = "11.11.11.1"
+  prefix_length = 24
+
+  static_ip_pool {
+    start_address = "11.11.11.10"
+    end_address   = "11.11.11.20"
+  }
+}
+
+resource "vcd_nsxt_nat_rule" "snat" {
+  org = "{{.Org}}"
+
+  edge_gateway_id = data.vcd_nsxt_edgegateway.existing.id
+
+  name      = "test-snat-applied-to"
+  rule_type = "SNAT"
+
+  # Using primary_ip from edge gateway
+  external_address = tolist(data.vcd_nsxt_edgegateway.existing.subnet)[0].primary_ip
+  internal_address = "11.11.11.0/24"
+
+  applied_to = {{.AppliedTo}}
+}
+`
+
 func testAccCheckNsxtNatRuleDestroy(natRuleIdentifier string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		conn := testAccProvider.Meta().(*VCDClient)
@@ -248,6 +248,22 @@ func resourceVcdOrgVdc() *schema.Resource {
 				Computed:    true,
 				Description: "ID of default VM sizing policy ID",
 			},
+			"vm_placement_policy_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Set of VM placement policy IDs",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"vm_vgpu_policy_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Set of vGPU policy IDs",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -312,6 +328,11 @@ func resourceVcdVdcCreate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error assigning VM sizing policies to VDC: %s", err)
 	}
 
+	err = updateAssignedVmPlacementAndVgpuPolicies(vcdClient, d)
+	if err != nil {
+		return fmt.Errorf("error assigning VM placement and vGPU policies to VDC: %s", err)
+	}
+
 	return resourceVcdVdcRead(d, meta)
 }
 
@@ -322,6 +343,14 @@ func isSizingPolicyAllowed(d *schema.ResourceData, vcdClient *VCDClient) error {
 		if okSizingPolicy || okDefaultPolicy {
 			return fmt.Errorf("'vm_sizing_policy_ids' and `default_vm_sizing_policy_id` only available for VCD 10.0+")
 		}
+		if _, ok := d.GetOk("vm_placement_policy_ids"); ok {
+			return fmt.Errorf("'vm_placement_policy_ids' only available for VCD 10.0+")
+		}
+	}
+	if vcdClient.Client.APIVCDMaxVersionIs("< " + vmVgpuPolicyMinApiVersion) {
+		if _, ok := d.GetOk("vm_vgpu_policy_ids"); ok {
+			return fmt.Errorf("'vm_vgpu_policy_ids' only available for VCD 10.4.0+")
+		}
 	}
 	return nil
 }
@@ -432,9 +461,23 @@ func setOrgVdcData(d *schema.ResourceData, vcdClient *VCDClient, adminOrg *govcd
 			log.Printf("[DEBUG] Unable to get assigned VM sizing policies")
 			return fmt.Errorf("unable to get assigned VM sizing policies %s", err)
 		}
+		// vGPU policies can only be recognized using the 2.0.0 endpoint
+		assignedVgpuPolicyIds, err := getAssignedVdcVgpuPolicyIds(&vcdClient.Client, adminVdc.AdminVdc.ID)
+		if err != nil {
+			return fmt.Errorf("unable to get assigned vGPU policies %s", err)
+		}
 		var policyIds []string
+		var placementPolicyIds []string
+		var vgpuPolicyIds []string
 		for _, policy := range assignedVmSizingPolicies {
-			policyIds = append(policyIds, policy.VdcComputePolicy.ID)
+			switch {
+			case policy.VdcComputePolicy.IsSizingOnly:
+				policyIds = append(policyIds, policy.VdcComputePolicy.ID)
+			case assignedVgpuPolicyIds[policy.VdcComputePolicy.ID]:
+				vgpuPolicyIds = append(vgpuPolicyIds, policy.VdcComputePolicy.ID)
+			default:
+				placementPolicyIds = append(placementPolicyIds, policy.VdcComputePolicy.ID)
+			}
 		}
 		vmSizingPoliciesSet := convertStringsToTypeSet(policyIds)
 
@@ -445,6 +488,16 @@ func setOrgVdcData(d *schema.ResourceData, vcdClient *VCDClient, adminOrg *govcd
 			return err
 		}
 
+		err = d.Set("vm_placement_policy_ids", convertStringsToTypeSet(placementPolicyIds))
+		if err != nil {
+			return err
+		}
+
+		err = d.Set("vm_vgpu_policy_ids", convertStringsToTypeSet(vgpuPolicyIds))
+		if err != nil {
+			return err
+		}
+
 	}
 
 	log.Printf("[TRACE] vdc read completed: %#v", adminVdc.AdminVdc)
@@ -570,6 +623,11 @@ func resourceVcdVdcUpdate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error assigning VM sizing policies to VDC: %s", err)
 	}
 
+	err = updateAssignedVmPlacementAndVgpuPolicies(vcdClient, d)
+	if err != nil {
+		return fmt.Errorf("error assigning VM placement and vGPU policies to VDC: %s", err)
+	}
+
 	if d.HasChange("storage_profile") {
 		vdcStorageProfilesConfigurations := d.Get("storage_profile").(*schema.Set)
 		err = updateStorageProfiles(vdcStorageProfilesConfigurations, vcdClient, adminVdc, d.Get("provider_vdc_name").(string))
@@ -958,6 +1016,58 @@ func addAssignedVmSizingPolicies(vcdClient *VCDClient, d *schema.ResourceData, m
 	return nil
 }
 
+// updateAssignedVmPlacementAndVgpuPolicies handles VM placement and vGPU policies. It runs after the VM sizing
+// policies are handled, because assigning sizing policies replaces the whole list of assigned compute policies,
+// placement and vGPU ones included. The assigned sizing policies are preserved and the placement and vGPU policies
+// are replaced by `vm_placement_policy_ids` and `vm_vgpu_policy_ids`
+func updateAssignedVmPlacementAndVgpuPolicies(vcdClient *VCDClient, d *schema.ResourceData) error {
+	if vcdClient.Client.APIVCDMaxVersionIs("< 33.0") {
+		return nil
+	}
+	if !d.HasChanges("vm_placement_policy_ids", "vm_vgpu_policy_ids", "vm_sizing_policy_ids", "default_vm_sizing_policy_id") {
+		return nil
+	}
+	log.Printf("[TRACE] updating assigned VM placement and vGPU policies to VDC")
+
+	vcdComputePolicyHref, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointVdcComputePolicies)
+	if err != nil {
+		return fmt.Errorf("error constructing HREF for compute policy")
+	}
+
+	adminOrg, err := vcdClient.GetAdminOrgFromResource(d)
+	if err != nil {
+		return fmt.Errorf(errorRetrievingOrg, err)
+	}
+
+	vdc, err := adminOrg.GetAdminVDCByName(d.Get("name").(string), false)
+	if err != nil {
+		return fmt.Errorf(errorRetrievingVdcFromOrg, d.Get("org").(string), d.Get("name").(string), err)
+	}
+
+	existingPolicies, err := vdc.GetAllAssignedVdcComputePolicies(nil)
+	if err != nil {
+		return fmt.Errorf("error getting assigned compute policies. %s", err)
+	}
+
+	var vdcComputePolicyReferenceList []*types.Reference
+	for _, existingPolicy := range existingPolicies {
+		if existingPolicy.VdcComputePolicy.IsSizingOnly {
+			vdcComputePolicyReferenceList = append(vdcComputePolicyReferenceList, &types.Reference{HREF: vcdComputePolicyHref.String() + existingPolicy.VdcComputePolicy.ID})
+		}
+	}
+	placementPolicyIds := convertSchemaSetToSliceOfStrings(d.Get("vm_placement_policy_ids").(*schema.Set))
+	vgpuPolicyIds := convertSchemaSetToSliceOfStrings(d.Get("vm_vgpu_policy_ids").(*schema.Set))
+	for _, policyId := range append(placementPolicyIds, vgpuPolicyIds...) {
+		vdcComputePolicyReferenceList = append(vdcComputePolicyReferenceList, &types.Reference{HREF: vcdComputePolicyHref.String() + policyId})
+	}
+
+	_, err = vdc.SetAssignedComputePolicies(types.VdcComputePolicyReferences{VdcComputePolicyReference: vdcComputePolicyReferenceList})
+	if err != nil {
+		return fmt.Errorf("error setting VM placement and vGPU policies. %s", err)
+	}
+	return nil
+}
+
 func createOrUpdateOrgMetadata(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[TRACE] adding/updating metadata to VDC")
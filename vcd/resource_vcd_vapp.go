@@ -5,8 +5,10 @@ import (
 	"log"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v2/govcd"
 )
 
@@ -65,6 +67,27 @@ func resourceVcdVApp() *schema.Resource {
 				Default:     false,
 				Description: "A boolean value stating if this vApp should be powered on",
 			},
+			"power_state": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"power_on"},
+				ValidateFunc:  validation.StringInSlice([]string{"on", "off", "suspended"}, false),
+				Description:   "Desired power state of the vApp ('on', 'off', 'suspended'). Power state drift is corrected when set",
+			},
+			"shutdown_behavior": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "power_off",
+				ValidateFunc: validation.StringInSlice([]string{"guest_shutdown", "power_off"}, false),
+				Description:  "How the vApp is stopped when it is powered off or removed ('guest_shutdown', 'power_off')",
+			},
+			"shutdown_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      300,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Seconds to wait for the guest OS of VMs to shut down before falling back to hard power off when 'shutdown_behavior=guest_shutdown'",
+			},
 			"guest_properties": {
 				Type:        schema.TypeMap,
 				Optional:    true,
@@ -228,6 +251,11 @@ func resourceVcdVAppUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	err = ensureVappPowerState(d, vapp)
+	if err != nil {
+		return err
+	}
+
 	return resourceVcdVAppRead(d, meta)
 }
 
@@ -292,6 +320,11 @@ func genericVcdVAppRead(d *schema.ResourceData, meta interface{}, origin string)
 	}
 	dSet(d, "status", vapp.VApp.Status)
 	dSet(d, "status_text", statusText)
+	// 'power_state' is only stored when it is set so that power state drift is not reported for
+	// vApps managed with 'power_on'
+	if origin == "resource" && d.Get("power_state").(string) != "" {
+		dSet(d, "power_state", vappStatusToPowerState(statusText))
+	}
 	dSet(d, "href", vapp.VApp.HREF)
 	dSet(d, "description", vapp.VApp.Description)
 	metadata, err := vapp.GetMetadata()
@@ -335,7 +368,7 @@ func resourceVcdVAppDelete(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error changing network: %#v", err)
 	}
 
-	err = tryUndeploy(*vapp)
+	err = tryUndeploy(d, *vapp)
 	if err != nil {
 		return err
 	}
@@ -357,7 +390,17 @@ func resourceVcdVAppDelete(d *schema.ResourceData, meta interface{}) error {
 // Very often the vApp is powered off at this point and Undeploy() would fail with error:
 // "The requested operation could not be executed since vApp vApp_name is not running"
 // So, if the error matches we just ignore it and the caller may fast forward to vapp.Delete()
-func tryUndeploy(vapp govcd.VApp) error {
+// When 'shutdown_behavior' is 'guest_shutdown', the guest OS of VMs is asked to shut down first
+// and the un-deploy only powers off what is still running after 'shutdown_timeout' seconds.
+func tryUndeploy(d *schema.ResourceData, vapp govcd.VApp) error {
+	if d.Get("shutdown_behavior").(string) == "guest_shutdown" {
+		timeout := time.Duration(d.Get("shutdown_timeout").(int)) * time.Second
+		err := shutdownVappGuest(&vapp, timeout)
+		if err != nil {
+			log.Printf("[DEBUG] guest OS shutdown of vApp %s did not succeed, falling back to power off: %s", vapp.VApp.Name, err)
+		}
+	}
+
 	task, err := vapp.Undeploy()
 	var reErr = regexp.MustCompile(`.*The requested operation could not be executed since vApp.*is not running.*`)
 	if err != nil && reErr.MatchString(err.Error()) {
@@ -374,6 +417,87 @@ func tryUndeploy(vapp govcd.VApp) error {
 	return nil
 }
 
+// shutdownVappGuest triggers a guest OS shutdown of all VMs in the vApp and waits up to timeout for
+// the vApp to be powered off
+func shutdownVappGuest(vapp *govcd.VApp, timeout time.Duration) error {
+	status, err := vapp.GetStatus()
+	if err != nil {
+		return fmt.Errorf("error getting vApp status: %s", err)
+	}
+	if status == "POWERED_OFF" || status == "RESOLVED" {
+		return nil
+	}
+
+	task, err := vapp.Shutdown()
+	if err != nil {
+		return err
+	}
+
+	return waitForGuestShutdown(task, vapp.GetStatus, timeout)
+}
+
+// ensureVappPowerState brings the vApp to the state requested in 'power_state', if it is set
+func ensureVappPowerState(d *schema.ResourceData, vapp *govcd.VApp) error {
+	powerState := d.Get("power_state").(string)
+	if powerState == "" {
+		return nil
+	}
+
+	status, err := vapp.GetStatus()
+	if err != nil {
+		return fmt.Errorf("error getting vApp %s status: %s", vapp.VApp.Name, err)
+	}
+	if vappStatusToPowerState(status) == powerState {
+		return nil
+	}
+
+	if powerState == "off" {
+		log.Printf("[DEBUG] Powering off vApp %s to match power_state. Current state %s", vapp.VApp.Name, status)
+		return tryUndeploy(d, *vapp)
+	}
+
+	// An empty vApp cannot be powered on or suspended
+	if vapp.VApp.Children == nil || len(vapp.VApp.Children.VM) == 0 {
+		log.Printf("[DEBUG] vApp %s has no VMs. Skipping power_state '%s'", vapp.VApp.Name, powerState)
+		return nil
+	}
+
+	if status != "POWERED_ON" {
+		log.Printf("[DEBUG] Powering on vApp %s to match power_state. Current state %s", vapp.VApp.Name, status)
+		task, err := vapp.PowerOn()
+		if err != nil {
+			return fmt.Errorf("error powering on vApp %s: %s", vapp.VApp.Name, err)
+		}
+		err = task.WaitTaskCompletion()
+		if err != nil {
+			return fmt.Errorf("error waiting for power on task for vApp %s: %s", vapp.VApp.Name, err)
+		}
+	}
+
+	if powerState == "suspended" {
+		log.Printf("[DEBUG] Suspending vApp %s to match power_state", vapp.VApp.Name)
+		task, err := vapp.Suspend()
+		if err != nil {
+			return fmt.Errorf("error suspending vApp %s: %s", vapp.VApp.Name, err)
+		}
+		err = task.WaitTaskCompletion()
+		if err != nil {
+			return fmt.Errorf("error waiting for suspend task for vApp %s: %s", vapp.VApp.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// vappStatusToPowerState converts a vApp status to the 'power_state' format. A vApp which was never
+// deployed ('RESOLVED') is considered as powered off.
+func vappStatusToPowerState(status string) string {
+	if status == "RESOLVED" {
+		return "off"
+	}
+	return vmStatusToPowerState(status)
+}
+
 // resourceVcdVappImport is responsible for importing the resource.
 // The following steps happen as part of import
 // 1. The user supplies `terraform import _resource_name_ _the_id_string_` command
@@ -406,6 +530,9 @@ func resourceVcdVappImport(d *schema.ResourceData, meta interface{}) ([]*schema.
 	dSet(d, "name", vappName)
 	dSet(d, "org", orgName)
 	dSet(d, "vdc", vdcName)
+	// Defaults are not applied on import
+	dSet(d, "shutdown_behavior", "power_off")
+	dSet(d, "shutdown_timeout", 300)
 	d.SetId(vapp.VApp.ID)
 	return []*schema.ResourceData{d}, nil
 }
@@ -3,10 +3,14 @@ package vcd
 //lint:file-ignore SA1019 ignore deprecated functions
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
 	"fmt"
+	"io"
 	"log"
 	"net"
+	"net/http"
 	"sort"
 	"strconv"
 	"strings"
@@ -120,6 +124,20 @@ func vmSchemaFunc(vmType typeOfVm) map[string]*schema.Schema {
 			ForceNew:    true,
 			Description: "The name of the VM in vApp Template to use. In cases when vApp template has more than one VM",
 		},
+		"vapp_template_id": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{"catalog_name", "template_name", "vm_name_in_template", "vm_template_id"},
+			Description:   "The ID of the vApp Template to use. The first VM of the template is used",
+		},
+		"vm_template_id": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{"catalog_name", "template_name", "vm_name_in_template", "vapp_template_id"},
+			Description:   "The ID of the VM inside a vApp Template to use",
+		},
 		"catalog_name": {
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -225,6 +243,27 @@ func vmSchemaFunc(vmType typeOfVm) map[string]*schema.Schema {
 			Default:     true,
 			Description: "A boolean value stating if this VM should be powered on",
 		},
+		"power_state": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"power_on"},
+			ValidateFunc:  validation.StringInSlice([]string{"on", "off", "suspended"}, false),
+			Description:   "Desired power state of the VM ('on', 'off', 'suspended'). Power state drift is corrected when set",
+		},
+		"shutdown_behavior": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "power_off",
+			ValidateFunc: validation.StringInSlice([]string{"guest_shutdown", "power_off"}, false),
+			Description:  "How the VM is stopped when an update or removal requires it ('guest_shutdown', 'power_off')",
+		},
+		"shutdown_timeout": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      300,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Seconds to wait for the guest OS to shut down before falling back to hard power off when 'shutdown_behavior=guest_shutdown'",
+		},
 		"storage_profile": {
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -255,6 +294,46 @@ func vmSchemaFunc(vmType typeOfVm) map[string]*schema.Schema {
 			Description: "Optional number of seconds to try and wait for DHCP IP (valid for " +
 				"'network' block only)",
 		},
+		"customization_status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Guest customization status (e.g. 'GC_PENDING', 'GC_COMPLETE', 'GC_FAILED')",
+		},
+		"fail_on_customization_error": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Fail VM creation or power on when guest customization reports an error",
+		},
+		"wait_for_guest": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Conditions to wait for after the VM is powered on, before it is considered ready",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"vmware_tools_running": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "Wait until VMware Tools report to be running in the guest OS, which confirms the guest heartbeat",
+					},
+					"customization_complete": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "Wait until guest customization status is 'GC_COMPLETE'. Fails if it reaches 'GC_FAILED'",
+					},
+					"timeout_seconds": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      600,
+						ValidateFunc: validation.IntAtLeast(10),
+						Description:  "Maximum number of seconds to wait for all conditions",
+					},
+				},
+			},
+		},
 		"network": {
 			Optional:    true,
 			Type:        schema.TypeList,
@@ -448,6 +527,38 @@ func vmSchemaFunc(vmType typeOfVm) map[string]*schema.Schema {
 			Optional:    true,
 			Description: "Key/value settings for guest properties",
 		},
+		"cloud_init": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Cloud-init data which is encoded and set as 'guestinfo.*' guest properties",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"user_data": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Cloud-init user data (e.g. '#cloud-config' document)",
+					},
+					"meta_data": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Cloud-init meta data. Must be YAML when 'network_config' is set",
+					},
+					"network_config": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Cloud-init network configuration. It is embedded into meta data",
+					},
+					"encoding": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "base64",
+						ValidateFunc: validation.StringInSlice([]string{"base64", "gzip+base64"}, false),
+						Description:  "Encoding of guest properties ('base64', 'gzip+base64')",
+					},
+				},
+			},
+		},
 		"customization": {
 			Optional:    true,
 			Computed:    true,
@@ -583,6 +694,12 @@ func vmSchemaFunc(vmType typeOfVm) map[string]*schema.Schema {
 			Computed:    true,
 			Description: "VM sizing policy ID. Has to be assigned to Org VDC.",
 		},
+		"placement_policy_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "VM placement policy ID. Has to be assigned to Org VDC.",
+		},
 	}
 }
 
@@ -600,6 +717,35 @@ func vmTemplatefromVappTemplate(name string, vappTemplate *types.VAppTemplate) *
 	return nil
 }
 
+// getVappTemplateById retrieves a vApp template or a VM inside a vApp template using its ID.
+// The ID can be either a vApp template URN (urn:vcloud:vapptemplate:<uuid>) or a VM URN
+// (urn:vcloud:vm:<uuid>). Retrieval does not depend on catalog or template names, therefore it also
+// works for templates in catalogs shared from other Organizations
+func getVappTemplateById(client *govcd.Client, id string) (*govcd.VAppTemplate, error) {
+	uuid := extractUuid(id)
+	if uuid == "" {
+		return nil, fmt.Errorf("'%s' is not a valid template ID", id)
+	}
+
+	var hrefPrefix string
+	switch {
+	case strings.HasPrefix(id, "urn:vcloud:vapptemplate:"):
+		hrefPrefix = "vappTemplate-"
+	case strings.HasPrefix(id, "urn:vcloud:vm:"):
+		hrefPrefix = "vm-"
+	default:
+		return nil, fmt.Errorf("'%s' is neither a vApp template nor a VM template ID", id)
+	}
+
+	href := fmt.Sprintf("%s/vAppTemplate/%s%s", client.VCDHREF.String(), hrefPrefix, uuid)
+	vappTemplate := govcd.NewVAppTemplate(client)
+	_, err := client.ExecuteRequest(href, http.MethodGet, "", "error retrieving vApp template: %s", nil, vappTemplate.VAppTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return vappTemplate, nil
+}
+
 func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType typeOfVm) error {
 	util.Logger.Printf("[DEBUG] [VM create] started")
 	vcdClient := meta.(*VCDClient)
@@ -623,22 +769,35 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 
 	catalogName := d.Get("catalog_name").(string)
 	templateName := d.Get("template_name").(string)
+	vappTemplateId := d.Get("vapp_template_id").(string)
+	vmTemplateId := d.Get("vm_template_id").(string)
 	vmName := d.Get("name").(string)
 	description := d.Get("description").(string)
-	powerOn := d.Get("power_on").(bool)
+	powerOn := isVmPowerOnRequested(d)
 
 	var vapp *govcd.VApp
 
 	//create not empty VM - use provided template
-	if catalogName != "" && templateName != "" {
-
-		catalog, err := org.GetCatalogByName(catalogName, false)
-		if err != nil {
-			return fmt.Errorf("error finding catalog %s: %s", catalogName, err)
-		}
+	if (catalogName != "" && templateName != "") || vappTemplateId != "" || vmTemplateId != "" {
 
 		var vappTemplate govcd.VAppTemplate
-		if vmNameInTemplate, ok := d.GetOk("vm_name_in_template"); ok {
+		if vappTemplateId != "" || vmTemplateId != "" {
+			templateId := vappTemplateId
+			if vmTemplateId != "" {
+				templateId = vmTemplateId
+			}
+			returnedVappTemplate, err := getVappTemplateById(&vcdClient.Client, templateId)
+			if err != nil {
+				return fmt.Errorf("[VM create] error retrieving template %s: %s", templateId, err)
+			}
+			util.Logger.Printf("[VM create] returnedVappTemplate %#v", pretty.Formatter(returnedVappTemplate))
+			vappTemplate = *returnedVappTemplate
+		} else if vmNameInTemplate, ok := d.GetOk("vm_name_in_template"); ok {
+			catalog, err := org.GetCatalogByName(catalogName, false)
+			if err != nil {
+				return fmt.Errorf("error finding catalog %s: %s", catalogName, err)
+			}
+
 			vmInTemplateRecord, err := vdc.QueryVappVmTemplate(catalogName, templateName, vmNameInTemplate.(string))
 			if err != nil {
 				return fmt.Errorf("error quering VM template %s: %s", vmNameInTemplate, err)
@@ -651,6 +810,10 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 			util.Logger.Printf("[VM create] returnedVappTemplate %#v", pretty.Formatter(returnedVappTemplate))
 			vappTemplate = *returnedVappTemplate
 		} else {
+			catalog, err := org.GetCatalogByName(catalogName, false)
+			if err != nil {
+				return fmt.Errorf("error finding catalog %s: %s", catalogName, err)
+			}
 			catalogItem, err := catalog.GetCatalogItemByName(templateName, false)
 			if err != nil {
 				return fmt.Errorf("error finding catalog item %s: %s", templateName, err)
@@ -708,11 +871,26 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 			}
 			util.Logger.Printf("[VM create] sizingPolicy (%s) %# v", vdcComputePolicy.Href, pretty.Formatter(sizingPolicy))
 		}
+		placementPolicyId := d.Get("placement_policy_id").(string)
+		if placementPolicyId != "" {
+			vdcComputePolicy, err := vcdClient.Client.GetVdcComputePolicyById(placementPolicyId)
+			if err != nil {
+				return fmt.Errorf("error getting placement policy %s: %s", placementPolicyId, err)
+			}
+			if vmComputePolicy == nil {
+				vmComputePolicy = &types.ComputePolicy{}
+			}
+			vmComputePolicy.VmPlacementPolicy = &types.Reference{HREF: vdcComputePolicy.Href}
+		}
 
 		var vm *govcd.VM
 
 		if vappName == "" {
 			vmTemplate := vmTemplatefromVappTemplate(d.Get("vm_name_in_template").(string), vappTemplate.VAppTemplate)
+			if vmTemplateId != "" {
+				// The template retrieved by ID is already the VM inside the vApp template
+				vmTemplate = vappTemplate.VAppTemplate
+			}
 			if vmTemplate == nil {
 				return fmt.Errorf("[VM creation] VM template isn't found. Please check vApp template %s : %s", vmName, err)
 			}
@@ -766,6 +944,15 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 				d.SetId("")
 				return fmt.Errorf("[VM creation] error getting VM %s : %s", vmName, err)
 			}
+
+			// Adding a VM to a vApp only supports the sizing policy, the placement policy is set afterwards
+			if placementPolicyId != "" {
+				err = updateVmComputePolicies(vcdClient, vm, d.Get("sizing_policy_id").(string), placementPolicyId)
+				if err != nil {
+					d.SetId("")
+					return fmt.Errorf("[VM creation] error setting placement policy of VM %s : %s", vmName, err)
+				}
+			}
 		}
 
 		var computedVmType string
@@ -847,6 +1034,15 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 			return fmt.Errorf("[VM creation] error applying advanced compute settings for standalone VM %s : %s", vmName, err)
 		}
 
+		err = waitForGuestReadiness(d, &vcdClient.Client, vdc, vm)
+		if err != nil {
+			return err
+		}
+		err = checkGuestCustomizationResult(d, &vcdClient.Client, vm)
+		if err != nil {
+			return err
+		}
+
 		return genericVcdVmRead(d, meta, "create", vmType)
 	}
 
@@ -1053,17 +1249,10 @@ func resourceVmHotUpdate(d *schema.ResourceData, meta interface{}, vmType typeOf
 		return err
 	}
 
-	if d.HasChange("sizing_policy_id") {
-		var sizingPolicy *types.VdcComputePolicy
-		value := d.Get("sizing_policy_id")
-		vdcComputePolicy, err := vcdClient.Client.GetVdcComputePolicyById(value.(string))
-		if err != nil {
-			return fmt.Errorf("error getting sizing policy %s: %s", value.(string), err)
-		}
-		sizingPolicy = vdcComputePolicy.VdcComputePolicy
-		_, err = vm.UpdateComputePolicy(sizingPolicy)
+	if d.HasChanges("sizing_policy_id", "placement_policy_id") {
+		err = updateVmComputePolicies(vcdClient, vm, d.Get("sizing_policy_id").(string), d.Get("placement_policy_id").(string))
 		if err != nil {
-			return fmt.Errorf("error updating sizing policy %s: %s", value.(string), err)
+			return fmt.Errorf("error updating compute policies of VM %s: %s", vm.VM.Name, err)
 		}
 	}
 
@@ -1083,12 +1272,17 @@ func resourceVmHotUpdate(d *schema.ResourceData, meta interface{}, vmType typeOf
 }
 
 func addRemoveGuestProperties(d *schema.ResourceData, vm *govcd.VM) error {
-	if d.HasChange("guest_properties") {
+	if d.HasChanges("guest_properties", "cloud_init") {
 		vmProperties, err := getGuestProperties(d)
 		if err != nil {
 			return fmt.Errorf("unable to convert guest properties to data structure")
 		}
 
+		err = addCloudInitGuestProperties(d, vmProperties)
+		if err != nil {
+			return err
+		}
+
 		log.Printf("[TRACE] Updating VM guest properties")
 		_, err = vm.SetProductSectionList(vmProperties)
 		if err != nil {
@@ -1159,6 +1353,9 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 	// Check if the user requested for forced customization of VM
 	customizationNeeded := isForcedCustomization(d.Get("customization"))
 
+	// Guest OS readiness is only awaited when the VM is (re)started in this operation
+	vmStarted := false
+
 	// Update guest customization if any of the customization related fields have changed
 	if d.HasChanges("customization", "computer_name", "name") {
 		log.Printf("[TRACE] VM %s customization has changes: customization(%t), computer_name(%t), name(%t)",
@@ -1214,13 +1411,9 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 			}
 			log.Printf("[DEBUG] Un-deploying VM %s for offline update. Previous state %s",
 				vm.VM.Name, vmStatusBeforeUpdate)
-			task, err := vm.Undeploy()
+			err = undeployVm(d, &vcdClient.Client, vm)
 			if err != nil {
-				return fmt.Errorf("error triggering undeploy for VM %s: %s", vm.VM.Name, err)
-			}
-			err = task.WaitTaskCompletion()
-			if err != nil {
-				return fmt.Errorf("error waiting for undeploy task for VM %s: %s", vm.VM.Name, err)
+				return err
 			}
 		}
 
@@ -1337,14 +1530,18 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 	}
 
 	// If the VM was powered off during update but it has to be powered on
-	if d.Get("power_on").(bool) {
+	if isVmPowerOnRequested(d) {
 		vmStatus, err := vm.GetStatus()
 		if err != nil {
 			return fmt.Errorf("error getting VM status before ensuring it is powered on: %s", err)
 		}
 
+		// A VM which is still suspended already matches 'power_state=suspended'. It is only resumed
+		// when it was powered off for a cold change, because only a running VM can be suspended.
+		isSuspendedAsRequested := d.Get("power_state").(string) == "suspended" && vmStatus == "SUSPENDED"
+
 		// Simply power on if customization is not requested
-		if !customizationNeeded && vmStatus != "POWERED_ON" {
+		if !customizationNeeded && vmStatus != "POWERED_ON" && !isSuspendedAsRequested {
 			log.Printf("[DEBUG] Powering on VM %s after update. Previous state %s", vm.VM.Name, vmStatus)
 			task, err := vm.PowerOn()
 			if err != nil {
@@ -1354,6 +1551,7 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 			if err != nil {
 				return fmt.Errorf(errorCompletingTask, err)
 			}
+			vmStarted = true
 		}
 
 		// When customization is requested VM must be un-deployed before starting it
@@ -1363,13 +1561,9 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 
 			if vmStatus != "POWERED_OFF" {
 				log.Printf("[TRACE] VM %s is in state %s. Un-deploying", vm.VM.Name, vmStatus)
-				task, err := vm.Undeploy()
-				if err != nil {
-					return fmt.Errorf("error triggering undeploy for VM %s: %s", vm.VM.Name, err)
-				}
-				err = task.WaitTaskCompletion()
+				err = undeployVm(d, &vcdClient.Client, vm)
 				if err != nil {
-					return fmt.Errorf("error waiting for undeploy task for VM %s: %s", vm.VM.Name, err)
+					return err
 				}
 			}
 
@@ -1378,8 +1572,27 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 			if err != nil {
 				return fmt.Errorf("failed powering on with customization: %s", err)
 			}
+			vmStarted = true
 		}
 	}
+
+	if vmStarted || executionType == "create" {
+		err = waitForGuestReadiness(d, &vcdClient.Client, vdc, vm)
+		if err != nil {
+			return err
+		}
+		err = checkGuestCustomizationResult(d, &vcdClient.Client, vm)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 'power_state' also enforces 'off' and 'suspended' states, unlike 'power_on=false' which only
+	// skips powering on
+	err = ensureVmPowerState(d, &vcdClient.Client, vm)
+	if err != nil {
+		return err
+	}
 	log.Printf("[DEBUG] [VM update] finished")
 	return genericVcdVmRead(d, meta, "update", vmType)
 }
@@ -1640,6 +1853,15 @@ func genericVcdVmRead(d *schema.ResourceData, meta interface{}, origin string, v
 		return fmt.Errorf("[VM read] unable to read guest properties: %s", err)
 	}
 
+	// Cloud-init guest properties are stored decoded in 'cloud_init' block instead of
+	// 'guest_properties' when the block is used
+	if origin != "datasource" && len(d.Get("cloud_init").([]interface{})) > 0 {
+		guestProperties, err = setCloudInitData(d, guestProperties)
+		if err != nil {
+			return fmt.Errorf("[VM read] unable to set cloud-init data in state: %s", err)
+		}
+	}
+
 	err = setGuestProperties(d, guestProperties)
 	if err != nil {
 		return fmt.Errorf("[VM read] unable to set guest properties in state: %s", err)
@@ -1671,6 +1893,28 @@ func genericVcdVmRead(d *schema.ResourceData, meta interface{}, origin string, v
 	if vm.VM.ComputePolicy != nil && vm.VM.ComputePolicy.VmSizingPolicy != nil {
 		dSet(d, "sizing_policy_id", vm.VM.ComputePolicy.VmSizingPolicy.ID)
 	}
+	if vm.VM.ComputePolicy != nil && vm.VM.ComputePolicy.VmPlacementPolicy != nil {
+		dSet(d, "placement_policy_id", vm.VM.ComputePolicy.VmPlacementPolicy.ID)
+	}
+
+	// Guest customization status is informative only and must not prevent reading VMs for which
+	// it cannot be retrieved
+	customizationStatus, err := vm.GetGuestCustomizationStatus()
+	if err != nil {
+		log.Printf("[DEBUG] [VM read] unable to retrieve guest customization status of VM %s: %s", vm.VM.Name, err)
+	} else {
+		dSet(d, "customization_status", customizationStatus)
+	}
+
+	// 'power_state' is only stored when it is set so that power state drift is not reported for
+	// VMs managed with 'power_on'
+	if origin != "datasource" && d.Get("power_state").(string) != "" {
+		vmStatus, err := vm.GetStatus()
+		if err != nil {
+			return fmt.Errorf("[VM read] error retrieving VM status: %s", err)
+		}
+		dSet(d, "power_state", vmStatusToPowerState(vmStatus))
+	}
 
 	log.Printf("[DEBUG] [VM read] finished with origin %s", origin)
 	return nil
@@ -1862,6 +2106,12 @@ func resourceVcdVAppVmDelete(ctx context.Context, d *schema.ResourceData, meta i
 
 	// If it is a standalone VM, we remove it in one go
 	if vapp.VApp.IsAutoNature {
+		if d.Get("shutdown_behavior").(string) == "guest_shutdown" {
+			err = undeployVm(d, &vcdClient.Client, vm)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
 		err = vm.Delete()
 		if err != nil {
 			return diag.FromErr(err)
@@ -1878,14 +2128,9 @@ func resourceVcdVAppVmDelete(ctx context.Context, d *schema.ResourceData, meta i
 	log.Printf("[TRACE] VM deploy Status: %t", deployed)
 	if deployed {
 		log.Printf("[TRACE] Undeploying VM: %s", vm.VM.Name)
-		task, err := vm.Undeploy()
+		err = undeployVm(d, &vcdClient.Client, vm)
 		if err != nil {
-			return diag.Errorf("error Undeploying: %s", err)
-		}
-
-		err = task.WaitTaskCompletion()
-		if err != nil {
-			return diag.Errorf("error Undeploying VM: %s", err)
+			return diag.FromErr(err)
 		}
 	}
 
@@ -2167,6 +2412,197 @@ func readNetworks(d *schema.ResourceData, vm govcd.VM, vapp govcd.VApp, vdc *gov
 	return nets, nil
 }
 
+// waitForGuestReadiness waits until all conditions defined in 'wait_for_guest' block are met. It
+// does nothing when the block is not set or the VM is not powered on.
+func waitForGuestReadiness(d *schema.ResourceData, client *govcd.Client, vdc *govcd.Vdc, vm *govcd.VM) error {
+	waitForGuest := d.Get("wait_for_guest").([]interface{})
+	if len(waitForGuest) == 0 || waitForGuest[0] == nil {
+		return nil
+	}
+	conditions := waitForGuest[0].(map[string]interface{})
+	timeout := time.Duration(conditions["timeout_seconds"].(int)) * time.Second
+
+	vmStatus, err := vm.GetStatus()
+	if err != nil {
+		return fmt.Errorf("error getting VM %s status: %s", vm.VM.Name, err)
+	}
+	if vmStatus != "POWERED_ON" {
+		log.Printf("[DEBUG] [VM guest readiness] VM %s is %s. Skipping 'wait_for_guest'", vm.VM.Name, vmStatus)
+		return nil
+	}
+
+	log.Printf("[DEBUG] [VM guest readiness] waiting up to %s for VM %s", timeout, vm.VM.Name)
+	start := time.Now()
+	for {
+		pending, err := getPendingGuestReadinessConditions(client, vdc, vm, conditions)
+		if err != nil {
+			return fmt.Errorf("error waiting for VM %s guest OS readiness: %s", vm.VM.Name, err)
+		}
+		if len(pending) == 0 {
+			log.Printf("[DEBUG] [VM guest readiness] VM %s is ready after %s", vm.VM.Name, time.Since(start))
+			return nil
+		}
+		if time.Since(start) > timeout {
+			return fmt.Errorf("timed out after %s waiting for VM %s: %s", timeout, vm.VM.Name, strings.Join(pending, ", "))
+		}
+		log.Printf("[TRACE] [VM guest readiness] VM %s pending conditions: %s", vm.VM.Name, strings.Join(pending, ", "))
+		time.Sleep(10 * time.Second)
+	}
+}
+
+// getPendingGuestReadinessConditions returns descriptions of 'wait_for_guest' conditions which are
+// not met yet. It returns an error for conditions which can no longer be met.
+func getPendingGuestReadinessConditions(client *govcd.Client, vdc *govcd.Vdc, vm *govcd.VM, conditions map[string]interface{}) ([]string, error) {
+	var pending []string
+
+	if conditions["vmware_tools_running"].(bool) {
+		vmRecord, err := getVmQueryRecord(vdc, vm)
+		if err != nil {
+			return nil, err
+		}
+		// VMware Tools report 'toolsOld' when running, but an upgrade is available
+		if vmRecord.VmToolsStatus != "toolsOk" && vmRecord.VmToolsStatus != "toolsOld" {
+			pending = append(pending, fmt.Sprintf("VMware Tools status is '%s'", vmRecord.VmToolsStatus))
+		}
+	}
+
+	if conditions["customization_complete"].(bool) {
+		customizationStatus, err := vm.GetGuestCustomizationStatus()
+		if err != nil {
+			return nil, err
+		}
+		if customizationStatus == "GC_FAILED" {
+			return nil, fmt.Errorf("guest customization failed: %s", getGuestCustomizationFailureDetails(client, vm))
+		}
+		if customizationStatus != "GC_COMPLETE" {
+			pending = append(pending, fmt.Sprintf("guest customization status is '%s'", customizationStatus))
+		}
+	}
+
+	return pending, nil
+}
+
+// checkGuestCustomizationResult waits for guest customization of a powered on VM to reach a final
+// state when 'fail_on_customization_error' is set. It returns an error containing failure details
+// reported by VCD when the final state is 'GC_FAILED'. The wait is limited by
+// 'wait_for_guest.timeout_seconds' (600 seconds when not set).
+func checkGuestCustomizationResult(d *schema.ResourceData, client *govcd.Client, vm *govcd.VM) error {
+	if !d.Get("fail_on_customization_error").(bool) {
+		return nil
+	}
+
+	vmStatus, err := vm.GetStatus()
+	if err != nil {
+		return fmt.Errorf("error getting VM %s status: %s", vm.VM.Name, err)
+	}
+	customizationSection, err := vm.GetGuestCustomizationSection()
+	if err != nil {
+		return fmt.Errorf("error retrieving guest customization section of VM %s: %s", vm.VM.Name, err)
+	}
+	// Guest customization only runs when it is enabled and the VM is powered on
+	if vmStatus != "POWERED_ON" || customizationSection.Enabled == nil || !*customizationSection.Enabled {
+		log.Printf("[DEBUG] VM %s is %s or has guest customization disabled. Skipping customization result check",
+			vm.VM.Name, vmStatus)
+		return nil
+	}
+
+	timeout := 600 * time.Second
+	waitForGuest := d.Get("wait_for_guest").([]interface{})
+	if len(waitForGuest) > 0 && waitForGuest[0] != nil {
+		timeout = time.Duration(waitForGuest[0].(map[string]interface{})["timeout_seconds"].(int)) * time.Second
+	}
+
+	start := time.Now()
+	for {
+		customizationStatus, err := vm.GetGuestCustomizationStatus()
+		if err != nil {
+			return fmt.Errorf("error retrieving guest customization status of VM %s: %s", vm.VM.Name, err)
+		}
+		switch customizationStatus {
+		case "GC_COMPLETE":
+			return nil
+		case "GC_FAILED":
+			return fmt.Errorf("guest customization of VM %s failed: %s", vm.VM.Name, getGuestCustomizationFailureDetails(client, vm))
+		}
+		if time.Since(start) > timeout {
+			return fmt.Errorf("timed out after %s waiting for guest customization of VM %s to finish: status is '%s'",
+				timeout, vm.VM.Name, customizationStatus)
+		}
+		log.Printf("[TRACE] VM %s guest customization status is %s", vm.VM.Name, customizationStatus)
+		time.Sleep(10 * time.Second)
+	}
+}
+
+const vmAuditTrailMinApiVersion = "33.0"
+
+// vmAuditTrailEvent contains fields of an audit trail event which are used to explain guest
+// customization failures
+type vmAuditTrailEvent struct {
+	EventId              string                 `json:"eventId,omitempty"`
+	EventType            string                 `json:"eventType,omitempty"`
+	EventStatus          string                 `json:"eventStatus,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Timestamp            string                 `json:"timestamp,omitempty"`
+	AdditionalProperties map[string]interface{} `json:"additionalProperties,omitempty"`
+}
+
+// getGuestCustomizationFailureDetails looks up the latest failed guest customization event of the VM
+// in the audit trail. It never fails as the details are only used to enrich an error message.
+func getGuestCustomizationFailureDetails(client *govcd.Client, vm *govcd.VM) string {
+	const noDetails = "no details available in VCD audit trail"
+
+	urlRef, err := openApiBuildEndpointWithVersion(client, vmAuditTrailMinApiVersion, types.OpenApiPathVersion1_0_0,
+		types.OpenApiEndpointAuditTrail)
+	if err != nil {
+		log.Printf("[DEBUG] unable to build audit trail endpoint: %s", err)
+		return noDetails
+	}
+
+	queryParameters := openApiFilterAnd("eventEntity.id=="+vm.VM.ID, "eventStatus==FAILURE")
+	queryParameters.Set("sortDesc", "timestamp")
+	events := []*vmAuditTrailEvent{{}}
+	err = client.OpenApiGetAllItems(vmAuditTrailMinApiVersion, urlRef, queryParameters, &events, nil)
+	if err != nil {
+		log.Printf("[DEBUG] unable to retrieve audit trail events of VM %s: %s", vm.VM.Name, err)
+		return noDetails
+	}
+
+	for _, event := range events {
+		if !strings.Contains(strings.ToLower(event.EventType), "customization") {
+			continue
+		}
+		details := []string{fmt.Sprintf("%s (%s)", event.EventType, event.Timestamp)}
+		if event.Description != "" {
+			details = append(details, event.Description)
+		}
+		propertyKeys := make([]string, 0, len(event.AdditionalProperties))
+		for key := range event.AdditionalProperties {
+			propertyKeys = append(propertyKeys, key)
+		}
+		sort.Strings(propertyKeys)
+		for _, key := range propertyKeys {
+			details = append(details, fmt.Sprintf("%s: %v", key, event.AdditionalProperties[key]))
+		}
+		return strings.Join(details, "; ")
+	}
+
+	return noDetails
+}
+
+// getVmQueryRecord retrieves the query record of a VM, which contains details (e.g. VMware Tools
+// status) not available in the VM structure
+func getVmQueryRecord(vdc *govcd.Vdc, vm *govcd.VM) (*types.QueryResultVMRecordType, error) {
+	vapp, err := vm.GetParentVApp()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving parent vApp: %s", err)
+	}
+	vmRecord, err := vdc.QueryVM(vapp.VApp.Name, vm.VM.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error querying VM record: %s", err)
+	}
+	return vmRecord.VM, nil
+}
+
 // isForcedCustomization checks "customization" block in resource and checks if the value of field "force"
 // is set to "true". It returns false if the value is not set or is set to false
 func isForcedCustomization(customizationBlock interface{}) bool {
@@ -2213,6 +2649,223 @@ func getGuestProperties(d *schema.ResourceData) (*types.ProductSectionList, erro
 	return vmProperties, nil
 }
 
+const (
+	cloudInitUserDataKey         = "guestinfo.userdata"
+	cloudInitUserDataEncodingKey = "guestinfo.userdata.encoding"
+	cloudInitMetaDataKey         = "guestinfo.metadata"
+	cloudInitMetaDataEncodingKey = "guestinfo.metadata.encoding"
+
+	// cloudInitNetworkMarker separates user provided meta data from network configuration which is
+	// embedded into meta data as cloud-init VMware data source expects it
+	cloudInitNetworkMarker = "# network configuration managed by terraform-provider-vcd"
+)
+
+// addCloudInitGuestProperties encodes 'cloud_init' block data and adds it to guest properties
+func addCloudInitGuestProperties(d *schema.ResourceData, properties *types.ProductSectionList) error {
+	cloudInitBlock := d.Get("cloud_init").([]interface{})
+	if len(cloudInitBlock) == 0 || cloudInitBlock[0] == nil {
+		return nil
+	}
+	cloudInit := cloudInitBlock[0].(map[string]interface{})
+	encoding := cloudInit["encoding"].(string)
+
+	cloudInitProperties := make(map[string]string)
+	if userData := cloudInit["user_data"].(string); userData != "" {
+		encoded, err := encodeCloudInitData(userData, encoding)
+		if err != nil {
+			return fmt.Errorf("error encoding cloud-init user data: %s", err)
+		}
+		cloudInitProperties[cloudInitUserDataKey] = encoded
+		cloudInitProperties[cloudInitUserDataEncodingKey] = encoding
+	}
+
+	metaData := buildCloudInitMetaData(cloudInit["meta_data"].(string), cloudInit["network_config"].(string))
+	if metaData != "" {
+		encoded, err := encodeCloudInitData(metaData, encoding)
+		if err != nil {
+			return fmt.Errorf("error encoding cloud-init meta data: %s", err)
+		}
+		cloudInitProperties[cloudInitMetaDataKey] = encoded
+		cloudInitProperties[cloudInitMetaDataEncodingKey] = encoding
+	}
+
+	keys := make([]string, 0, len(cloudInitProperties))
+	for key := range cloudInitProperties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, property := range properties.ProductSection.Property {
+			if property.Key == key {
+				return fmt.Errorf("guest property '%s' is managed by 'cloud_init' block and must not be set in 'guest_properties'", key)
+			}
+		}
+		log.Printf("[TRACE] Adding cloud-init guest property: key=%s", key)
+		properties.ProductSection.Property = append(properties.ProductSection.Property, &types.Property{
+			UserConfigurable: true,
+			Type:             "string",
+			Key:              key,
+			Label:            key,
+			Value:            &types.Value{Value: cloudInitProperties[key]},
+		})
+	}
+	return nil
+}
+
+// setCloudInitData decodes cloud-init guest properties into 'cloud_init' block and returns the
+// remaining guest properties
+func setCloudInitData(d *schema.ResourceData, properties *types.ProductSectionList) (*types.ProductSectionList, error) {
+	if properties == nil || properties.ProductSection == nil {
+		return properties, d.Set("cloud_init", nil)
+	}
+
+	cloudInitValues := make(map[string]string)
+	remainingProperties := &types.ProductSectionList{
+		ProductSection: &types.ProductSection{Info: properties.ProductSection.Info},
+	}
+	for _, property := range properties.ProductSection.Property {
+		switch property.Key {
+		case cloudInitUserDataKey, cloudInitUserDataEncodingKey, cloudInitMetaDataKey, cloudInitMetaDataEncodingKey:
+			if property.Value != nil {
+				cloudInitValues[property.Key] = property.Value.Value
+			}
+		default:
+			remainingProperties.ProductSection.Property = append(remainingProperties.ProductSection.Property, property)
+		}
+	}
+
+	cloudInit := map[string]interface{}{
+		"encoding": d.Get("cloud_init.0.encoding").(string),
+	}
+
+	if encodedUserData, ok := cloudInitValues[cloudInitUserDataKey]; ok {
+		encoding := normalizeCloudInitEncoding(cloudInitValues[cloudInitUserDataEncodingKey])
+		userData, err := decodeCloudInitData(encodedUserData, encoding)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding cloud-init user data: %s", err)
+		}
+		cloudInit["user_data"] = userData
+		cloudInit["encoding"] = encoding
+	}
+
+	if encodedMetaData, ok := cloudInitValues[cloudInitMetaDataKey]; ok {
+		encoding := normalizeCloudInitEncoding(cloudInitValues[cloudInitMetaDataEncodingKey])
+		metaData, err := decodeCloudInitData(encodedMetaData, encoding)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding cloud-init meta data: %s", err)
+		}
+		metaData, networkConfig, err := splitCloudInitMetaData(metaData)
+		if err != nil {
+			return nil, err
+		}
+		cloudInit["meta_data"] = metaData
+		cloudInit["network_config"] = networkConfig
+		cloudInit["encoding"] = encoding
+	}
+
+	return remainingProperties, d.Set("cloud_init", []interface{}{cloudInit})
+}
+
+// normalizeCloudInitEncoding converts short encoding names accepted by cloud-init to the ones used
+// in 'cloud_init.encoding'
+func normalizeCloudInitEncoding(encoding string) string {
+	switch encoding {
+	case "b64":
+		return "base64"
+	case "gz+b64":
+		return "gzip+base64"
+	}
+	return encoding
+}
+
+// encodeCloudInitData encodes data using 'base64' or 'gzip+base64' encoding
+func encodeCloudInitData(data, encoding string) (string, error) {
+	switch encoding {
+	case "base64":
+		return base64.StdEncoding.EncodeToString([]byte(data)), nil
+	case "gzip+base64":
+		var buffer bytes.Buffer
+		writer := gzip.NewWriter(&buffer)
+		_, err := writer.Write([]byte(data))
+		if err != nil {
+			return "", err
+		}
+		err = writer.Close()
+		if err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString(buffer.Bytes()), nil
+	}
+	return "", fmt.Errorf("unsupported encoding '%s'", encoding)
+}
+
+// decodeCloudInitData decodes data encoded with encodeCloudInitData. Data without encoding is
+// returned as is
+func decodeCloudInitData(data, encoding string) (string, error) {
+	switch encoding {
+	case "":
+		return data, nil
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return "", err
+		}
+		return string(decoded), nil
+	case "gzip+base64":
+		decoded, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return "", err
+		}
+		reader, err := gzip.NewReader(bytes.NewReader(decoded))
+		if err != nil {
+			return "", err
+		}
+		defer reader.Close()
+		unzipped, err := io.ReadAll(reader)
+		if err != nil {
+			return "", err
+		}
+		return string(unzipped), nil
+	}
+	return "", fmt.Errorf("unsupported encoding '%s'", encoding)
+}
+
+// buildCloudInitMetaData embeds network configuration into meta data using 'network' and
+// 'network.encoding' keys expected by cloud-init VMware data source
+func buildCloudInitMetaData(metaData, networkConfig string) string {
+	if networkConfig == "" {
+		return metaData
+	}
+	var builder strings.Builder
+	// A separating new line is always added so that meta data can be restored exactly
+	if metaData != "" {
+		builder.WriteString(metaData + "\n")
+	}
+	builder.WriteString(cloudInitNetworkMarker + "\n")
+	builder.WriteString("network: " + base64.StdEncoding.EncodeToString([]byte(networkConfig)) + "\n")
+	builder.WriteString("network.encoding: base64\n")
+	return builder.String()
+}
+
+// splitCloudInitMetaData reverses buildCloudInitMetaData
+func splitCloudInitMetaData(metaData string) (string, string, error) {
+	markerIndex := strings.Index(metaData, cloudInitNetworkMarker+"\n")
+	if markerIndex < 0 {
+		return metaData, "", nil
+	}
+
+	userMetaData := strings.TrimSuffix(metaData[:markerIndex], "\n")
+
+	networkLines := strings.Split(metaData[markerIndex+len(cloudInitNetworkMarker)+1:], "\n")
+	networkValue := strings.TrimPrefix(networkLines[0], "network: ")
+	networkConfig, err := base64.StdEncoding.DecodeString(networkValue)
+	if err != nil {
+		return "", "", fmt.Errorf("error decoding cloud-init network configuration: %s", err)
+	}
+	return userMetaData, string(networkConfig), nil
+}
+
 // setGuestProperties sets guest properties into state
 func setGuestProperties(d *schema.ResourceData, properties *types.ProductSectionList) error {
 	data := make(map[string]string)
@@ -2313,6 +2966,10 @@ func resourceVcdVappVmImport(ctx context.Context, d *schema.ResourceData, meta i
 	dSet(d, "org", orgName)
 	dSet(d, "vdc", vdcName)
 	dSet(d, "vapp_name", vappName)
+	// Defaults are not applied on import
+	dSet(d, "shutdown_behavior", "power_off")
+	dSet(d, "shutdown_timeout", 300)
+	dSet(d, "fail_on_customization_error", false)
 	d.SetId(vm.VM.ID)
 	return []*schema.ResourceData{d}, nil
 }
@@ -2606,6 +3263,10 @@ func addEmptyVm(d *schema.ResourceData, vcdClient *VCDClient, org *govcd.Org, vd
 	if err != nil {
 		return nil, err
 	}
+	err = addPlacementPolicy(d, vcdClient, recomposeVAppParamsForEmptyVm)
+	if err != nil {
+		return nil, err
+	}
 
 	var newVm *govcd.VM
 	var mediaReference *types.Reference
@@ -2685,7 +3346,7 @@ func addEmptyVm(d *schema.ResourceData, vcdClient *VCDClient, org *govcd.Org, vd
 		return nil, err
 	}
 
-	if d.Get("power_on").(bool) {
+	if isVmPowerOnRequested(d) {
 		log.Printf("[DEBUG] Powering on VM %s", newVm.VM.Name)
 		task, err := newVm.PowerOn()
 		if err != nil {
@@ -2696,6 +3357,11 @@ func addEmptyVm(d *schema.ResourceData, vcdClient *VCDClient, org *govcd.Org, vd
 			return nil, fmt.Errorf(errorCompletingTask, err)
 		}
 	}
+
+	err = ensureVmPowerState(d, &vcdClient.Client, newVm)
+	if err != nil {
+		return nil, err
+	}
 	return newVm, nil
 }
 
@@ -2733,6 +3399,56 @@ func addSizingPolicy(d *schema.ResourceData, vcdClient *VCDClient, recomposeVApp
 	return nil
 }
 
+func addPlacementPolicy(d *schema.ResourceData, vcdClient *VCDClient, recomposeVAppParamsForEmptyVm *types.RecomposeVAppParamsForEmptyVm) error {
+	value, ok := d.GetOk("placement_policy_id")
+	if !ok {
+		return nil
+	}
+	vcdComputePolicyHref, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointVdcComputePolicies)
+	if err != nil {
+		return fmt.Errorf("error constructing HREF for compute policy")
+	}
+	if recomposeVAppParamsForEmptyVm.CreateItem.ComputePolicy == nil {
+		recomposeVAppParamsForEmptyVm.CreateItem.ComputePolicy = &types.ComputePolicy{}
+	}
+	recomposeVAppParamsForEmptyVm.CreateItem.ComputePolicy.VmPlacementPolicy = &types.Reference{HREF: vcdComputePolicyHref.String() + value.(string)}
+	return nil
+}
+
+// updateVmComputePolicies sets both the sizing and the placement policy of the VM. Unlike vm.UpdateComputePolicy,
+// which only handles the sizing policy, an empty ID removes the corresponding policy from the VM
+func updateVmComputePolicies(vcdClient *VCDClient, vm *govcd.VM, sizingPolicyId, placementPolicyId string) error {
+	vcdComputePolicyHref, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointVdcComputePolicies)
+	if err != nil {
+		return fmt.Errorf("error constructing HREF for compute policy")
+	}
+
+	computePolicy := &types.ComputePolicy{}
+	if sizingPolicyId != "" {
+		computePolicy.VmSizingPolicy = &types.Reference{HREF: vcdComputePolicyHref.String() + sizingPolicyId}
+	}
+	if placementPolicyId != "" {
+		computePolicy.VmPlacementPolicy = &types.Reference{HREF: vcdComputePolicyHref.String() + placementPolicyId}
+	}
+
+	task, err := vcdClient.Client.ExecuteTaskRequest(vm.VM.HREF+"/action/reconfigureVm", http.MethodPost,
+		types.MimeVM, "error updating VM compute policies: %s", &types.Vm{
+			Xmlns:         types.XMLNamespaceVCloud,
+			Ovf:           types.XMLNamespaceOVF,
+			Name:          vm.VM.Name,
+			Description:   vm.VM.Description,
+			ComputePolicy: computePolicy,
+		})
+	if err != nil {
+		return err
+	}
+	err = task.WaitTaskCompletion()
+	if err != nil {
+		return err
+	}
+	return vm.Refresh()
+}
+
 // handleExposeHardwareVirtualization toggles hardware virtualization according `expose_hardware_virtualization` field value.
 func handleExposeHardwareVirtualization(d *schema.ResourceData, newVm *govcd.VM) error {
 	// The below operation assumes VM is powered off and does not check for it because VM is being
@@ -2751,3 +3467,148 @@ func handleExposeHardwareVirtualization(d *schema.ResourceData, newVm *govcd.VM)
 	}
 	return nil
 }
+
+// isVmPowerOnRequested checks if the VM must be running. 'power_state' takes precedence over
+// 'power_on'. 'suspended' requires a running VM too, as only a running VM can be suspended. Callers
+// must not power on a VM which is already suspended when 'power_state' is 'suspended'.
+func isVmPowerOnRequested(d *schema.ResourceData) bool {
+	switch d.Get("power_state").(string) {
+	case "on", "suspended":
+		return true
+	case "off":
+		return false
+	}
+	return d.Get("power_on").(bool)
+}
+
+// ensureVmPowerState powers off or suspends the VM when 'power_state' is 'off' or 'suspended'.
+// Powering on is handled together with guest customization in resourceVcdVAppVmUpdateExecute.
+func ensureVmPowerState(d *schema.ResourceData, client *govcd.Client, vm *govcd.VM) error {
+	powerState := d.Get("power_state").(string)
+	if powerState != "off" && powerState != "suspended" {
+		return nil
+	}
+
+	vmStatus, err := vm.GetStatus()
+	if err != nil {
+		return fmt.Errorf("error getting VM %s status: %s", vm.VM.Name, err)
+	}
+
+	if powerState == "off" && vmStatus != "POWERED_OFF" {
+		log.Printf("[DEBUG] Powering off VM %s to match power_state. Current state %s", vm.VM.Name, vmStatus)
+		return undeployVm(d, client, vm)
+	}
+
+	if powerState == "suspended" && vmStatus != "SUSPENDED" {
+		log.Printf("[DEBUG] Suspending VM %s to match power_state. Current state %s", vm.VM.Name, vmStatus)
+		task, err := executeVmPowerAction(client, vm, "suspend")
+		if err != nil {
+			return fmt.Errorf("error suspending VM %s: %s", vm.VM.Name, err)
+		}
+		err = task.WaitTaskCompletion()
+		if err != nil {
+			return fmt.Errorf("error waiting for suspend task for VM %s: %s", vm.VM.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// undeployVm un-deploys the VM honoring 'shutdown_behavior'. When it is 'guest_shutdown', the guest
+// OS is asked to shut down first and, if the VM does not power off within 'shutdown_timeout'
+// seconds, the un-deploy operation powers it off.
+func undeployVm(d *schema.ResourceData, client *govcd.Client, vm *govcd.VM) error {
+	if d.Get("shutdown_behavior").(string) == "guest_shutdown" {
+		timeout := time.Duration(d.Get("shutdown_timeout").(int)) * time.Second
+		err := shutdownVmGuest(client, vm, timeout)
+		if err != nil {
+			log.Printf("[DEBUG] guest OS shutdown of VM %s did not succeed, falling back to power off: %s", vm.VM.Name, err)
+		}
+	}
+
+	deployed, err := vm.IsDeployed()
+	if err != nil {
+		return fmt.Errorf("error checking if VM %s is deployed: %s", vm.VM.Name, err)
+	}
+	if !deployed {
+		return nil
+	}
+
+	task, err := vm.Undeploy()
+	if err != nil {
+		return fmt.Errorf("error triggering undeploy for VM %s: %s", vm.VM.Name, err)
+	}
+	err = task.WaitTaskCompletion()
+	if err != nil {
+		return fmt.Errorf("error waiting for undeploy task for VM %s: %s", vm.VM.Name, err)
+	}
+	return nil
+}
+
+// shutdownVmGuest triggers a guest OS shutdown and waits up to timeout for the VM to be powered
+// off. The shutdown task is cancelled when the timeout is reached.
+func shutdownVmGuest(client *govcd.Client, vm *govcd.VM, timeout time.Duration) error {
+	vmStatus, err := vm.GetStatus()
+	if err != nil {
+		return fmt.Errorf("error getting VM status: %s", err)
+	}
+	if vmStatus != "POWERED_ON" {
+		return fmt.Errorf("guest OS shutdown requires a powered on VM, but VM is %s", vmStatus)
+	}
+
+	task, err := executeVmPowerAction(client, vm, "shutdown")
+	if err != nil {
+		return err
+	}
+
+	return waitForGuestShutdown(task, vm.GetStatus, timeout)
+}
+
+// waitForGuestShutdown polls getStatus until a VM or vApp is powered off while the guest OS shutdown
+// task runs. The task is cancelled if the timeout is reached first.
+func waitForGuestShutdown(task govcd.Task, getStatus func() (string, error), timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		status, err := getStatus()
+		if err != nil {
+			return fmt.Errorf("error getting status: %s", err)
+		}
+		if status == "POWERED_OFF" || status == "RESOLVED" {
+			return nil
+		}
+
+		err = task.Refresh()
+		if err == nil && (task.Task.Status == "error" || task.Task.Status == "aborted") {
+			return fmt.Errorf("guest OS shutdown task finished with status '%s'", task.Task.Status)
+		}
+
+		if time.Now().After(deadline) {
+			err = task.CancelTask()
+			if err != nil {
+				log.Printf("[DEBUG] unable to cancel guest OS shutdown task %s: %s", task.Task.HREF, err)
+			}
+			return fmt.Errorf("not powered off within %s (state %s)", timeout, status)
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// executeVmPowerAction triggers a power action (e.g. 'shutdown', 'suspend') which has no
+// dedicated method for VMs in go-vcloud-director
+func executeVmPowerAction(client *govcd.Client, vm *govcd.VM, action string) (govcd.Task, error) {
+	return client.ExecuteTaskRequest(vm.VM.HREF+"/power/action/"+action, http.MethodPost,
+		"", "error executing VM power action "+action+": %s", nil)
+}
+
+// vmStatusToPowerState converts a VM status (e.g. 'POWERED_ON') to the 'power_state' format
+func vmStatusToPowerState(vmStatus string) string {
+	switch vmStatus {
+	case "POWERED_ON":
+		return "on"
+	case "POWERED_OFF":
+		return "off"
+	case "SUSPENDED":
+		return "suspended"
+	}
+	return strings.ToLower(vmStatus)
+}
@@ -0,0 +1,96 @@
+//go:build vapp || vm || ALL || functional
+// +build vapp vm ALL functional
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccVcdVmCloudInit checks that 'cloud_init' data is stored as guest properties and read back
+// in decoded form without leaking into 'guest_properties'
+func TestAccVcdVmCloudInit(t *testing.T) {
+	preTestChecks(t)
+
+	var params = StringMap{
+		"Org":      testConfig.VCD.Org,
+		"Vdc":      testConfig.VCD.Vdc,
+		"VmName":   t.Name() + "-vm",
+		"Encoding": "base64",
+		"UserData": "#cloud-config\\nhostname: step1\\n",
+		"FuncName": t.Name(),
+		"Tags":     "vm",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText1 := templateFill(testAccVcdVmCloudInit, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "-step2"
+	params["Encoding"] = "gzip+base64"
+	params["UserData"] = "#cloud-config\\nhostname: step2\\n"
+	configText2 := templateFill(testAccVcdVmCloudInit, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_vm.cloud"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdStandaloneVmDestroy(params["VmName"].(string), params["Org"].(string), params["Vdc"].(string)),
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "cloud_init.0.user_data", "#cloud-config\nhostname: step1\n"),
+					resource.TestCheckResourceAttr(resourceName, "cloud_init.0.meta_data", "instance-id: cloud-init-vm\n"),
+					resource.TestCheckResourceAttr(resourceName, "cloud_init.0.network_config", "version: 2\n"),
+					resource.TestCheckResourceAttr(resourceName, "cloud_init.0.encoding", "base64"),
+					resource.TestCheckResourceAttr(resourceName, "guest_properties.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "guest_properties.guest.hostname", "cloud-init-vm"),
+				),
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "cloud_init.0.user_data", "#cloud-config\nhostname: step2\n"),
+					resource.TestCheckResourceAttr(resourceName, "cloud_init.0.encoding", "gzip+base64"),
+					resource.TestCheckResourceAttr(resourceName, "guest_properties.%", "1"),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdVmCloudInit = `
+resource "vcd_vm" "cloud" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  power_on         = false
+  name             = "{{.VmName}}"
+  memory           = 512
+  cpus             = 1
+  cpu_cores        = 1
+  os_type          = "sles11_64Guest"
+  hardware_version = "vmx-14"
+  computer_name    = "cloud-init"
+
+  guest_properties = {
+    "guest.hostname" = "cloud-init-vm"
+  }
+
+  cloud_init {
+    user_data      = "{{.UserData}}"
+    meta_data      = "instance-id: cloud-init-vm\n"
+    network_config = "version: 2\n"
+    encoding       = "{{.Encoding}}"
+  }
+}
+`
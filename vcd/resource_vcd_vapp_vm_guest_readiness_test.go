@@ -0,0 +1,87 @@
+//go:build vapp || vm || ALL || functional
+// +build vapp vm ALL functional
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccVcdVAppVmWaitForGuest checks that VM creation waits for VMware Tools and guest
+// customization, and that successful customization is reported
+func TestAccVcdVAppVmWaitForGuest(t *testing.T) {
+	preTestChecks(t)
+
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"Vdc":         testConfig.VCD.Vdc,
+		"Catalog":     testSuiteCatalogName,
+		"CatalogItem": testSuiteCatalogOVAItem,
+		"VappName":    t.Name() + "-vapp",
+		"VmName":      t.Name() + "-vm",
+		"FuncName":    t.Name(),
+		"Tags":        "vapp vm",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText := templateFill(testAccVcdVAppVmWaitForGuest, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_vapp_vm.ready"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppVmDestroy(params["VappName"].(string)),
+		Steps: []resource.TestStep{
+			{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "wait_for_guest.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "wait_for_guest.0.timeout_seconds", "900"),
+					resource.TestCheckResourceAttr(resourceName, "customization_status", "GC_COMPLETE"),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdVAppVmWaitForGuest = `
+resource "vcd_vapp" "ready" {
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+  name = "{{.VappName}}"
+}
+
+resource "vcd_vapp_vm" "ready" {
+  org           = "{{.Org}}"
+  vdc           = "{{.Vdc}}"
+  vapp_name     = vcd_vapp.ready.name
+  name          = "{{.VmName}}"
+  computer_name = "ready"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 1024
+  cpus          = 1
+  cpu_cores     = 1
+
+  customization {
+    enabled = true
+  }
+
+  fail_on_customization_error = true
+
+  wait_for_guest {
+    vmware_tools_running   = true
+    customization_complete = true
+    timeout_seconds        = 900
+  }
+}
+`
@@ -0,0 +1,82 @@
+//go:build unit || ALL
+// +build unit ALL
+
+package vcd
+
+import (
+	"testing"
+)
+
+func TestVmStatusToPowerState(t *testing.T) {
+	tests := map[string]string{
+		"POWERED_ON":            "on",
+		"POWERED_OFF":           "off",
+		"SUSPENDED":             "suspended",
+		"PARTIALLY_POWERED_OFF": "partially_powered_off",
+	}
+	for status, expected := range tests {
+		if powerState := vmStatusToPowerState(status); powerState != expected {
+			t.Errorf("status %s: expected '%s', got '%s'", status, expected, powerState)
+		}
+	}
+	if powerState := vappStatusToPowerState("RESOLVED"); powerState != "off" {
+		t.Errorf("status RESOLVED: expected 'off', got '%s'", powerState)
+	}
+}
+
+func TestCloudInitDataEncoding(t *testing.T) {
+	data := "#cloud-config\nusers:\n  - name: admin\n"
+	for _, encoding := range []string{"base64", "gzip+base64"} {
+		encoded, err := encodeCloudInitData(data, encoding)
+		if err != nil {
+			t.Fatalf("error encoding with %s: %s", encoding, err)
+		}
+		if encoded == data {
+			t.Errorf("data was not encoded with %s", encoding)
+		}
+		decoded, err := decodeCloudInitData(encoded, encoding)
+		if err != nil {
+			t.Fatalf("error decoding with %s: %s", encoding, err)
+		}
+		if decoded != data {
+			t.Errorf("%s: expected '%s', got '%s'", encoding, data, decoded)
+		}
+	}
+
+	_, err := encodeCloudInitData(data, "unknown")
+	if err == nil {
+		t.Errorf("expected error for unknown encoding")
+	}
+	if normalizeCloudInitEncoding("gz+b64") != "gzip+base64" || normalizeCloudInitEncoding("b64") != "base64" {
+		t.Errorf("short encoding names were not normalized")
+	}
+}
+
+func TestCloudInitMetaData(t *testing.T) {
+	tests := []struct {
+		name          string
+		metaData      string
+		networkConfig string
+	}{
+		{name: "meta data only", metaData: "instance-id: vm-1\n"},
+		{name: "network only", networkConfig: "version: 2\n"},
+		{name: "both", metaData: "instance-id: vm-1\nlocal-hostname: vm-1\n", networkConfig: "version: 2\nethernets: {}\n"},
+		{name: "meta data without trailing new line", metaData: "instance-id: vm-1", networkConfig: "version: 2"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			built := buildCloudInitMetaData(test.metaData, test.networkConfig)
+			metaData, networkConfig, err := splitCloudInitMetaData(built)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if metaData != test.metaData {
+				t.Errorf("expected meta data '%s', got '%s'", test.metaData, metaData)
+			}
+			if networkConfig != test.networkConfig {
+				t.Errorf("expected network config '%s', got '%s'", test.networkConfig, networkConfig)
+			}
+		})
+	}
+}
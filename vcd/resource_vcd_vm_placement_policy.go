@@ -0,0 +1,329 @@
+package vcd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// vdcComputePolicyGroupReference is an alias of the anonymous reference type used by types.VdcComputePolicy
+// for VM groups and logical VM groups
+type vdcComputePolicyGroupReference = struct {
+	Name string `json:"name,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+func resourceVcdVmPlacementPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVmPlacementPolicyCreate,
+		ReadContext:   resourceVmPlacementPolicyRead,
+		UpdateContext: resourceVmPlacementPolicyUpdate,
+		DeleteContext: resourceVmPlacementPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVmPlacementPolicyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the VM placement policy",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the VM placement policy",
+			},
+			"provider_vdc_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the Provider VDC to which the VM placement policy belongs",
+			},
+			"vm_group_ids": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Description:  "IDs of the VM groups of the Provider VDC where the VMs with this policy are placed",
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: []string{"vm_group_ids", "logical_vm_group_ids"},
+			},
+			"logical_vm_group_ids": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Description:  "IDs of the logical VM groups of the Provider VDC where the VMs with this policy are placed",
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: []string{"vm_group_ids", "logical_vm_group_ids"},
+			},
+		},
+	}
+}
+
+func resourceVmPlacementPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	policyName := d.Get("name").(string)
+	log.Printf("[TRACE] VM placement policy creation initiated: %s", policyName)
+
+	vcdClient := meta.(*VCDClient)
+
+	if !vcdClient.Client.IsSysAdmin {
+		return diag.Errorf("functionality requires System administrator privileges")
+	}
+
+	providerVdcName := d.Get("provider_vdc_name").(string)
+	providerVdcResults, err := govcd.QueryProviderVdcByName(vcdClient.VCDClient, providerVdcName)
+	if err != nil {
+		return diag.Errorf("error retrieving Provider VDC %s: %s", providerVdcName, err)
+	}
+	if len(providerVdcResults) == 0 {
+		return diag.Errorf("no Provider VDC found with name %s", providerVdcName)
+	}
+
+	params := &types.VdcComputePolicy{
+		Name:         policyName,
+		Description:  d.Get("description").(string),
+		PvdcID:       normalizeId("urn:vcloud:providervdc:", extractUuid(providerVdcResults[0].HREF)),
+		IsSizingOnly: false,
+	}
+	setVmPlacementPolicyGroups(d, params)
+
+	log.Printf("[DEBUG] Creating VM placement policy: %#v", params)
+
+	createdVmPlacementPolicy, err := vcdClient.Client.CreateVdcComputePolicy(params)
+	if err != nil {
+		log.Printf("[DEBUG] Error creating VM placement policy: %s", err)
+		return diag.Errorf("error creating VM placement policy %s: %s", policyName, err)
+	}
+
+	d.SetId(createdVmPlacementPolicy.VdcComputePolicy.ID)
+	log.Printf("[TRACE] VM placement policy created: %#v", createdVmPlacementPolicy.VdcComputePolicy)
+
+	return resourceVmPlacementPolicyRead(ctx, d, meta)
+}
+
+// setVmPlacementPolicyGroups fills the VM group references of the policy from the resource configuration
+func setVmPlacementPolicyGroups(d *schema.ResourceData, policy *types.VdcComputePolicy) {
+	var vmGroups []vdcComputePolicyGroupReference
+	for _, id := range convertSchemaSetToSliceOfStrings(d.Get("vm_group_ids").(*schema.Set)) {
+		vmGroups = append(vmGroups, vdcComputePolicyGroupReference{ID: id})
+	}
+	policy.NamedVMGroups = nil
+	if len(vmGroups) > 0 {
+		policy.NamedVMGroups = [][]vdcComputePolicyGroupReference{vmGroups}
+	}
+
+	policy.LogicalVMGroupReferences = nil
+	for _, id := range convertSchemaSetToSliceOfStrings(d.Get("logical_vm_group_ids").(*schema.Set)) {
+		policy.LogicalVMGroupReferences = append(policy.LogicalVMGroupReferences, vdcComputePolicyGroupReference{ID: id})
+	}
+}
+
+func resourceVmPlacementPolicyRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	policyName := d.Get("name").(string)
+	log.Printf("[TRACE] VM placement policy read initiated: %s", policyName)
+
+	vcdClient := meta.(*VCDClient)
+
+	policy, err := vcdClient.Client.GetVdcComputePolicyById(d.Id())
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] Unable to find VM placement policy %s. Removing from tfstate", policyName)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("unable to find VM placement policy %s, err: %s", policyName, err)
+	}
+
+	dSet(d, "name", policy.VdcComputePolicy.Name)
+	dSet(d, "description", policy.VdcComputePolicy.Description)
+
+	var vmGroupIds []string
+	for _, vmGroups := range policy.VdcComputePolicy.NamedVMGroups {
+		for _, vmGroup := range vmGroups {
+			vmGroupIds = append(vmGroupIds, vmGroup.ID)
+		}
+	}
+	err = d.Set("vm_group_ids", convertStringsToTypeSet(vmGroupIds))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var logicalVmGroupIds []string
+	for _, logicalVmGroup := range policy.VdcComputePolicy.LogicalVMGroupReferences {
+		logicalVmGroupIds = append(logicalVmGroupIds, logicalVmGroup.ID)
+	}
+	err = d.Set("logical_vm_group_ids", convertStringsToTypeSet(logicalVmGroupIds))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The Provider VDC name is only retrieved when missing, which happens after import
+	if d.Get("provider_vdc_name").(string) == "" && policy.VdcComputePolicy.PvdcID != "" {
+		providerVdcs, err := vcdClient.QueryProviderVdcs()
+		if err != nil {
+			return diag.Errorf("error retrieving Provider VDCs: %s", err)
+		}
+		for _, providerVdc := range providerVdcs {
+			if haveSameUuid(providerVdc.HREF, policy.VdcComputePolicy.PvdcID) {
+				dSet(d, "provider_vdc_name", providerVdc.Name)
+				break
+			}
+		}
+	}
+
+	log.Printf("[TRACE] VM placement policy read completed: %s", policyName)
+	return nil
+}
+
+func resourceVmPlacementPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	policyName := d.Get("name").(string)
+	log.Printf("[TRACE] VM placement policy update initiated: %s", policyName)
+
+	vcdClient := meta.(*VCDClient)
+
+	policy, err := vcdClient.Client.GetVdcComputePolicyById(d.Id())
+	if err != nil {
+		log.Printf("[DEBUG] Unable to find VM placement policy %s", policyName)
+		return diag.Errorf("unable to find VM placement policy %s, error:  %s", policyName, err)
+	}
+
+	policy.VdcComputePolicy.Name = policyName
+	policy.VdcComputePolicy.Description = d.Get("description").(string)
+	if d.HasChanges("vm_group_ids", "logical_vm_group_ids") {
+		setVmPlacementPolicyGroups(d, policy.VdcComputePolicy)
+	}
+
+	_, err = policy.Update()
+	if err != nil {
+		log.Printf("[DEBUG] Error updating VM placement policy %s with error %s", policyName, err)
+		return diag.Errorf("error updating VM placement policy %s, err: %s", policyName, err)
+	}
+
+	log.Printf("[TRACE] VM placement policy update completed: %s", policyName)
+	return resourceVmPlacementPolicyRead(ctx, d, meta)
+}
+
+func resourceVmPlacementPolicyDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	policyName := d.Get("name").(string)
+	log.Printf("[TRACE] VM placement policy delete started: %s", policyName)
+
+	vcdClient := meta.(*VCDClient)
+
+	if !vcdClient.Client.IsSysAdmin {
+		return diag.Errorf("functionality requires System administrator privileges")
+	}
+
+	policy, err := vcdClient.Client.GetVdcComputePolicyById(d.Id())
+	if err != nil {
+		log.Printf("[DEBUG] Unable to find VM placement policy %s. Removing from tfstate", policyName)
+		d.SetId("")
+		return nil
+	}
+
+	err = policy.Delete()
+	if err != nil {
+		log.Printf("[DEBUG] Error removing VM placement policy %s, err: %s", policyName, err)
+		return diag.Errorf("error removing VM placement policy %s, err: %s", policyName, err)
+	}
+
+	log.Printf("[TRACE] VM placement policy delete completed: %s", policyName)
+	return nil
+}
+
+var errHelpVmPlacementPolicyImport = fmt.Errorf(`resource id must be specified in one of these formats:
+'vm-placement-policy-name', 'vm-placement-policy-id' or 'list@' to get a list of VM placement policies with their IDs`)
+
+// resourceVmPlacementPolicyImport is responsible for importing the resource.
+// The following steps happen as part of import
+// 1. The user supplies `terraform import _resource_name_ _the_id_string_` command
+// 2. `_the_id_string_` contains the name or the ID of the VM placement policy
+// 3. The function looks up the object
+// 4. If the lookup succeeds it sets the ID field for `_resource_name_` resource in state file
+// (the resource must be already defined in .tf config otherwise `terraform import` will complain)
+// 5. `terraform refresh` is being implicitly launched. The Read method looks up all other fields
+// based on the known ID of object.
+//
+// Example resource name (_resource_name_): vcd_vm_placement_policy.my_existing_policy_name
+// Example import path (_the_id_string_): my_existing_vm_placement_policy_id
+// Example list path (_the_id_string_): list@
+func resourceVmPlacementPolicyImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Printf("[DEBUG] importing VM placement policy resource with provided id %s", d.Id())
+
+	if strings.Contains(d.Id(), "list@") {
+		return listVmPlacementPoliciesForImport(meta)
+	}
+
+	vcdClient := meta.(*VCDClient)
+	policyId := d.Id()
+
+	policy, err := vcdClient.Client.GetVdcComputePolicyById(policyId)
+	if err != nil {
+		queryParams := url.Values{}
+		queryParams.Add("filter", "name=="+policyId)
+		policies, err := vcdClient.Client.GetAllVdcComputePolicies(queryParams)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find VM placement policy %s, err: %s", policyId, err)
+		}
+		if len(policies) != 1 {
+			return nil, fmt.Errorf("unable to find unique VM placement policy %s, found %d", policyId, len(policies))
+		}
+		policy = policies[0]
+	}
+	if policy.VdcComputePolicy.IsSizingOnly {
+		return nil, fmt.Errorf("compute policy %s is a VM sizing policy, use vcd_vm_sizing_policy to import it", policyId)
+	}
+
+	dSet(d, "name", policy.VdcComputePolicy.Name)
+	d.SetId(policy.VdcComputePolicy.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func listVmPlacementPoliciesForImport(meta interface{}) ([]*schema.ResourceData, error) {
+	vcdClient := meta.(*VCDClient)
+
+	buf := new(bytes.Buffer)
+	_, err := fmt.Fprintln(buf, "Retrieving all VM placement policies")
+	if err != nil {
+		logForScreen("vcd_vm_placement_policy", fmt.Sprintf("error writing to buffer: %s", err))
+	}
+	policies, err := vcdClient.Client.GetAllVdcComputePolicies(nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve VM placement policies: %s", err)
+	}
+
+	writer := tabwriter.NewWriter(buf, 0, 8, 1, '\t', tabwriter.AlignRight)
+
+	_, err = fmt.Fprintln(writer, "No\tID\tName\t")
+	if err != nil {
+		logForScreen("vcd_vm_placement_policy", fmt.Sprintf("error writing to buffer: %s", err))
+	}
+	_, err = fmt.Fprintln(writer, "--\t--\t----\t")
+	if err != nil {
+		logForScreen("vcd_vm_placement_policy", fmt.Sprintf("error writing to buffer: %s", err))
+	}
+
+	index := 0
+	for _, policy := range policies {
+		if policy.VdcComputePolicy.IsSizingOnly || policy.VdcComputePolicy.IsAutoGenerated {
+			continue
+		}
+		index++
+		_, err = fmt.Fprintf(writer, "%d\t%s\t%s \n", index, policy.VdcComputePolicy.ID, policy.VdcComputePolicy.Name)
+		if err != nil {
+			logForScreen("vcd_vm_placement_policy", fmt.Sprintf("error writing to buffer: %s", err))
+		}
+	}
+	err = writer.Flush()
+	if err != nil {
+		logForScreen("vcd_vm_placement_policy", fmt.Sprintf("error flushing buffer: %s", err))
+	}
+
+	return nil, fmt.Errorf("resource was not imported! %s\n%s", errHelpVmPlacementPolicyImport, buf.String())
+}
@@ -0,0 +1,103 @@
+//go:build vdc || ALL || functional
+// +build vdc ALL functional
+
+package vcd
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccVcdVmPlacementPolicy checks that a VM placement policy can be created, updated and imported
+func TestAccVcdVmPlacementPolicy(t *testing.T) {
+	preTestChecks(t)
+	if !usingSysAdmin() {
+		t.Skip(t.Name() + " requires system admin privileges")
+	}
+	if testConfig.VCD.ProviderVdc.Name == "" || testConfig.VCD.ProviderVdc.VmGroupId == "" {
+		t.Skip("Variables providerVdc.Name and providerVdc.vmGroupId must be set to run " + t.Name())
+	}
+
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"Vdc":         testConfig.VCD.Vdc,
+		"PolicyName":  t.Name(),
+		"Description": t.Name() + "-description",
+		"ProviderVdc": testConfig.VCD.ProviderVdc.Name,
+		"VmGroupId":   testConfig.VCD.ProviderVdc.VmGroupId,
+		"FuncName":    t.Name(),
+		"Tags":        "vdc",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText1 := templateFill(testAccVcdVmPlacementPolicy, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "-update"
+	params["Description"] = t.Name() + "-description-updated"
+	configText2 := templateFill(testAccVcdVmPlacementPolicy, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_vm_placement_policy.policy"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVmPlacementPolicyDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile(`^urn:vcloud:vdcComputePolicy:`)),
+					resource.TestCheckResourceAttr(resourceName, "name", t.Name()),
+					resource.TestCheckResourceAttr(resourceName, "description", t.Name()+"-description"),
+					resource.TestCheckResourceAttr(resourceName, "vm_group_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "vm_group_ids.*", testConfig.VCD.ProviderVdc.VmGroupId),
+				),
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", t.Name()+"-description-updated"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     t.Name(),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+func testAccCheckVmPlacementPolicyDestroyed(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*VCDClient)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vcd_vm_placement_policy" {
+			continue
+		}
+		_, err := conn.Client.GetVdcComputePolicyById(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("VM placement policy %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+const testAccVcdVmPlacementPolicy = `
+resource "vcd_vm_placement_policy" "policy" {
+  name              = "{{.PolicyName}}"
+  description       = "{{.Description}}"
+  provider_vdc_name = "{{.ProviderVdc}}"
+  vm_group_ids      = ["{{.VmGroupId}}"]
+}
+`
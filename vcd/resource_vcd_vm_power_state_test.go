@@ -0,0 +1,223 @@
+//go:build vapp || vm || ALL || functional
+// +build vapp vm ALL functional
+
+package vcd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// TestAccVcdVmPowerState checks that 'power_state' is enforced for vApps, vApp VMs and standalone
+// VMs. Empty VMs have no VMware Tools, therefore 'shutdown_behavior=guest_shutdown' always falls back
+// to hard power off after 'shutdown_timeout'
+func TestAccVcdVmPowerState(t *testing.T) {
+	preTestChecks(t)
+
+	var params = StringMap{
+		"Org":          testConfig.VCD.Org,
+		"Vdc":          testConfig.VCD.Vdc,
+		"FuncName":     t.Name(),
+		"Tags":         "vapp vm",
+		"VappName":     t.Name() + "-vapp",
+		"VappVmName":   t.Name() + "-vapp-vm",
+		"VmName":       t.Name() + "-vm",
+		"PowerState":   "off",
+		"VmPowerState": "off",
+		"MetadataStep": "initial",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText1 := templateFill(testAccVcdVmPowerState, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "-step2"
+	params["PowerState"] = "on"
+	params["VmPowerState"] = "on"
+	configText2 := templateFill(testAccVcdVmPowerState, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	params["FuncName"] = t.Name() + "-step3"
+	params["PowerState"] = "suspended"
+	params["VmPowerState"] = "suspended"
+	configText3 := templateFill(testAccVcdVmPowerState, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 3: %s", configText3)
+
+	params["FuncName"] = t.Name() + "-step3-update"
+	params["MetadataStep"] = "suspended"
+	configText3Update := templateFill(testAccVcdVmPowerState, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 3 update: %s", configText3Update)
+
+	params["FuncName"] = t.Name() + "-step4"
+	params["PowerState"] = "off"
+	params["VmPowerState"] = "off"
+	configText4 := templateFill(testAccVcdVmPowerState, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 4: %s", configText4)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	var suspendedSince time.Time
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckVcdVAppDestroy,
+			testAccCheckVcdStandaloneVmDestroy(params["VmName"].(string), params["Org"].(string), params["Vdc"].(string)),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_vapp.power", "power_state", "off"),
+					resource.TestCheckResourceAttr("vcd_vapp_vm.power", "power_state", "off"),
+					resource.TestCheckResourceAttr("vcd_vm.power", "power_state", "off"),
+					resource.TestCheckResourceAttr("vcd_vm.power", "shutdown_behavior", "guest_shutdown"),
+				),
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_vapp.power", "power_state", "on"),
+					resource.TestCheckResourceAttr("vcd_vapp.power", "status_text", "POWERED_ON"),
+					resource.TestCheckResourceAttr("vcd_vapp_vm.power", "power_state", "on"),
+					resource.TestCheckResourceAttr("vcd_vm.power", "power_state", "on"),
+				),
+			},
+			{
+				Config: configText3,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_vapp.power", "power_state", "suspended"),
+					resource.TestCheckResourceAttr("vcd_vapp_vm.power", "power_state", "suspended"),
+					resource.TestCheckResourceAttr("vcd_vm.power", "power_state", "suspended"),
+					testAccRecordTime(&suspendedSince),
+				),
+			},
+			{
+				// Updating a field which doesn't need a power cycle must not resume suspended VMs
+				Config: configText3Update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_vapp_vm.power", "power_state", "suspended"),
+					resource.TestCheckResourceAttr("vcd_vapp_vm.power", "metadata.step", "suspended"),
+					resource.TestCheckResourceAttr("vcd_vm.power", "power_state", "suspended"),
+					resource.TestCheckResourceAttr("vcd_vm.power", "metadata.step", "suspended"),
+					testAccCheckVmNotPoweredOnSince("vcd_vapp_vm.power", &suspendedSince),
+					testAccCheckVmNotPoweredOnSince("vcd_vm.power", &suspendedSince),
+				),
+			},
+			{
+				// Suspended VMs are stopped using guest shutdown with power off fallback
+				Config: configText4,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_vapp.power", "power_state", "off"),
+					resource.TestCheckResourceAttr("vcd_vapp_vm.power", "power_state", "off"),
+					resource.TestCheckResourceAttr("vcd_vm.power", "power_state", "off"),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+// testAccRecordTime stores the current time, so that later steps can check what happened since then
+func testAccRecordTime(since *time.Time) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		*since = time.Now().UTC()
+		return nil
+	}
+}
+
+// testAccCheckVmNotPoweredOnSince checks in the VCD audit trail that the VM was neither deployed nor
+// powered on after the given time
+func testAccCheckVmNotPoweredOnSince(resourceName string, since *time.Time) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+		client := &testAccProvider.Meta().(*VCDClient).Client
+
+		urlRef, err := openApiBuildEndpointWithVersion(client, vmAuditTrailMinApiVersion, types.OpenApiPathVersion1_0_0,
+			types.OpenApiEndpointAuditTrail)
+		if err != nil {
+			return err
+		}
+		queryParameters := openApiFilterAnd("eventEntity.id=="+rs.Primary.ID, "timestamp=gt="+since.Format(time.RFC3339))
+		events := []*vmAuditTrailEvent{{}}
+		err = client.OpenApiGetAllItems(vmAuditTrailMinApiVersion, urlRef, queryParameters, &events, nil)
+		if err != nil {
+			return fmt.Errorf("error retrieving audit trail events of VM %s: %s", rs.Primary.ID, err)
+		}
+
+		for _, event := range events {
+			eventType := strings.ToLower(event.EventType)
+			if strings.Contains(eventType, "deploy") && !strings.Contains(eventType, "undeploy") ||
+				strings.Contains(eventType, "poweron") {
+				return fmt.Errorf("VM %s was powered on at %s (%s)", resourceName, event.Timestamp, event.EventType)
+			}
+		}
+		return nil
+	}
+}
+
+const testAccVcdVmPowerState = `
+resource "vcd_vapp" "power" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  name              = "{{.VappName}}"
+  power_state       = "{{.PowerState}}"
+  shutdown_behavior = "guest_shutdown"
+  shutdown_timeout  = 10
+}
+
+resource "vcd_vapp_vm" "power" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name         = vcd_vapp.power.name
+  name              = "{{.VappVmName}}"
+  power_state       = "{{.VmPowerState}}"
+  shutdown_behavior = "guest_shutdown"
+  shutdown_timeout  = 10
+
+  memory           = 512
+  cpus             = 1
+  cpu_cores        = 1
+  os_type          = "sles11_64Guest"
+  hardware_version = "vmx-14"
+  computer_name    = "vapp-power"
+
+  metadata = {
+    step = "{{.MetadataStep}}"
+  }
+}
+
+resource "vcd_vm" "power" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  name              = "{{.VmName}}"
+  power_state       = "{{.VmPowerState}}"
+  shutdown_behavior = "guest_shutdown"
+  shutdown_timeout  = 10
+
+  memory           = 512
+  cpus             = 1
+  cpu_cores        = 1
+  os_type          = "sles11_64Guest"
+  hardware_version = "vmx-14"
+  computer_name    = "vm-power"
+
+  metadata = {
+    step = "{{.MetadataStep}}"
+  }
+}
+`
@@ -0,0 +1,323 @@
+package vcd
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// vmSnapshotCreateParamsMime is the content type of createSnapshot action payload
+const vmSnapshotCreateParamsMime = "application/vnd.vmware.vcloud.createSnapshotParams+xml"
+
+// vmCreateSnapshotParams is the payload for VM createSnapshot action which is not available in SDK
+type vmCreateSnapshotParams struct {
+	XMLName xml.Name `xml:"CreateSnapshotParams"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	// Memory defines if memory of a powered on VM should be included in snapshot
+	Memory bool `xml:"memory,attr"`
+	// Quiesce defines if file system of a powered on VM should be quiesced using VMware Tools
+	Quiesce bool `xml:"quiesce,attr"`
+}
+
+func resourceVcdVmSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdVmSnapshotCreate,
+		ReadContext:   resourceVcdVmSnapshotRead,
+		UpdateContext: resourceVcdVmSnapshotUpdate,
+		DeleteContext: resourceVcdVmSnapshotDelete,
+		CustomizeDiff: resourceVcdVmSnapshotCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdVmSnapshotImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The name of VDC to use, optional if defined at provider level",
+			},
+			"vapp_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The vApp this VM belongs to. Use the VM name for standalone VMs",
+			},
+			"vm_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "VM for which the snapshot is created",
+			},
+			"memory": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Include memory of a powered on VM in the snapshot",
+			},
+			"quiesce": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Quiesce file system of a powered on VM before taking the snapshot (requires VMware Tools)",
+			},
+			"revert_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Revert VM to the snapshot before removing it on destroy",
+			},
+			"revert_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Any change of this value reverts VM to the snapshot",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation date of the snapshot",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size of the snapshot in bytes",
+			},
+			"powered_on": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Shows if VM was powered on when the snapshot was taken",
+			},
+		},
+	}
+}
+
+// resourceVcdVmSnapshotCustomizeDiff fails plan when a snapshot is about to be created for a VM
+// which already has one. VCD supports only one snapshot per VM and creating a new one silently
+// replaces the existing snapshot.
+func resourceVcdVmSnapshotCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// Only new snapshots are validated. VM may not exist yet if it is created in the same plan,
+	// in which case its name is unknown or the lookup below does not find it.
+	if d.Id() != "" || !d.NewValueKnown("vapp_name") || !d.NewValueKnown("vm_name") {
+		return nil
+	}
+
+	vcdClient := meta.(*VCDClient)
+	_, vdc, err := vcdClient.GetOrgAndVdc(d.Get("org").(string), d.Get("vdc").(string))
+	if err != nil {
+		return nil
+	}
+
+	vm, err := getVmByVappAndVmName(vdc, d.Get("vapp_name").(string), d.Get("vm_name").(string))
+	if err != nil {
+		return nil
+	}
+
+	if vmHasSnapshot(vm) {
+		return fmt.Errorf("VM '%s' already has a snapshot and VCD supports only one snapshot per VM. "+
+			"Remove the existing snapshot or import it into 'vcd_vm_snapshot'", vm.VM.Name)
+	}
+
+	return nil
+}
+
+func resourceVcdVmSnapshotCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	vcdClient.lockParentVm(d)
+	defer vcdClient.unLockParentVm(d)
+
+	vm, _, err := getVm(vcdClient, d)
+	if err != nil {
+		return diag.Errorf("[vm snapshot create] %s", err)
+	}
+
+	// Existing snapshot could have been created after plan
+	if vmHasSnapshot(vm) {
+		return diag.Errorf("[vm snapshot create] VM '%s' already has a snapshot and VCD supports only one snapshot per VM",
+			vm.VM.Name)
+	}
+
+	params := &vmCreateSnapshotParams{
+		Xmlns:   types.XMLNamespaceVCloud,
+		Memory:  d.Get("memory").(bool),
+		Quiesce: d.Get("quiesce").(bool),
+	}
+
+	err = executeVmSnapshotAction(&vcdClient.Client, vm, "createSnapshot", vmSnapshotCreateParamsMime, params)
+	if err != nil {
+		return diag.Errorf("[vm snapshot create] error creating snapshot for VM '%s': %s", vm.VM.Name, err)
+	}
+
+	// VCD supports only one snapshot per VM therefore VM ID identifies the snapshot
+	d.SetId(vm.VM.ID)
+
+	return resourceVcdVmSnapshotRead(ctx, d, meta)
+}
+
+func resourceVcdVmSnapshotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	vm, _, err := getVm(vcdClient, d)
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] VM for snapshot '%s' not found. Removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[vm snapshot read] %s", err)
+	}
+
+	if !vmHasSnapshot(vm) {
+		log.Printf("[DEBUG] VM '%s' does not have a snapshot anymore. Removing from state", vm.VM.Name)
+		d.SetId("")
+		return nil
+	}
+
+	snapshot := vm.VM.Snapshots.Snapshot[0]
+	dSet(d, "created", snapshot.Created)
+	dSet(d, "size", snapshot.Size)
+	dSet(d, "powered_on", snapshot.PoweredOn)
+	d.SetId(vm.VM.ID)
+
+	return nil
+}
+
+// resourceVcdVmSnapshotUpdate only handles 'revert_trigger' as all other fields either force a new
+// resource or are only used on destroy
+func resourceVcdVmSnapshotUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	if !d.HasChange("revert_trigger") {
+		return resourceVcdVmSnapshotRead(ctx, d, meta)
+	}
+
+	vcdClient.lockParentVm(d)
+	defer vcdClient.unLockParentVm(d)
+
+	vm, _, err := getVm(vcdClient, d)
+	if err != nil {
+		return diag.Errorf("[vm snapshot update] %s", err)
+	}
+
+	err = executeVmSnapshotAction(&vcdClient.Client, vm, "revertToCurrentSnapshot", "", nil)
+	if err != nil {
+		return diag.Errorf("[vm snapshot update] error reverting VM '%s' to snapshot: %s", vm.VM.Name, err)
+	}
+
+	return resourceVcdVmSnapshotRead(ctx, d, meta)
+}
+
+func resourceVcdVmSnapshotDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	vcdClient.lockParentVm(d)
+	defer vcdClient.unLockParentVm(d)
+
+	vm, _, err := getVm(vcdClient, d)
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			return nil
+		}
+		return diag.Errorf("[vm snapshot delete] %s", err)
+	}
+
+	if !vmHasSnapshot(vm) {
+		return nil
+	}
+
+	if d.Get("revert_on_destroy").(bool) {
+		err = executeVmSnapshotAction(&vcdClient.Client, vm, "revertToCurrentSnapshot", "", nil)
+		if err != nil {
+			return diag.Errorf("[vm snapshot delete] error reverting VM '%s' to snapshot: %s", vm.VM.Name, err)
+		}
+	}
+
+	err = executeVmSnapshotAction(&vcdClient.Client, vm, "removeAllSnapshots", "", nil)
+	if err != nil {
+		return diag.Errorf("[vm snapshot delete] error removing snapshot of VM '%s': %s", vm.VM.Name, err)
+	}
+
+	return nil
+}
+
+// resourceVcdVmSnapshotImport is responsible for importing the resource.
+// The d.ID() field as being passed from `terraform import _resource_name_ _the_id_string_ requires
+// a name based dot-formatted path to the object to lookup the object and sets the id of object.
+// `terraform import` automatically performs `refresh` operation which loads up all other fields.
+//
+// Example import path (id): org.vdc.vapp-name.vm-name
+// Example import command:   terraform import vcd_vm_snapshot.snapshot-name org.vdc.vapp-name.vm-name
+func resourceVcdVmSnapshotImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	if len(resourceURI) != 4 {
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.vapp-name.vm-name")
+	}
+	orgName, vdcName, vappName, vmName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
+
+	vcdClient := meta.(*VCDClient)
+	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
+	if err != nil {
+		return nil, fmt.Errorf(errorRetrievingOrgAndVdc, err)
+	}
+
+	vm, err := getVmByVappAndVmName(vdc, vappName, vmName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !vmHasSnapshot(vm) {
+		return nil, fmt.Errorf("VM '%s' does not have a snapshot", vmName)
+	}
+
+	dSet(d, "org", orgName)
+	dSet(d, "vdc", vdcName)
+	dSet(d, "vapp_name", vappName)
+	dSet(d, "vm_name", vmName)
+	d.SetId(vm.VM.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func getVmByVappAndVmName(vdc *govcd.Vdc, vappName, vmName string) (*govcd.VM, error) {
+	vapp, err := vdc.GetVAppByName(vappName, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vApp '%s': %s", vappName, err)
+	}
+	vm, err := vapp.GetVMByName(vmName, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VM '%s': %s", vmName, err)
+	}
+	return vm, nil
+}
+
+func vmHasSnapshot(vm *govcd.VM) bool {
+	return vm.VM.Snapshots != nil && len(vm.VM.Snapshots.Snapshot) > 0
+}
+
+// executeVmSnapshotAction runs one of VM snapshot actions ('createSnapshot',
+// 'revertToCurrentSnapshot', 'removeAllSnapshots') and waits for the task to finish
+func executeVmSnapshotAction(client *govcd.Client, vm *govcd.VM, action, contentType string, payload interface{}) error {
+	task, err := client.ExecuteTaskRequest(vm.VM.HREF+"/action/"+action, http.MethodPost,
+		contentType, "error executing VM snapshot action: %s", payload)
+	if err != nil {
+		return err
+	}
+
+	return task.WaitTaskCompletion()
+}
@@ -0,0 +1,133 @@
+//go:build vapp || vm || ALL || functional
+// +build vapp vm ALL functional
+
+package vcd
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccVcdVmSnapshot(t *testing.T) {
+	preTestChecks(t)
+
+	var params = StringMap{
+		"Org":           testConfig.VCD.Org,
+		"Vdc":           testConfig.VCD.Vdc,
+		"FuncName":      t.Name(),
+		"Tags":          "vm",
+		"VmName":        t.Name() + "-vm",
+		"RevertTrigger": "1",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText1 := templateFill(testAccVcdVmSnapshot, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "-step2"
+	params["RevertTrigger"] = "2"
+	configText2 := templateFill(testAccVcdVmSnapshot, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	params["FuncName"] = t.Name() + "-step4"
+	configText4 := templateFill(testAccVcdVmSnapshotDuplicate, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 4: %s", configText4)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_vm_snapshot.snap"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckVcdStandaloneVmDestroy(params["VmName"].(string), params["Org"].(string), params["Vdc"].(string)),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "id", "vcd_vm.snap", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "created"),
+					resource.TestCheckResourceAttrSet(resourceName, "size"),
+					resource.TestCheckResourceAttr(resourceName, "powered_on", "false"),
+				),
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "revert_trigger", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "created"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       importStateIdVmSnapshot(resourceName),
+				ImportStateVerifyIgnore: []string{"memory", "quiesce", "revert_on_destroy", "revert_trigger"},
+			},
+			{
+				Config:      configText4,
+				ExpectError: regexp.MustCompile(`already has a snapshot`),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+// importStateIdVmSnapshot builds import path using vApp name of a standalone VM which is only known
+// after it is created
+func importStateIdVmSnapshot(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found", resourceName)
+		}
+		return testConfig.VCD.Org + ImportSeparator + testConfig.VCD.Vdc + ImportSeparator +
+			rs.Primary.Attributes["vapp_name"] + ImportSeparator + rs.Primary.Attributes["vm_name"], nil
+	}
+}
+
+const testAccVcdVmSnapshot = `
+resource "vcd_vm" "snap" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  power_on  = false
+  name      = "{{.VmName}}"
+  memory    = 512
+  cpus      = 1
+  cpu_cores = 1
+
+  os_type          = "sles11_64Guest"
+  hardware_version = "vmx-14"
+  computer_name    = "snapshot"
+}
+
+resource "vcd_vm_snapshot" "snap" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name = vcd_vm.snap.vapp_name
+  vm_name   = vcd_vm.snap.name
+
+  revert_on_destroy = true
+  revert_trigger    = "{{.RevertTrigger}}"
+}
+`
+
+const testAccVcdVmSnapshotDuplicate = testAccVcdVmSnapshot + `
+resource "vcd_vm_snapshot" "duplicate" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name = vcd_vm.snap.vapp_name
+  vm_name   = vcd_vm.snap.name
+}
+`
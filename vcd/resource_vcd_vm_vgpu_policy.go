@@ -0,0 +1,425 @@
+package vcd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// vmVgpuPolicyMinApiVersion is the first API version which supports vGPU policies (VCD 10.4.0)
+const vmVgpuPolicyMinApiVersion = "37.0"
+
+// vdcComputePolicyV2ApiVersions lists API versions used for vdcComputePolicyV2 type
+var vdcComputePolicyV2ApiVersions = []string{vmVgpuPolicyMinApiVersion}
+
+// vdcComputePolicyV2 extends types.VdcComputePolicy with fields of the 2.0.0 endpoint which define
+// vGPU policies
+type vdcComputePolicyV2 struct {
+	types.VdcComputePolicy
+	// PolicyType is 'VdcVmPolicy' for vGPU policies
+	PolicyType   string `json:"policyType,omitempty"`
+	IsVgpuPolicy bool   `json:"isVgpuPolicy,omitempty"`
+	// PvdcVgpuClustersMap limits the vGPU policy to clusters of Provider VDCs
+	PvdcVgpuClustersMap []vdcComputePolicyVgpuClusters `json:"pvdcVgpuClustersMap,omitempty"`
+	VgpuProfiles        []vdcComputePolicyVgpuProfile  `json:"vgpuProfiles,omitempty"`
+}
+
+// vdcComputePolicyVgpuClusters defines the clusters of a Provider VDC where VMs with a vGPU policy
+// can be placed
+type vdcComputePolicyVgpuClusters struct {
+	PvdcRef  *types.OpenApiReference `json:"pvdcRef"`
+	Clusters []string                `json:"clusters,omitempty"`
+}
+
+// vdcComputePolicyVgpuProfile defines a vGPU profile of a vGPU policy and the number of vGPUs it
+// provides to a VM
+type vdcComputePolicyVgpuProfile struct {
+	ID    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Count int    `json:"count"`
+}
+
+func resourceVcdVmVgpuPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVmVgpuPolicyCreate,
+		ReadContext:   resourceVmVgpuPolicyRead,
+		UpdateContext: resourceVmVgpuPolicyUpdate,
+		DeleteContext: resourceVmVgpuPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVmVgpuPolicyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the vGPU policy",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the vGPU policy",
+			},
+			"vgpu_profile": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "vGPU profile which is provided to the VMs with this policy",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "ID of the vGPU profile",
+						},
+						"count": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							Description:  "Number of vGPUs of the profile provided to each VM",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+			"provider_vdc_scope": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Provider VDCs and their clusters where the VMs with this policy can be placed",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"provider_vdc_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "ID of the Provider VDC",
+						},
+						"cluster_names": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "Names of the Provider VDC clusters with vGPU devices",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceVmVgpuPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	policyName := d.Get("name").(string)
+	log.Printf("[TRACE] vGPU policy creation initiated: %s", policyName)
+
+	vcdClient := meta.(*VCDClient)
+
+	if !vcdClient.Client.IsSysAdmin {
+		return diag.Errorf("functionality requires System administrator privileges")
+	}
+
+	params := &vdcComputePolicyV2{
+		VdcComputePolicy: types.VdcComputePolicy{
+			Name:        policyName,
+			Description: d.Get("description").(string),
+		},
+		PolicyType:   "VdcVmPolicy",
+		IsVgpuPolicy: true,
+	}
+	setVmVgpuPolicyProfileAndScope(d, params)
+
+	log.Printf("[DEBUG] Creating vGPU policy: %#v", params)
+
+	createdPolicy := &vdcComputePolicyV2{}
+	err := openApiPostExtendedItem(&vcdClient.Client, vdcComputePolicyV2ApiVersions, params, createdPolicy,
+		openApiPathVersion2_0_0, types.OpenApiEndpointVdcComputePolicies)
+	if err != nil {
+		log.Printf("[DEBUG] Error creating vGPU policy: %s", err)
+		return diag.Errorf("error creating vGPU policy %s: %s", policyName, err)
+	}
+
+	d.SetId(createdPolicy.ID)
+	log.Printf("[TRACE] vGPU policy created: %#v", createdPolicy)
+
+	return resourceVmVgpuPolicyRead(ctx, d, meta)
+}
+
+// setVmVgpuPolicyProfileAndScope fills the vGPU profile and Provider VDC scope of the policy from the
+// resource configuration
+func setVmVgpuPolicyProfileAndScope(d *schema.ResourceData, policy *vdcComputePolicyV2) {
+	policy.VgpuProfiles = nil
+	for _, profile := range d.Get("vgpu_profile").([]interface{}) {
+		profileMap := profile.(map[string]interface{})
+		policy.VgpuProfiles = append(policy.VgpuProfiles, vdcComputePolicyVgpuProfile{
+			ID:    profileMap["id"].(string),
+			Count: profileMap["count"].(int),
+		})
+	}
+
+	policy.PvdcVgpuClustersMap = nil
+	for _, scope := range d.Get("provider_vdc_scope").(*schema.Set).List() {
+		scopeMap := scope.(map[string]interface{})
+		policy.PvdcVgpuClustersMap = append(policy.PvdcVgpuClustersMap, vdcComputePolicyVgpuClusters{
+			PvdcRef:  &types.OpenApiReference{ID: scopeMap["provider_vdc_id"].(string)},
+			Clusters: convertSchemaSetToSliceOfStrings(scopeMap["cluster_names"].(*schema.Set)),
+		})
+	}
+}
+
+func resourceVmVgpuPolicyRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	policyName := d.Get("name").(string)
+	log.Printf("[TRACE] vGPU policy read initiated: %s", policyName)
+
+	vcdClient := meta.(*VCDClient)
+
+	policy, err := getVdcComputePolicyV2ById(&vcdClient.Client, d.Id())
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] Unable to find vGPU policy %s. Removing from tfstate", policyName)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("unable to find vGPU policy %s, err: %s", policyName, err)
+	}
+
+	dSet(d, "name", policy.Name)
+	dSet(d, "description", policy.Description)
+
+	vgpuProfiles := make([]interface{}, len(policy.VgpuProfiles))
+	for index, profile := range policy.VgpuProfiles {
+		vgpuProfiles[index] = map[string]interface{}{
+			"id":    profile.ID,
+			"count": profile.Count,
+		}
+	}
+	err = d.Set("vgpu_profile", vgpuProfiles)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	providerVdcScopes := make([]interface{}, 0, len(policy.PvdcVgpuClustersMap))
+	for _, scope := range policy.PvdcVgpuClustersMap {
+		if scope.PvdcRef == nil {
+			continue
+		}
+		providerVdcScopes = append(providerVdcScopes, map[string]interface{}{
+			"provider_vdc_id": scope.PvdcRef.ID,
+			"cluster_names":   convertStringsToTypeSet(scope.Clusters),
+		})
+	}
+	err = d.Set("provider_vdc_scope", providerVdcScopes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[TRACE] vGPU policy read completed: %s", policyName)
+	return nil
+}
+
+func resourceVmVgpuPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	policyName := d.Get("name").(string)
+	log.Printf("[TRACE] vGPU policy update initiated: %s", policyName)
+
+	vcdClient := meta.(*VCDClient)
+
+	policy, err := getVdcComputePolicyV2ById(&vcdClient.Client, d.Id())
+	if err != nil {
+		log.Printf("[DEBUG] Unable to find vGPU policy %s", policyName)
+		return diag.Errorf("unable to find vGPU policy %s, error:  %s", policyName, err)
+	}
+
+	policy.Name = policyName
+	policy.Description = d.Get("description").(string)
+	if d.HasChanges("vgpu_profile", "provider_vdc_scope") {
+		setVmVgpuPolicyProfileAndScope(d, policy)
+	}
+
+	updatedPolicy := &vdcComputePolicyV2{}
+	err = openApiPutExtendedItem(&vcdClient.Client, vdcComputePolicyV2ApiVersions, policy, updatedPolicy,
+		openApiPathVersion2_0_0, types.OpenApiEndpointVdcComputePolicies, d.Id())
+	if err != nil {
+		log.Printf("[DEBUG] Error updating vGPU policy %s with error %s", policyName, err)
+		return diag.Errorf("error updating vGPU policy %s, err: %s", policyName, err)
+	}
+
+	log.Printf("[TRACE] vGPU policy update completed: %s", policyName)
+	return resourceVmVgpuPolicyRead(ctx, d, meta)
+}
+
+func resourceVmVgpuPolicyDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	policyName := d.Get("name").(string)
+	log.Printf("[TRACE] vGPU policy delete started: %s", policyName)
+
+	vcdClient := meta.(*VCDClient)
+
+	if !vcdClient.Client.IsSysAdmin {
+		return diag.Errorf("functionality requires System administrator privileges")
+	}
+
+	urlRef, err := openApiBuildEndpointWithVersion(&vcdClient.Client, vmVgpuPolicyMinApiVersion,
+		openApiPathVersion2_0_0, types.OpenApiEndpointVdcComputePolicies, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = vcdClient.Client.OpenApiDeleteItem(vmVgpuPolicyMinApiVersion, urlRef, nil, nil)
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] Unable to find vGPU policy %s. Removing from tfstate", policyName)
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] Error removing vGPU policy %s, err: %s", policyName, err)
+		return diag.Errorf("error removing vGPU policy %s, err: %s", policyName, err)
+	}
+
+	log.Printf("[TRACE] vGPU policy delete completed: %s", policyName)
+	return nil
+}
+
+var errHelpVmVgpuPolicyImport = fmt.Errorf(`resource id must be specified in one of these formats:
+'vgpu-policy-name', 'vgpu-policy-id' or 'list@' to get a list of vGPU policies with their IDs`)
+
+// resourceVmVgpuPolicyImport is responsible for importing the resource.
+// The following steps happen as part of import
+// 1. The user supplies `terraform import _resource_name_ _the_id_string_` command
+// 2. `_the_id_string_` contains the name or the ID of the vGPU policy
+// 3. The function looks up the object
+// 4. If the lookup succeeds it sets the ID field for `_resource_name_` resource in state file
+// (the resource must be already defined in .tf config otherwise `terraform import` will complain)
+// 5. `terraform refresh` is being implicitly launched. The Read method looks up all other fields
+// based on the known ID of object.
+//
+// Example resource name (_resource_name_): vcd_vm_vgpu_policy.my_existing_policy_name
+// Example import path (_the_id_string_): my_existing_vgpu_policy_id
+// Example list path (_the_id_string_): list@
+func resourceVmVgpuPolicyImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Printf("[DEBUG] importing vGPU policy resource with provided id %s", d.Id())
+
+	vcdClient := meta.(*VCDClient)
+	if strings.Contains(d.Id(), "list@") {
+		return listVmVgpuPoliciesForImport(vcdClient)
+	}
+
+	policyId := d.Id()
+	policy, err := getVdcComputePolicyV2ById(&vcdClient.Client, policyId)
+	if err != nil {
+		policies, err := getAllVmVgpuPolicies(&vcdClient.Client)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find vGPU policy %s, err: %s", policyId, err)
+		}
+		var foundPolicies []*vdcComputePolicyV2
+		for _, candidate := range policies {
+			if candidate.Name == policyId {
+				foundPolicies = append(foundPolicies, candidate)
+			}
+		}
+		if len(foundPolicies) != 1 {
+			return nil, fmt.Errorf("unable to find unique vGPU policy %s, found %d", policyId, len(foundPolicies))
+		}
+		policy = foundPolicies[0]
+	}
+	if !policy.IsVgpuPolicy {
+		return nil, fmt.Errorf("compute policy %s is not a vGPU policy", policyId)
+	}
+
+	dSet(d, "name", policy.Name)
+	d.SetId(policy.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func listVmVgpuPoliciesForImport(vcdClient *VCDClient) ([]*schema.ResourceData, error) {
+	buf := new(bytes.Buffer)
+	_, err := fmt.Fprintln(buf, "Retrieving all vGPU policies")
+	if err != nil {
+		logForScreen("vcd_vm_vgpu_policy", fmt.Sprintf("error writing to buffer: %s", err))
+	}
+	policies, err := getAllVmVgpuPolicies(&vcdClient.Client)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve vGPU policies: %s", err)
+	}
+
+	writer := tabwriter.NewWriter(buf, 0, 8, 1, '\t', tabwriter.AlignRight)
+
+	_, err = fmt.Fprintln(writer, "No\tID\tName\t")
+	if err != nil {
+		logForScreen("vcd_vm_vgpu_policy", fmt.Sprintf("error writing to buffer: %s", err))
+	}
+	_, err = fmt.Fprintln(writer, "--\t--\t----\t")
+	if err != nil {
+		logForScreen("vcd_vm_vgpu_policy", fmt.Sprintf("error writing to buffer: %s", err))
+	}
+
+	for index, policy := range policies {
+		_, err = fmt.Fprintf(writer, "%d\t%s\t%s \n", index+1, policy.ID, policy.Name)
+		if err != nil {
+			logForScreen("vcd_vm_vgpu_policy", fmt.Sprintf("error writing to buffer: %s", err))
+		}
+	}
+	err = writer.Flush()
+	if err != nil {
+		logForScreen("vcd_vm_vgpu_policy", fmt.Sprintf("error flushing buffer: %s", err))
+	}
+
+	return nil, fmt.Errorf("resource was not imported! %s\n%s", errHelpVmVgpuPolicyImport, buf.String())
+}
+
+func getVdcComputePolicyV2ById(client *govcd.Client, id string) (*vdcComputePolicyV2, error) {
+	policy := &vdcComputePolicyV2{}
+	err := openApiGetExtendedItem(client, vdcComputePolicyV2ApiVersions, policy,
+		openApiPathVersion2_0_0, types.OpenApiEndpointVdcComputePolicies, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// getAllVmVgpuPolicies retrieves all compute policies and returns the vGPU ones
+func getAllVmVgpuPolicies(client *govcd.Client) ([]*vdcComputePolicyV2, error) {
+	allPolicies := []*vdcComputePolicyV2{{}}
+	err := openApiGetAllExtendedItems(client, vdcComputePolicyV2ApiVersions, nil, &allPolicies,
+		openApiPathVersion2_0_0, types.OpenApiEndpointVdcComputePolicies)
+	if err != nil {
+		return nil, err
+	}
+
+	var vgpuPolicies []*vdcComputePolicyV2
+	for _, policy := range allPolicies {
+		if policy.IsVgpuPolicy {
+			vgpuPolicies = append(vgpuPolicies, policy)
+		}
+	}
+
+	return vgpuPolicies, nil
+}
+
+// getAssignedVdcVgpuPolicyIds returns IDs of the vGPU policies assigned to a VDC. It returns an empty
+// map for VCD versions which do not support vGPU policies
+func getAssignedVdcVgpuPolicyIds(client *govcd.Client, vdcId string) (map[string]bool, error) {
+	vgpuPolicyIds := make(map[string]bool)
+	if client.APIVCDMaxVersionIs("< " + vmVgpuPolicyMinApiVersion) {
+		return vgpuPolicyIds, nil
+	}
+
+	assignedPolicies := []*vdcComputePolicyV2{{}}
+	err := openApiGetAllExtendedItems(client, vdcComputePolicyV2ApiVersions, nil, &assignedPolicies,
+		openApiPathVersion2_0_0, fmt.Sprintf(types.OpenApiEndpointVdcAssignedComputePolicies, vdcId))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, policy := range assignedPolicies {
+		if policy.IsVgpuPolicy {
+			vgpuPolicyIds[policy.ID] = true
+		}
+	}
+
+	return vgpuPolicyIds, nil
+}
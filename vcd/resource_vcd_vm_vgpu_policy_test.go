@@ -0,0 +1,162 @@
+//go:build vdc || ALL || functional
+// +build vdc ALL functional
+
+package vcd
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccVcdVmVgpuPolicy checks that a vGPU policy can be created, updated, assigned to and removed
+// from a VDC and imported
+func TestAccVcdVmVgpuPolicy(t *testing.T) {
+	preTestChecks(t)
+	if !usingSysAdmin() {
+		t.Skip(t.Name() + " requires system admin privileges")
+	}
+	if testConfig.VCD.ProviderVdc.Name == "" || testConfig.VCD.ProviderVdc.VgpuProfileId == "" {
+		t.Skip("Variables providerVdc.Name and providerVdc.vgpuProfileId must be set to run " + t.Name())
+	}
+
+	vcdClient := createTemporaryVCDConnection(false)
+	if vcdClient.Client.APIVCDMaxVersionIs("< " + vmVgpuPolicyMinApiVersion) {
+		t.Skip(t.Name() + " requires VCD 10.4.0+")
+	}
+
+	var params = StringMap{
+		"Org":                       testConfig.VCD.Org,
+		"VdcName":                   t.Name(),
+		"PolicyName":                t.Name(),
+		"Description":               t.Name() + "-description",
+		"ProviderVdc":               testConfig.VCD.ProviderVdc.Name,
+		"NetworkPool":               testConfig.VCD.ProviderVdc.NetworkPool,
+		"ProviderVdcStorageProfile": testConfig.VCD.ProviderVdc.StorageProfile,
+		"VgpuProfileId":             testConfig.VCD.ProviderVdc.VgpuProfileId,
+		"VgpuPolicyIds":             "[vcd_vm_vgpu_policy.policy.id]",
+		"FuncName":                  t.Name(),
+		"Tags":                      "vdc",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText1 := templateFill(testAccVcdVmVgpuPolicy, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "-update"
+	params["Description"] = t.Name() + "-description-updated"
+	params["VgpuPolicyIds"] = "[]"
+	configText2 := templateFill(testAccVcdVmVgpuPolicy, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_vm_vgpu_policy.policy"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckVmVgpuPolicyDestroyed,
+			testAccCheckVdcDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile(`^urn:vcloud:vdcComputePolicy:`)),
+					resource.TestCheckResourceAttr(resourceName, "name", t.Name()),
+					resource.TestCheckResourceAttr(resourceName, "description", t.Name()+"-description"),
+					resource.TestCheckResourceAttr(resourceName, "vgpu_profile.0.id", testConfig.VCD.ProviderVdc.VgpuProfileId),
+					resource.TestCheckResourceAttr(resourceName, "vgpu_profile.0.count", "1"),
+					resource.TestCheckResourceAttr("vcd_org_vdc.vdc", "vm_vgpu_policy_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("vcd_org_vdc.vdc", "vm_vgpu_policy_ids.*", resourceName, "id"),
+					resource.TestCheckResourceAttr("vcd_org_vdc.vdc", "vm_placement_policy_ids.#", "0"),
+				),
+			},
+			// An empty set removes the vGPU policy from the VDC
+			{
+				Config: configText2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", t.Name()+"-description-updated"),
+					resource.TestCheckResourceAttr("vcd_org_vdc.vdc", "vm_vgpu_policy_ids.#", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     t.Name(),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+func testAccCheckVmVgpuPolicyDestroyed(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*VCDClient)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vcd_vm_vgpu_policy" {
+			continue
+		}
+		_, err := getVdcComputePolicyV2ById(&conn.Client, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("vGPU policy %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+const testAccVcdVmVgpuPolicy = `
+resource "vcd_vm_vgpu_policy" "policy" {
+  name        = "{{.PolicyName}}"
+  description = "{{.Description}}"
+
+  vgpu_profile {
+    id    = "{{.VgpuProfileId}}"
+    count = 1
+  }
+}
+
+resource "vcd_org_vdc" "vdc" {
+  name = "{{.VdcName}}"
+  org  = "{{.Org}}"
+
+  allocation_model  = "Flex"
+  network_pool_name = "{{.NetworkPool}}"
+  provider_vdc_name = "{{.ProviderVdc}}"
+
+  compute_capacity {
+    cpu {
+      allocated = 1024
+      limit     = 1024
+    }
+
+    memory {
+      allocated = 1024
+      limit     = 1024
+    }
+  }
+
+  storage_profile {
+    name    = "{{.ProviderVdcStorageProfile}}"
+    enabled = true
+    limit   = 10240
+    default = true
+  }
+
+  enabled                    = true
+  enable_thin_provisioning   = true
+  enable_fast_provisioning   = true
+  delete_force               = true
+  delete_recursive           = true
+  elasticity                 = false
+  include_vm_memory_overhead = false
+
+  vm_vgpu_policy_ids = {{.VgpuPolicyIds}}
+}
+`
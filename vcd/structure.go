@@ -69,6 +69,17 @@ func convertSchemaSetToSliceOfStrings(param *schema.Set) []string {
 	return result
 }
 
+// convertToStringSlice accepts a slice of interfaces (e.g. value of Terraform's TypeList of strings)
+// and converts it to slice of strings
+func convertToStringSlice(param []interface{}) []string {
+	result := make([]string, len(param))
+	for index, value := range param {
+		result[index] = fmt.Sprint(value)
+	}
+
+	return result
+}
+
 // convertStringsToTypeSet accepts a slice of strings and returns a *schema.Set suitable for storing in Terraform
 // set of strings
 func convertStringsToTypeSet(param []string) *schema.Set {
@@ -166,3 +166,24 @@ func resourceFieldIntNotEqual(object, field string, notEqualTo int) resource.Tes
 		return nil
 	}
 }
+
+// resourceFieldsNotEqual checks that field1 of object1 and field2 of object2 have different values
+func resourceFieldsNotEqual(object1, field1, object2, field2 string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resource1, ok := s.RootModule().Resources[object1]
+		if !ok {
+			return fmt.Errorf("unable to find %s", object1)
+		}
+
+		resource2, ok := s.RootModule().Resources[object2]
+		if !ok {
+			return fmt.Errorf("unable to find %s", object2)
+		}
+
+		value1, value2 := resource1.Primary.Attributes[field1], resource2.Primary.Attributes[field2]
+		if value1 == value2 {
+			return fmt.Errorf("field %s of %s and field %s of %s have the same value %s", field1, object1, field2, object2, value1)
+		}
+		return nil
+	}
+}